@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/kndrad/piccrack/config"
 	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
 	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/tesseract"
 	"github.com/kndrad/piccrack/pkg/retry"
+	"github.com/kndrad/piccrack/pkg/textproc"
 	"github.com/spf13/cobra"
 
 	"github.com/kndrad/piccrack/cmd/logger"
@@ -54,11 +58,36 @@ var startCmd = &cobra.Command{
 		}
 		defer db.Close(ctx)
 
+		aliases, err := textproc.LoadAliases(cfg.Text.Aliases)
+		if err != nil {
+			l.Error("Loading aliases", "err", err.Error())
+
+			return fmt.Errorf("load aliases: %w", err)
+		}
+
 		q := database.New(db)
-		svc := apiv1.NewService(q, l)
+		svcOpts := []apiv1.ServiceOption{apiv1.WithAliases(aliases), apiv1.WithTransactions(db)}
+		if cfg.Text.Stemming {
+			svcOpts = append(svcOpts, apiv1.WithStemming())
+		}
+		svc := apiv1.NewService(q, l, svcOpts...)
+
+		var e ocr.Engine = tesseract.NewPool(cfg.OCR.Workers)
+		defer e.Close()
+
+		if cfg.OCR.CacheSize > 0 {
+			e = ocr.NewCachedEngine(e, ocr.NewMemoryCache(cfg.OCR.CacheSize))
+		}
+
+		opts, err := ocrOptions(cfg.OCR)
+		if err != nil {
+			l.Error("Invalid ocr config", "err", err)
+
+			return fmt.Errorf("ocr options: %w", err)
+		}
 
 		// Create server instance
-		srv, err := apiv1.NewServer(cfg.HTTP, svc, l)
+		srv, err := apiv1.NewServer(cfg.HTTP, svc, e, opts, l)
 		if err != nil {
 			l.Error("Failed to init new http server", "err", err)
 
@@ -79,3 +108,32 @@ var startCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(startCmd)
 }
+
+// ocrOptions returns recognition options used by the server.
+func ocrOptions(cfg config.OCRConfig) (ocr.Options, error) {
+	opts := ocr.DefaultOptions()
+
+	langs, err := ocr.ParseLanguages(cfg.Languages)
+	if err != nil {
+		return opts, fmt.Errorf("parse languages: %w", err)
+	}
+	mode := ocr.PageSegMode(cfg.PageSegMode)
+	if !mode.Valid() {
+		return opts, fmt.Errorf("invalid page seg mode: %d", cfg.PageSegMode)
+	}
+
+	opts.Languages = langs
+	opts.Whitelist = cfg.Whitelist
+	opts.PageSegMode = mode
+	opts.MinConfidence = cfg.MinConfidence
+
+	if cfg.Timeout != "" {
+		timeout, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return opts, fmt.Errorf("parse timeout: %w", err)
+		}
+		opts.Timeout = timeout
+	}
+
+	return opts, nil
+}
@@ -4,13 +4,21 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"time"
 
 	"github.com/kndrad/piccrack/cmd/logger"
+	"github.com/kndrad/piccrack/pkg/imgprep"
 	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/tesseract"
 	"github.com/kndrad/piccrack/pkg/picphrase"
 	"github.com/spf13/cobra"
 )
 
+// cacheDir is a directory of ocr results cache within output directory.
+const cacheDir = "ocr-cache"
+
 var phrasesCmd = &cobra.Command{
 	Use: "phrases",
 
@@ -21,39 +29,158 @@ var phrasesCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("get string: %w", err)
 		}
+		workers, err := cmd.Flags().GetInt("workers")
+		if err != nil {
+			return fmt.Errorf("get int: %w", err)
+		}
+		opts := ocr.DefaultOptions()
+		opts.MinConfidence, err = cmd.Flags().GetFloat64("min-confidence")
+		if err != nil {
+			return fmt.Errorf("get float64: %w", err)
+		}
+		preprocess, err := cmd.Flags().GetString("preprocess")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		opts.Preprocess, err = imgprep.ParseSteps(preprocess)
+		if err != nil {
+			return fmt.Errorf("parse preprocess steps: %w", err)
+		}
+		lang, err := cmd.Flags().GetString("lang")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		opts.Languages, err = ocr.ParseLanguages(lang)
+		if err != nil {
+			return fmt.Errorf("parse languages: %w", err)
+		}
+		opts.Whitelist, err = cmd.Flags().GetString("whitelist")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		psm, err := cmd.Flags().GetInt("psm")
+		if err != nil {
+			return fmt.Errorf("get int: %w", err)
+		}
+		opts.PageSegMode = ocr.PageSegMode(psm)
+		if !opts.PageSegMode.Valid() {
+			return fmt.Errorf("invalid page seg mode: %d", psm)
+		}
+		regions, err := cmd.Flags().GetStringArray("region")
+		if err != nil {
+			return fmt.Errorf("get string array: %w", err)
+		}
+		opts.Regions, err = ocr.ParseRegions(regions...)
+		if err != nil {
+			return fmt.Errorf("parse regions: %w", err)
+		}
+		opts.Timeout, err = cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return fmt.Errorf("get duration: %w", err)
+		}
+
+		formatName, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		var format ocr.Format
+		if formatName != "" {
+			format, err = ocr.ParseFormat(formatName)
+			if err != nil {
+				return fmt.Errorf("parse format: %w", err)
+			}
+		}
 
 		info, err := os.Stat(path)
 		if err != nil {
 			return fmt.Errorf("stat: %w", err)
 		}
 
-		tc := ocr.NewClient()
-		defer tc.Close()
+		var e ocr.Engine = tesseract.NewPool(workers)
+		defer e.Close()
+
+		noCache, err := cmd.Flags().GetBool("no-cache")
+		if err != nil {
+			return fmt.Errorf("get bool: %w", err)
+		}
+		clearCache, err := cmd.Flags().GetBool("clear-cache")
+		if err != nil {
+			return fmt.Errorf("get bool: %w", err)
+		}
+		out, err := cmd.Flags().GetString("out")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+
+		var cached *ocr.CachedEngine
+		if !noCache {
+			cache, err := ocr.NewDiskCache(filepath.Join(out, cacheDir))
+			if err != nil {
+				return fmt.Errorf("new disk cache: %w", err)
+			}
+			if clearCache {
+				if err := cache.Clear(); err != nil {
+					return fmt.Errorf("clear cache: %w", err)
+				}
+				l.Info("Cleared ocr cache", "dir", cache.Dir())
+			}
+			cached = ocr.NewCachedEngine(e, cache)
+			e = cached
+		}
 
-		ctx := context.Background()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-		phrases := make([]*picphrase.Phrase, 0)
+		outcomes := make([]picphrase.Outcome, 0)
 
 		switch info.IsDir() {
 		case false:
-			values, err := picphrase.ScanAt(ctx, path)
-			if err != nil {
-				return fmt.Errorf("scan image: %w", err)
+			outcomes = append(outcomes, picphrase.ScanFile(ctx, e, path, opts))
+		case true:
+			values, errc := picphrase.ScanDir(ctx, e, path, opts)
+			for o := range values {
+				outcomes = append(outcomes, o)
 			}
-			for v := range values {
-				phrases = append(phrases, v)
+			if err := <-errc; err != nil {
+				return fmt.Errorf("scan dir: %w", err)
 			}
-		case true:
-			values, err := picphrase.ScanDir(ctx, path)
+		}
+
+		if format != "" {
+			n, err := writeResults(out, path, outcomes, format)
 			if err != nil {
-				return fmt.Errorf("scan images: %w", err)
+				return fmt.Errorf("write results: %w", err)
 			}
-			for v := range values {
-				phrases = append(phrases, v)
+			l.Info("Wrote ocr results", "files", n, "format", format, "dir", out)
+		}
+
+		if err := writeSummary(cmd.OutOrStdout(), outcomes); err != nil {
+			return fmt.Errorf("write summary: %w", err)
+		}
+
+		phrases, failed := 0, 0
+		for _, o := range outcomes {
+			if o.Err != nil {
+				failed++
 			}
+			phrases += len(o.Phrases)
+		}
+
+		l.Info("Scanned sentences", "total", phrases, "files", len(outcomes), "failed", failed)
+		if cached != nil {
+			stats := cached.Stats()
+			l.Info("OCR cache", "hits", stats.Hits, "misses", stats.Misses, "errors", stats.Errors)
 		}
 
-		l.Info("Scanned sentences", "total", len(phrases))
+		maxRatio, err := cmd.Flags().GetFloat64("max-failure-ratio")
+		if err != nil {
+			return fmt.Errorf("get float64: %w", err)
+		}
+		if ratio := failureRatio(outcomes); ratio > maxRatio {
+			cmd.SilenceUsage = true // Flags were fine
+
+			return fmt.Errorf("%d of %d files failed, ratio %.2f exceeds %.2f", failed, len(outcomes), ratio, maxRatio)
+		}
 		l.Info("Program completed successfully")
 
 		return nil
@@ -63,5 +190,18 @@ var phrasesCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(phrasesCmd)
 
-	phrasesCmd.Flags().String("image", "", "image to image")
+	phrasesCmd.Flags().String("image", "", "image, pdf document or directory of them to scan")
+	phrasesCmd.Flags().Int("workers", runtime.NumCPU(), "number of images scanned concurrently")
+	phrasesCmd.Flags().Float64("min-confidence", 0, "drop words recognized with lower confidence (0-100)")
+	phrasesCmd.Flags().String("lang", "eng+pol", "languages to recognize joined with +")
+	phrasesCmd.Flags().String("whitelist", "", "only recognize these characters, all if empty")
+	phrasesCmd.Flags().Int("psm", int(ocr.PSMAuto), "tesseract page segmentation mode")
+	phrasesCmd.Flags().StringArray("region", nil, "only recognize region x,y,width,height of every image, can be repeated")
+	phrasesCmd.Flags().Duration("timeout", time.Minute, "give up recognition of an image after this long, 0 means no limit")
+	phrasesCmd.Flags().String("out", "./output", "output directory, ocr results are cached in it")
+	phrasesCmd.Flags().String("format", "", "write ocr result of every image to the output directory as text, json, hocr or alto")
+	phrasesCmd.Flags().Bool("no-cache", false, "always recognize images, don't read or write cached results")
+	phrasesCmd.Flags().Bool("clear-cache", false, "remove cached results before scanning")
+	phrasesCmd.Flags().Float64("max-failure-ratio", 0, "exit with an error if ratio of files which failed to scan is higher (0-1)")
+	phrasesCmd.Flags().String("preprocess", "", "comma separated image preprocessing steps: grayscale,invert,upscale,binarize,deskew or all")
 }
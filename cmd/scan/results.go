@@ -0,0 +1,56 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/picphrase"
+)
+
+// writeResults writes recognition result of every scanned image to dir in
+// format f. Files mirror paths of images relative to root, which is either
+// a scanned directory or a single image. Returns number of written files.
+func writeResults(dir, root string, outcomes []picphrase.Outcome, f ocr.Format) (int, error) {
+	n := 0
+	for _, o := range outcomes {
+		if o.Result == nil {
+			continue // Failed or a pdf document
+		}
+
+		rel, err := filepath.Rel(root, o.Path)
+		if err != nil || rel == "." {
+			rel = filepath.Base(o.Path)
+		}
+		path := filepath.Join(dir, rel+f.Ext())
+
+		if err := writeResult(path, o.Result, f); err != nil {
+			return n, fmt.Errorf("write result of %s: %w", o.Path, err)
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+func writeResult(path string, res *ocr.Result, f ocr.Format) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+	if err := res.Encode(file, f); err != nil {
+		file.Close()
+
+		return fmt.Errorf("encode: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+
+	return nil
+}
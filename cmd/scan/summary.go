@@ -0,0 +1,49 @@
+package scan
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/kndrad/piccrack/pkg/picphrase"
+)
+
+// writeSummary writes a table of scanned files sorted by path.
+func writeSummary(w io.Writer, outcomes []picphrase.Outcome) error {
+	sorted := slices.SortedFunc(slices.Values(outcomes), func(a, b picphrase.Outcome) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATH\tSTATUS\tPHRASES\tERROR")
+	for _, o := range sorted {
+		status, msg := "ok", ""
+		if o.Err != nil {
+			status, msg = "failed", o.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", o.Path, status, len(o.Phrases), msg)
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
+
+// failureRatio returns a ratio of failed outcomes, zero if there are none.
+func failureRatio(outcomes []picphrase.Outcome) float64 {
+	if len(outcomes) == 0 {
+		return 0
+	}
+
+	failed := 0
+	for _, o := range outcomes {
+		if o.Err != nil {
+			failed++
+		}
+	}
+
+	return float64(failed) / float64(len(outcomes))
+}
@@ -1,8 +1,6 @@
 package words
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,11 +8,11 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/kndrad/piccrack/cmd/logger"
 	"github.com/kndrad/piccrack/config"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
 	"github.com/kndrad/piccrack/internal/database"
 	"github.com/kndrad/piccrack/pkg/retry"
 	"github.com/kndrad/piccrack/pkg/textproc"
@@ -59,6 +57,13 @@ var addManyCmd = &cobra.Command{
 		}
 		defer conn.Close(ctx)
 
+		aliases, err := textproc.LoadAliases(cfg.Text.Aliases)
+		if err != nil {
+			l.Error("Loading aliases", "err", err.Error())
+
+			return fmt.Errorf("load aliases: %w", err)
+		}
+
 		// Read words from a json file
 		analysis := new(textproc.TextAnalysis)
 		path := filepath.Clean(args[0])
@@ -87,19 +92,19 @@ var addManyCmd = &cobra.Command{
 
 				return fmt.Errorf("read file: %w", err)
 			}
-			scanner := bufio.NewScanner(bytes.NewReader(data))
-			scanner.Split(bufio.ScanWords)
+			words, stemmers, err := textWords(cfg.Text, aliases, func() (textproc.Taxonomy, error) {
+				return apiv1.NewService(database.New(conn), l, apiv1.WithAliases(aliases)).Taxonomy(ctx)
+			}, string(data))
+			if err != nil {
+				l.Error("Failed to read words", "err", err.Error())
 
-			for scanner.Scan() {
-				word := strings.Trim(scanner.Text(), " ")
-				analysis.IncWordCount(word)
+				return fmt.Errorf("text words: %w", err)
 			}
-
-			if err := scanner.Err(); err != nil {
-				l.Error("Scanner returned an error", "err", err.Error())
-
-				return fmt.Errorf("scanner err: %w", err)
+			for _, word := range words {
+				analysis.IncWordCount(word)
 			}
+			analysis.Stemmers = stemmers
+			l.Info("Read words", slog.Any("stemmers", stemmers))
 		}
 		if Verbose {
 			printWords(analysis)
@@ -108,7 +113,7 @@ var addManyCmd = &cobra.Command{
 		// Query db to insert each word
 		q := database.New(conn)
 		for word := range analysis.WordFrequency {
-			row, err := q.CreateWord(ctx, word)
+			row, err := q.CreateWord(ctx, aliases.Normalize(word))
 			if err != nil {
 				l.Error("Failed to insert word",
 					slog.String("word", word),
@@ -0,0 +1,90 @@
+package words
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kndrad/piccrack/cmd/logger"
+	"github.com/kndrad/piccrack/config"
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/spf13/cobra"
+)
+
+var aliasesCmd = &cobra.Command{
+	Use:     "aliases",
+	Short:   "Shows aliases merged into canonical terms.",
+	Long:    "Shows aliases of every canonical term or, given a txt file, raw forms of its words merged into each term.",
+	Example: "piccrack words aliases --path=./testdata/words.txt",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		aliases, err := loadAliases()
+		if err != nil {
+			l.Error("Failed to load aliases", "err", err)
+
+			return fmt.Errorf("load aliases: %w", err)
+		}
+
+		path, err := cmd.Flags().GetString("path")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		if path == "" {
+			terms := aliases.Terms()
+
+			fmt.Fprintln(tw, "TERM\tALIASES")
+			for _, term := range slices.Sorted(maps.Keys(terms)) {
+				fmt.Fprintf(tw, "%s\t%s\n", term, strings.Join(terms[term], ", "))
+			}
+		} else {
+			content, err := os.ReadFile(filepath.Clean(path))
+			if err != nil {
+				l.Error("Failed to read txt file", "err", err)
+
+				return fmt.Errorf("read file: %w", err)
+			}
+			merges := aliases.Merges(textproc.Tokens(string(content)))
+
+			fmt.Fprintln(tw, "TERM\tMERGED")
+			for _, term := range slices.Sorted(maps.Keys(merges)) {
+				forms := make([]string, 0, len(merges[term]))
+				for _, form := range slices.Sorted(maps.Keys(merges[term])) {
+					forms = append(forms, fmt.Sprintf("%s (%d)", form, merges[term][form]))
+				}
+				fmt.Fprintf(tw, "%s\t%s\n", term, strings.Join(forms, ", "))
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(aliasesCmd)
+
+	aliasesCmd.Flags().String("path", "", "Path of txt file whose merged words are shown")
+}
+
+// loadAliases returns default aliases overridden by a file set in config.
+func loadAliases() (textproc.Aliases, error) {
+	cfg, err := config.Load("config/development.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("config load: %w", err)
+	}
+	aliases, err := textproc.LoadAliases(cfg.Text.Aliases)
+	if err != nil {
+		return nil, fmt.Errorf("load aliases: %w", err)
+	}
+
+	return aliases, nil
+}
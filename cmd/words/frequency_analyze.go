@@ -1,8 +1,6 @@
 package words
 
 import (
-	"bufio"
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -10,6 +8,7 @@ import (
 	"path/filepath"
 
 	"github.com/kndrad/piccrack/cmd/logger"
+	"github.com/kndrad/piccrack/config"
 	"github.com/kndrad/piccrack/pkg/openf"
 	"github.com/kndrad/piccrack/pkg/textproc"
 	"github.com/spf13/cobra"
@@ -35,26 +34,34 @@ var frequencyAnalyzeCmd = &cobra.Command{
 
 			return fmt.Errorf("read file: %w", err)
 		}
-		scanner := bufio.NewScanner(bytes.NewReader(content))
-		scanner.Split(bufio.ScanWords)
+		cfg, err := config.Load("config/development.yaml")
+		if err != nil {
+			l.Error("Failed to load config", "err", err)
 
-		words := make([]string, 0)
-		for scanner.Scan() {
-			word := scanner.Text()
-			words = append(words, word)
+			return fmt.Errorf("config load: %w", err)
 		}
-		if err := scanner.Err(); err != nil {
-			l.Error("Scanning failed", "err", err)
+		aliases, err := textproc.LoadAliases(cfg.Text.Aliases)
+		if err != nil {
+			l.Error("Failed to load aliases", "err", err)
 
-			return fmt.Errorf("scanner: %w", err)
+			return fmt.Errorf("load aliases: %w", err)
 		}
 
+		words, stemmers, err := textWords(cfg.Text, aliases, func() (textproc.Taxonomy, error) {
+			return storedTaxonomy(l)
+		}, string(content))
+		if err != nil {
+			l.Error("Failed to read words", "err", err)
+
+			return fmt.Errorf("text words: %w", err)
+		}
 		analysis, err := textproc.AnalyzeWordsFrequency(words)
 		if err != nil {
 			l.Error("Analyzing words frequency failed", "err", err)
 
 			return fmt.Errorf("frequency analysis: %w", err)
 		}
+		analysis.Stemmers = stemmers
 
 		out, err := cmd.Flags().GetString("out")
 		if err != nil {
@@ -0,0 +1,102 @@
+package words
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kndrad/piccrack/cmd/logger"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/spf13/cobra"
+)
+
+var languagesCmd = &cobra.Command{
+	Use:   "languages",
+	Short: "Displays spoken languages required in phrase batches.",
+	Long: "Displays spoken languages required in phrase batches per level, CEFR or native, " +
+		"every batch counted at the highest level it requires.",
+	Example: "piccrack words languages\n" +
+		"piccrack words languages --batch=offer.pdf\n" +
+		"piccrack words languages --language=en",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		batch, err := cmd.Flags().GetString("batch")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		language, err := cmd.Flags().GetString("language")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		return withService(ctx, l, func(svc apiv1.Service) error {
+			rows, err := svc.ListLanguageRequirementSummary(ctx, batch, language)
+			if err != nil {
+				l.Error("Failed to get language requirement summary", "err", err.Error())
+
+				return fmt.Errorf("language requirement summary: %w", err)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "LANGUAGE\tLEVEL\tTOTAL")
+			for _, row := range rows {
+				fmt.Fprintf(tw, "%s\t%s\t%d\n", row.Language, row.Proficiency, row.Total)
+			}
+			if err := tw.Flush(); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+var languagesExtractCmd = &cobra.Command{
+	Use:     "extract",
+	Short:   "Extracts spoken languages required in a txt file.",
+	Example: "piccrack words languages extract --path=./testdata/offer.txt",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		path, err := cmd.Flags().GetString("path")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			l.Error("Failed to read txt file", "err", err)
+
+			return fmt.Errorf("read file: %w", err)
+		}
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "LANGUAGE\tLEVEL\tSOURCE")
+		for _, req := range textproc.LanguageRequirements(string(content)) {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", req.Language, req.Proficiency, req.Source)
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(languagesCmd)
+	languagesCmd.AddCommand(languagesExtractCmd)
+
+	languagesCmd.Flags().String("batch", "", "Name of a phrase batch, all batches if empty")
+	languagesCmd.Flags().String("language", "", "ISO 639-1 code of a spoken language, e.g. en, all if empty")
+
+	languagesExtractCmd.Flags().String("path", "", "Path of txt input file")
+	languagesExtractCmd.MarkFlagRequired("path")
+}
@@ -0,0 +1,201 @@
+package words
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kndrad/piccrack/cmd/logger"
+	"github.com/kndrad/piccrack/config"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
+	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/retry"
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/spf13/cobra"
+)
+
+var ngramsCmd = &cobra.Command{
+	Use:   "ngrams",
+	Short: "Extracts collocations of words from a txt file or ranks stored ones.",
+	Long: "Extracts bigrams and trigrams, e.g. \"machine learning\", from lines of a txt file " +
+		"scored by a collocation measure and optionally saves them as a batch. " +
+		"Without a file ranks n-grams saved in a database.",
+	Example: "piccrack words ngrams --path=./testdata/words.txt --measure=llr --save=offer\n" +
+		"piccrack words ngrams --n=2 --limit=10\n" +
+		"piccrack words ngrams --group --limit=3",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		path, err := cmd.Flags().GetString("path")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		n, err := cmd.Flags().GetInt("n")
+		if err != nil {
+			return fmt.Errorf("get int: %w", err)
+		}
+		if n != 0 && (n < 2 || n > textproc.MaxNGram) {
+			return apiv1.ErrInvalidNgramLength
+		}
+		limit, err := cmd.Flags().GetInt32("limit")
+		if err != nil {
+			return fmt.Errorf("get int32: %w", err)
+		}
+
+		if path == "" {
+			return printNgramRankings(cmd, l, int32(n), limit)
+		}
+
+		measureName, err := cmd.Flags().GetString("measure")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		measure, err := textproc.ParseMeasure(measureName)
+		if err != nil {
+			return fmt.Errorf("parse measure: %w", err)
+		}
+		minCount, err := cmd.Flags().GetInt("min-count")
+		if err != nil {
+			return fmt.Errorf("get int: %w", err)
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			l.Error("Failed to read txt file", "err", err)
+
+			return fmt.Errorf("read file: %w", err)
+		}
+		ngrams := textproc.ExtractNGrams(textproc.ScanLines(string(content)), n, measure, minCount)
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NGRAM\tCOUNT\tSCORE")
+		for i, g := range ngrams {
+			if int32(i) == limit {
+				break
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%.2f\n", g, g.Count, g.Score)
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+
+		name, err := cmd.Flags().GetString("save")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		if name == "" {
+			return nil
+		}
+
+		return saveNgrams(l, name, measure, ngrams)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ngramsCmd)
+
+	ngramsCmd.Flags().String("path", "", "Path of txt input file")
+	ngramsCmd.Flags().Int("n", 0, "Number of words of n-grams, 0 for bigrams and trigrams")
+	ngramsCmd.Flags().String("measure", string(textproc.PMI), "Collocation measure: pmi or llr")
+	ngramsCmd.Flags().Int("min-count", 2, "Minimum count of an n-gram")
+	ngramsCmd.Flags().Int32("limit", 30, "Maximum number of listed n-grams")
+	ngramsCmd.Flags().String("save", "", "Name of a batch to save extracted n-grams as")
+	addCategoryFlags(ngramsCmd)
+}
+
+// withQueries calls fn with queries of a database set in config.
+func withQueries(ctx context.Context, l *slog.Logger, fn func(q *database.Queries) error) error {
+	cfg, err := config.Load("config/development.yaml")
+	if err != nil {
+		l.Error("Loading database config", "err", err.Error())
+
+		return fmt.Errorf("config load: %w", err)
+	}
+
+	pool, err := database.Pool(ctx, cfg.Database)
+	if err != nil {
+		l.Error("Loading database pool", "err", err.Error())
+
+		return fmt.Errorf("database pool: %w", err)
+	}
+	defer pool.Close()
+
+	if err := retry.Ping(ctx, pool, retry.MaxRetries); err != nil {
+		l.Error("Pinging database", "err", err.Error())
+
+		return fmt.Errorf("database ping: %w", err)
+	}
+
+	conn, err := database.Connect(ctx, pool)
+	if err != nil {
+		l.Error("Connecting to database", "err", err.Error())
+
+		return fmt.Errorf("database connection: %w", err)
+	}
+	defer conn.Close(ctx)
+
+	return fn(database.New(conn))
+}
+
+func saveNgrams(l *slog.Logger, name string, m textproc.Measure, ngrams []textproc.NGram) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	return withService(ctx, l, func(svc apiv1.Service) error {
+		row, err := svc.CreateNgramsBatch(ctx, name, m, ngrams)
+		if err != nil {
+			l.Error("Failed to create n-grams batch", "err", err.Error())
+
+			return fmt.Errorf("create ngrams batch: %w", err)
+		}
+		l.Info("Saved n-grams batch",
+			slog.String("name", name),
+			slog.Int64("batch_id", row.BatchID.Int64),
+			slog.Int("len_ngrams", len(ngrams)),
+		)
+
+		return nil
+	})
+}
+
+func printNgramRankings(cmd *cobra.Command, l *slog.Logger, n, limit int32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	return withService(ctx, l, func(svc apiv1.Service) error {
+		t, terms, err := categoryTerms(cmd, func() (textproc.Taxonomy, error) {
+			return svc.Taxonomy(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("category terms: %w", err)
+		}
+
+		rows, err := svc.ListNgramRankings(ctx, n, terms, queryLimit(cmd, limit), 0)
+		if err != nil {
+			l.Error("Failed to get n-grams rank", "err", err.Error())
+
+			return fmt.Errorf("ngrams rank: %w", err)
+		}
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "RANK\tNGRAM\tTOTAL")
+		err = printRankings(tw, cmd, t, rows,
+			func(row database.ListNgramRankingsRow) string { return row.Value }, limit,
+			func(row database.ListNgramRankingsRow) {
+				fmt.Fprintf(tw, "%d\t%s\t%d\n", row.Ranking, row.Value, row.Total)
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+
+		return nil
+	})
+}
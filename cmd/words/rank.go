@@ -2,87 +2,293 @@ package words
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/kndrad/piccrack/cmd/logger"
-	"github.com/kndrad/piccrack/config"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
 	"github.com/kndrad/piccrack/internal/database"
-	"github.com/kndrad/piccrack/pkg/retry"
+	"github.com/kndrad/piccrack/pkg/textproc"
 	"github.com/spf13/cobra"
 )
 
 var rankCmd = &cobra.Command{
 	Use:   "rank",
 	Short: "Displays ranking of words from a database.",
+	Long: "Displays ranking of words by their count or, with --by=tfidf, by TF-IDF treating every " +
+		"word and phrase batch as a document. Given json analyses, TF-IDF is computed offline " +
+		"treating every analysis as a document. With --by=weighted, words of phrases are ranked " +
+		"by weights of offer sections they are found in, so requirements outrank nice to haves, " +
+		"optionally only in job offers of attributes, e.g. remote B2B offers mentioning Go.",
+	Example: "piccrack words rank 10\n" +
+		"piccrack words rank --category=databases,clouds 10\n" +
+		"piccrack words rank --group 5\n" +
+		"piccrack words rank --by=tfidf --batch=offer.pdf 10\n" +
+		"piccrack words rank --by=tfidf --analysis=a.json --analysis=b.json\n" +
+		"piccrack words rank --by=weighted --batch=offer.pdf 10\n" +
+		"piccrack words rank --by=weighted --work-mode=remote --contract=b2b --mentions=go 10",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		l := logger.New(Verbose)
 
-		cfg, err := config.Load("config/development.yaml")
+		by, err := cmd.Flags().GetString("by")
 		if err != nil {
-			l.Error("Loading database config", "err", err.Error())
+			return fmt.Errorf("get string: %w", err)
+		}
+		switch by {
+		case rankByCount:
+		case rankByTfidf:
+			return rankTfidf(cmd, args, l)
+		case rankByWeighted:
+			return rankWeighted(cmd, args, l)
+		default:
+			return fmt.Errorf("%w: %q", ErrUnknownRanking, by)
+		}
 
-			return fmt.Errorf("loading config: %w", err)
+		var limit int32 = 30
+		if len(args) > 0 {
+			n, err := strconv.ParseInt(args[0], 10, 32)
+			if err != nil {
+				return fmt.Errorf("parse int: %w", err)
+			}
+			limit = int32(n)
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 
-		pool, err := database.Pool(ctx, cfg.Database)
-		if err != nil {
-			l.Error("Loading database pool", "err", err.Error())
+		err = withService(ctx, l, func(svc apiv1.Service) error {
+			t, terms, err := categoryTerms(cmd, func() (textproc.Taxonomy, error) {
+				return svc.Taxonomy(ctx)
+			})
+			if err != nil {
+				return fmt.Errorf("category terms: %w", err)
+			}
 
-			return fmt.Errorf("database pool: %w", err)
-		}
-		defer pool.Close()
+			rows, err := svc.ListWordRankings(ctx, terms, queryLimit(cmd, limit), 0)
+			if err != nil {
+				l.Error("Failed to get words rank", "err", err.Error())
 
-		if err := retry.Ping(ctx, pool, retry.MaxRetries); err != nil {
-			l.Error("Pinging database", "err", err.Error())
+				return fmt.Errorf("words rank err: %w", err)
+			}
 
-			return fmt.Errorf("database ping: %w", err)
+			return printRankings(os.Stdout, cmd, t, rows,
+				func(row database.ListWordRankingsRow) string { return row.Value }, limit,
+				func(row database.ListWordRankingsRow) {
+					fmt.Printf("WORD: %s | RANK: %d\n", row.Value, row.Ranking)
+				},
+			)
+		})
+		if err != nil {
+			return err
 		}
 
-		conn, err := database.Connect(ctx, pool)
+		l.Info("Program completed successfully.")
+
+		return nil
+	},
+}
+
+const (
+	rankByCount    = "count"
+	rankByTfidf    = "tfidf"
+	rankByWeighted = "weighted"
+)
+
+var ErrUnknownRanking = errors.New("unknown ranking")
+
+func init() {
+	rootCmd.AddCommand(rankCmd)
+
+	rankCmd.Flags().String("by", rankByCount, "Ranking of words: count, tfidf or weighted")
+	rankCmd.Flags().String("batch", "", "Name of batches to rank words of by tfidf or weighted, all batches if empty")
+	rankCmd.Flags().StringSlice("analysis", nil, "Paths of json analyses to rank words of by tfidf offline")
+	for flag, name := range attributeFlags {
+		rankCmd.Flags().StringSlice(flag, nil, "Values of "+name+" of job offers to rank words of by weighted")
+	}
+	rankCmd.Flags().StringSlice("mentions", nil, "Terms mentioned by job offers to rank words of by weighted")
+	addCategoryFlags(rankCmd)
+}
+
+// rankTfidf prints words ranked by TF-IDF, computed offline if paths of
+// analyses are passed in flags.
+func rankTfidf(cmd *cobra.Command, args []string, l *slog.Logger) error {
+	var limit int32 = 30
+	if len(args) > 0 {
+		n, err := strconv.ParseInt(args[0], 10, 32)
 		if err != nil {
-			l.Error("Connecting to database", "err", err.Error())
+			return fmt.Errorf("parse int: %w", err)
+		}
+		limit = int32(n)
+	}
+
+	paths, err := cmd.Flags().GetStringSlice("analysis")
+	if err != nil {
+		return fmt.Errorf("get string slice: %w", err)
+	}
+	if len(paths) > 0 {
+		analyses := make([]*textproc.TextAnalysis, 0, len(paths))
+		for _, path := range paths {
+			analysis, err := textproc.LoadTextAnalysis(path)
+			if err != nil {
+				l.Error("Failed to load analysis", "err", err.Error())
 
-			return fmt.Errorf("database connection: %w", err)
+				return fmt.Errorf("load analysis: %w", err)
+			}
+			analyses = append(analyses, analysis)
 		}
-		defer conn.Close(ctx)
 
-		q := database.New(conn)
+		t, terms, err := categoryTerms(cmd, func() (textproc.Taxonomy, error) {
+			return textproc.DefaultTaxonomy(), nil
+		})
+		if err != nil {
+			return fmt.Errorf("category terms: %w", err)
+		}
 
-		var limit int32 = 30
-		params := database.ListWordRankingsParams{
-			Limit: limit,
+		type rankedScore struct {
+			textproc.TermScore
+			Ranking int
 		}
+		for i, scores := range textproc.TFIDF(analyses...) {
+			fmt.Printf("ANALYSIS: %s (%s)\n", analyses[i].ID, paths[i])
 
-		if len(args) > 0 {
-			limit, err := strconv.ParseInt(args[0], 10, 32)
+			ranked := make([]rankedScore, 0, len(scores))
+			for _, s := range scores {
+				if t == nil || slices.Contains(terms, s.Term) {
+					ranked = append(ranked, rankedScore{s, len(ranked) + 1})
+				}
+			}
+			ranked = ranked[:min(len(ranked), int(queryLimit(cmd, limit)))]
+
+			err := printRankings(os.Stdout, cmd, t, ranked,
+				func(s rankedScore) string { return s.Term }, limit,
+				func(s rankedScore) {
+					fmt.Printf("WORD: %s | RANK: %d | TFIDF: %.4f\n", s.Term, s.Ranking, s.Score)
+				},
+			)
 			if err != nil {
-				l.Error("Failed to strconv", "err", err.Error())
+				return err
 			}
-			params.Limit = int32(limit)
 		}
 
-		rows, err := q.ListWordRankings(ctx, params)
+		return nil
+	}
+
+	batch, err := cmd.Flags().GetString("batch")
+	if err != nil {
+		return fmt.Errorf("get string: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	return withService(ctx, l, func(svc apiv1.Service) error {
+		t, terms, err := categoryTerms(cmd, func() (textproc.Taxonomy, error) {
+			return svc.Taxonomy(ctx)
+		})
 		if err != nil {
-			l.Error("Failed to get words rank", "err", err.Error())
+			return fmt.Errorf("category terms: %w", err)
+		}
 
-			return fmt.Errorf("words rank err: %w", err)
+		rows, err := svc.ListTfidfRankings(ctx, batch, terms, queryLimit(cmd, limit), 0)
+		if err != nil {
+			l.Error("Failed to get words tfidf rank", "err", err.Error())
+
+			return fmt.Errorf("words tfidf rank: %w", err)
 		}
 
-		for _, row := range rows {
-			fmt.Printf("WORD: %s | RANK: %d\n", row.Value, row.Ranking)
+		return printRankings(os.Stdout, cmd, t, rows,
+			func(row database.ListTfidfRankingsRow) string { return row.Value }, limit,
+			func(row database.ListTfidfRankingsRow) {
+				fmt.Printf("WORD: %s | RANK: %d | TFIDF: %.4f\n", row.Value, row.Ranking, row.Score)
+			},
+		)
+	})
+}
+
+// rankWeighted prints words of phrases ranked by weights of offer sections
+// they are found in.
+func rankWeighted(cmd *cobra.Command, args []string, l *slog.Logger) error {
+	var limit int32 = 30
+	if len(args) > 0 {
+		n, err := strconv.ParseInt(args[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("parse int: %w", err)
 		}
+		limit = int32(n)
+	}
 
-		l.Info("Program completed successfully.")
+	batch, err := cmd.Flags().GetString("batch")
+	if err != nil {
+		return fmt.Errorf("get string: %w", err)
+	}
+	f, err := batchFilter(cmd)
+	if err != nil {
+		return fmt.Errorf("batch filter: %w", err)
+	}
 
-		return nil
-	},
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	return withService(ctx, l, func(svc apiv1.Service) error {
+		t, terms, err := categoryTerms(cmd, func() (textproc.Taxonomy, error) {
+			return svc.Taxonomy(ctx)
+		})
+		if err != nil {
+			return fmt.Errorf("category terms: %w", err)
+		}
+
+		rows, err := svc.ListWeightedRankings(ctx, batch, terms, f, queryLimit(cmd, limit), 0)
+		if err != nil {
+			l.Error("Failed to get words weighted rank", "err", err.Error())
+
+			return fmt.Errorf("words weighted rank: %w", err)
+		}
+
+		return printRankings(os.Stdout, cmd, t, rows,
+			func(row database.ListWeightedRankingsRow) string { return row.Value }, limit,
+			func(row database.ListWeightedRankingsRow) {
+				fmt.Printf("WORD: %s | RANK: %d | SCORE: %.2f | TOTAL: %d\n", row.Value, row.Ranking, row.Score, row.Total)
+			},
+		)
+	})
 }
 
-func init() {
-	rootCmd.AddCommand(rankCmd)
+// attributeFlags are flags of attributes of job offers, keyed by flag name.
+var attributeFlags = map[string]string{
+	"work-mode":   textproc.AttributeWorkMode,
+	"contract":    textproc.AttributeContract,
+	"employment":  textproc.AttributeEmployment,
+	"city":        textproc.AttributeCity,
+	"postal-code": textproc.AttributePostalCode,
+}
+
+// batchFilter returns a filter of phrase batches of attributes and mentioned
+// terms set in flags.
+func batchFilter(cmd *cobra.Command) (apiv1.BatchFilter, error) {
+	var f apiv1.BatchFilter
+	for flag, name := range attributeFlags {
+		values, err := cmd.Flags().GetStringSlice(flag)
+		if err != nil {
+			return f, fmt.Errorf("get string slice: %w", err)
+		}
+		for _, v := range values {
+			a, err := textproc.ParseAttribute(name, v)
+			if err != nil {
+				return f, fmt.Errorf("parse attribute: %w", err)
+			}
+			f.Attributes = append(f.Attributes, a)
+		}
+	}
+	mentions, err := cmd.Flags().GetStringSlice("mentions")
+	if err != nil {
+		return f, fmt.Errorf("get string slice: %w", err)
+	}
+	f.Mentions = mentions
+
+	return f, nil
 }
@@ -0,0 +1,124 @@
+package words
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kndrad/piccrack/cmd/logger"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/spf13/cobra"
+)
+
+var salariesCmd = &cobra.Command{
+	Use:   "salaries",
+	Short: "Displays statistics of salaries offered in phrase batches.",
+	Long: "Displays statistics of salaries offered in phrase batches per currency and contract, " +
+		"amounts are normalized to a month.",
+	Example: "piccrack words salaries\n" +
+		"piccrack words salaries --batch=offer.pdf\n" +
+		"piccrack words salaries --currency=PLN --contract=b2b",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		batch, err := cmd.Flags().GetString("batch")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		currency, err := cmd.Flags().GetString("currency")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		contract, err := cmd.Flags().GetString("contract")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		return withService(ctx, l, func(svc apiv1.Service) error {
+			rows, err := svc.ListSalaryStats(ctx, batch, currency, contract)
+			if err != nil {
+				l.Error("Failed to get salary stats", "err", err.Error())
+
+				return fmt.Errorf("salary stats: %w", err)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "CURRENCY\tCONTRACT\tTOTAL\tMIN\tMAX\tAVG MIN\tAVG MAX\tMEDIAN")
+			for _, row := range rows {
+				contract := row.Contract
+				if contract == "" {
+					contract = "-"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%d\t%.0f\t%.0f\t%.0f\t%.0f\t%.0f\n",
+					row.Currency,
+					contract,
+					row.Total,
+					row.MinAmount,
+					row.MaxAmount,
+					row.AvgMinAmount,
+					row.AvgMaxAmount,
+					row.MedianAmount,
+				)
+			}
+			if err := tw.Flush(); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+var salariesExtractCmd = &cobra.Command{
+	Use:     "extract",
+	Short:   "Extracts salaries offered in a txt file.",
+	Example: "piccrack words salaries extract --path=./testdata/offer.txt",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		path, err := cmd.Flags().GetString("path")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			l.Error("Failed to read txt file", "err", err)
+
+			return fmt.Errorf("read file: %w", err)
+		}
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MIN\tMAX\tCURRENCY\tPERIOD\tCONTRACT\tSOURCE")
+		for _, s := range textproc.Salaries(string(content)) {
+			contract := string(s.Contract)
+			if contract == "" {
+				contract = "-"
+			}
+			fmt.Fprintf(tw, "%.2f\t%.2f\t%s\t%s\t%s\t%s\n", s.Min, s.Max, s.Currency, s.Period, contract, s.Source)
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(salariesCmd)
+	salariesCmd.AddCommand(salariesExtractCmd)
+
+	salariesCmd.Flags().String("batch", "", "Name of a phrase batch, all batches if empty")
+	salariesCmd.Flags().String("currency", "", "Currency of salaries: PLN, EUR or USD, all if empty")
+	salariesCmd.Flags().String("contract", "", "Contract of salaries: b2b, uop or uz, all if empty")
+
+	salariesExtractCmd.Flags().String("path", "", "Path of txt input file")
+	salariesExtractCmd.MarkFlagRequired("path")
+}
@@ -0,0 +1,49 @@
+package words
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kndrad/piccrack/config"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
+	"github.com/kndrad/piccrack/pkg/textproc"
+)
+
+// textWords returns words of text normalized by aliases and, if enabled
+// in cfg, stemmed, along with names of stemmers which produced them.
+// Technology names of aliases and of taxonomy, loaded only if words are
+// stemmed, are kept as they are, "kubernetes" is not "kubernet".
+func textWords(cfg config.TextConfig, aliases textproc.Aliases, taxonomy func() (textproc.Taxonomy, error), text string) ([]string, []string, error) {
+	tokens := func(line string) []string {
+		return aliases.NormalizeAll(textproc.Tokens(line))
+	}
+	if !cfg.Stemming {
+		return tokens(text), nil, nil
+	}
+
+	t, err := taxonomy()
+	if err != nil {
+		return nil, nil, fmt.Errorf("taxonomy: %w", err)
+	}
+	words, stemmers := textproc.NewTermStemming(aliases, t).Words(text, tokens)
+
+	return words, stemmers, nil
+}
+
+// storedTaxonomy returns taxonomy edited by skill terms stored in a database.
+func storedTaxonomy(l *slog.Logger) (textproc.Taxonomy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	var t textproc.Taxonomy
+	err := withService(ctx, l, func(svc apiv1.Service) error {
+		var err error
+		t, err = svc.Taxonomy(ctx)
+
+		return err
+	})
+
+	return t, err
+}
@@ -0,0 +1,273 @@
+package words
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kndrad/piccrack/cmd/logger"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
+	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/spf13/cobra"
+)
+
+var taxonomyCmd = &cobra.Command{
+	Use:   "taxonomy",
+	Short: "Lists categories of skills and their terms.",
+	Long: "Lists categories of skills and their terms, the default taxonomy " +
+		"edited by skill terms stored in a database.",
+	Example: "piccrack words taxonomy",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		return withService(ctx, l, func(svc apiv1.Service) error {
+			t, err := svc.Taxonomy(ctx)
+			if err != nil {
+				l.Error("Failed to get taxonomy", "err", err.Error())
+
+				return fmt.Errorf("taxonomy: %w", err)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "CATEGORY\tTERMS")
+			for _, c := range t.Categories() {
+				terms, err := t.Terms(c)
+				if err != nil {
+					return fmt.Errorf("terms: %w", err)
+				}
+				fmt.Fprintf(tw, "%s\t%s\n", c, strings.Join(terms, ", "))
+			}
+			if err := tw.Flush(); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+var taxonomySetCmd = &cobra.Command{
+	Use:     "set <term> [category]",
+	Short:   "Moves a term to a category, removes it from taxonomy without a category.",
+	Example: "piccrack words taxonomy set \"github actions\" tools",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		category := ""
+		if len(args) > 1 {
+			category = args[1]
+		}
+
+		return withService(ctx, l, func(svc apiv1.Service) error {
+			row, err := svc.SetSkillTerm(ctx, args[0], category)
+			if err != nil {
+				l.Error("Failed to set skill term", "err", err.Error())
+
+				return fmt.Errorf("set skill term: %w", err)
+			}
+			l.Info("Set skill term",
+				slog.String("term", row.Term),
+				slog.String("category", row.Category.String),
+			)
+
+			return nil
+		})
+	},
+}
+
+var taxonomyResetCmd = &cobra.Command{
+	Use:     "reset <term>",
+	Short:   "Deletes an edit of a term, restoring its default category.",
+	Example: "piccrack words taxonomy reset terraform",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		return withService(ctx, l, func(svc apiv1.Service) error {
+			if err := svc.DeleteSkillTerm(ctx, args[0]); err != nil {
+				l.Error("Failed to delete skill term", "err", err.Error())
+
+				return fmt.Errorf("delete skill term: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+var taxonomyTagCmd = &cobra.Command{
+	Use:     "tag",
+	Short:   "Counts skills of every category found in a txt file.",
+	Example: "piccrack words taxonomy tag --path=./testdata/words.txt",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		path, err := cmd.Flags().GetString("path")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			l.Error("Failed to read txt file", "err", err)
+
+			return fmt.Errorf("read file: %w", err)
+		}
+		aliases, err := loadAliases()
+		if err != nil {
+			return fmt.Errorf("load aliases: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		return withService(ctx, l, func(svc apiv1.Service) error {
+			t, err := svc.Taxonomy(ctx)
+			if err != nil {
+				l.Error("Failed to get taxonomy", "err", err.Error())
+
+				return fmt.Errorf("taxonomy: %w", err)
+			}
+
+			counts := make(map[textproc.Tag]int)
+			for line := range textproc.ScanLines(string(content)) {
+				for _, tag := range t.Match(aliases.NormalizeAll(textproc.Tokens(line))) {
+					counts[tag]++
+				}
+			}
+			tags := slices.SortedFunc(maps.Keys(counts), func(a, b textproc.Tag) int {
+				if a.Category != b.Category {
+					return strings.Compare(a.Category, b.Category)
+				}
+
+				return counts[b] - counts[a]
+			})
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "CATEGORY\tTERM\tCOUNT")
+			for _, tag := range tags {
+				fmt.Fprintf(tw, "%s\t%s\t%d\n", tag.Category, tag.Term, counts[tag])
+			}
+			if err := tw.Flush(); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(taxonomyCmd)
+	taxonomyCmd.AddCommand(taxonomySetCmd)
+	taxonomyCmd.AddCommand(taxonomyResetCmd)
+	taxonomyCmd.AddCommand(taxonomyTagCmd)
+
+	taxonomyTagCmd.Flags().String("path", "", "Path of txt input file")
+	taxonomyTagCmd.MarkFlagRequired("path")
+}
+
+// withService calls fn with a service of a database set in config,
+// normalizing words with configured aliases.
+func withService(ctx context.Context, l *slog.Logger, fn func(svc apiv1.Service) error) error {
+	aliases, err := loadAliases()
+	if err != nil {
+		return fmt.Errorf("load aliases: %w", err)
+	}
+
+	return withQueries(ctx, l, func(q *database.Queries) error {
+		return fn(apiv1.NewService(q, l, apiv1.WithAliases(aliases)))
+	})
+}
+
+// addCategoryFlags adds flags filtering and grouping rankings by category.
+func addCategoryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("category", nil, "Categories of skills to rank, e.g. databases")
+	cmd.Flags().Bool("group", false, "Group rankings by category of skills")
+}
+
+// categoryTerms returns terms of categories set in flags, or of every
+// category if rankings are grouped, and taxonomy they belong to. No terms
+// if neither flag is set.
+func categoryTerms(cmd *cobra.Command, taxonomy func() (textproc.Taxonomy, error)) (textproc.Taxonomy, []string, error) {
+	categories, err := cmd.Flags().GetStringSlice("category")
+	if err != nil {
+		return nil, nil, fmt.Errorf("get string slice: %w", err)
+	}
+	group, err := cmd.Flags().GetBool("group")
+	if err != nil {
+		return nil, nil, fmt.Errorf("get bool: %w", err)
+	}
+	if len(categories) == 0 && !group {
+		return nil, nil, nil
+	}
+
+	t, err := taxonomy()
+	if err != nil {
+		return nil, nil, fmt.Errorf("taxonomy: %w", err)
+	}
+	if len(categories) == 0 {
+		categories = t.Categories()
+	}
+	terms, err := t.Terms(categories...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("terms: %w", err)
+	}
+
+	return t, terms, nil
+}
+
+// queryLimit returns limit of ranked rows to query, every row if they are
+// grouped by category and limited afterwards.
+func queryLimit(cmd *cobra.Command, limit int32) int32 {
+	if group, _ := cmd.Flags().GetBool("group"); group {
+		return math.MaxInt32
+	}
+
+	return limit
+}
+
+// printRankings prints rows to w with print, grouped by category of their
+// value with at most limit rows in every group if grouping is set in flags.
+func printRankings[T any](w io.Writer, cmd *cobra.Command, t textproc.Taxonomy, rows []T, value func(T) string, limit int32, print func(T)) error {
+	group, err := cmd.Flags().GetBool("group")
+	if err != nil {
+		return fmt.Errorf("get bool: %w", err)
+	}
+	if !group {
+		for _, row := range rows {
+			print(row)
+		}
+
+		return nil
+	}
+
+	groups := textproc.GroupByCategory(t, rows, value, int(limit))
+	for _, c := range slices.Sorted(maps.Keys(groups)) {
+		fmt.Fprintf(w, "CATEGORY: %s\n", c)
+		for _, row := range groups[c] {
+			print(row)
+		}
+	}
+
+	return nil
+}
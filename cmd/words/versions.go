@@ -0,0 +1,108 @@
+package words
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kndrad/piccrack/cmd/logger"
+	apiv1 "github.com/kndrad/piccrack/internal/api/v1"
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/spf13/cobra"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "Displays versions of technologies asked for in phrase batches.",
+	Long: "Displays versions of technologies asked for in phrase batches, e.g. \"17\" of \"Java 17\", " +
+		"counted per technology, the most asked first.",
+	Example: "piccrack words versions\n" +
+		"piccrack words versions --batch=offer.pdf\n" +
+		"piccrack words versions --term=java,go",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		batch, err := cmd.Flags().GetString("batch")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		terms, err := cmd.Flags().GetStringSlice("term")
+		if err != nil {
+			return fmt.Errorf("get string slice: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		return withService(ctx, l, func(svc apiv1.Service) error {
+			rows, err := svc.ListVersionDistribution(ctx, batch, terms)
+			if err != nil {
+				l.Error("Failed to get version distribution", "err", err.Error())
+
+				return fmt.Errorf("version distribution: %w", err)
+			}
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "TERM\tVERSION\tTOTAL")
+			for _, row := range rows {
+				fmt.Fprintf(tw, "%s\t%s\t%d\n", row.Term, row.Version, row.Total)
+			}
+			if err := tw.Flush(); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+
+			return nil
+		})
+	},
+}
+
+var versionsExtractCmd = &cobra.Command{
+	Use:     "extract",
+	Short:   "Extracts versions of technologies asked for in a txt file.",
+	Example: "piccrack words versions extract --path=./testdata/offer.txt",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := logger.New(Verbose)
+
+		path, err := cmd.Flags().GetString("path")
+		if err != nil {
+			return fmt.Errorf("get string: %w", err)
+		}
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			l.Error("Failed to read txt file", "err", err)
+
+			return fmt.Errorf("read file: %w", err)
+		}
+		aliases, err := loadAliases()
+		if err != nil {
+			return fmt.Errorf("load aliases: %w", err)
+		}
+
+		e := textproc.NewVersionExtractor(textproc.DefaultTaxonomy(), aliases)
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TERM\tVERSION\tSOURCE")
+		for _, v := range e.Versions(string(content)) {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Term, v.Version, v.Source)
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionsCmd)
+	versionsCmd.AddCommand(versionsExtractCmd)
+
+	versionsCmd.Flags().String("batch", "", "Name of a phrase batch, all batches if empty")
+	versionsCmd.Flags().StringSlice("term", nil, "Technologies to count versions of, all if empty")
+
+	versionsExtractCmd.Flags().String("path", "", "Path of txt input file")
+	versionsExtractCmd.MarkFlagRequired("path")
+}
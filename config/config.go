@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"path/filepath"
+	"runtime"
 
 	"github.com/spf13/viper"
 )
@@ -11,6 +12,8 @@ type Config struct {
 	Database DatabaseConfig `mapstructure:"database"`
 	HTTP     HTTPConfig     `mapstructure:"http"`
 	App      AppConfig      `mapstructure:"app"`
+	OCR      OCRConfig      `mapstructure:"ocr"`
+	Text     TextConfig     `mapstructure:"text"`
 }
 
 func Load(path string) (*Config, error) {
@@ -32,6 +35,13 @@ func Load(path string) (*Config, error) {
 	v.SetDefault("App.Environment", "development")
 	v.SetDefault("App.LogLevel", "info")
 
+	v.SetDefault("ocr.workers", runtime.NumCPU())
+	v.SetDefault("ocr.min_confidence", 0)
+	v.SetDefault("ocr.languages", "eng+pol")
+	v.SetDefault("ocr.page_seg_mode", 3)
+	v.SetDefault("ocr.cache_size", 512)
+	v.SetDefault("ocr.timeout", "15s")
+
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("reading config: %w", err)
 	}
@@ -71,3 +81,26 @@ type HTTPConfig struct {
 	Port       string `mapstructure:"port"`
 	TLSEnabled bool   `mapstructure:"tls_enabled"`
 }
+
+type OCRConfig struct {
+	Workers       int     `mapstructure:"workers"`
+	MinConfidence float64 `mapstructure:"min_confidence"`
+	Languages     string  `mapstructure:"languages"`
+	Whitelist     string  `mapstructure:"whitelist"`
+	PageSegMode   int     `mapstructure:"page_seg_mode"`
+	// CacheSize is a number of results cached in memory, zero disables cache.
+	CacheSize int `mapstructure:"cache_size"`
+	// Timeout limits recognition of a single image, e.g. "15s".
+	// Keep it below http write timeout so clients get an error response.
+	Timeout string `mapstructure:"timeout"`
+}
+
+type TextConfig struct {
+	// Aliases is a path of a file overriding default aliases of terms,
+	// see textproc.LoadAliases. Defaults are used if empty.
+	Aliases string `mapstructure:"aliases"`
+	// Stemming reduces words, and terms of phrases stored by the API, to
+	// their stems with an English or Polish stemmer picked per line by
+	// detected language. Skill terms of the taxonomy are kept intact.
+	Stemming bool `mapstructure:"stemming"`
+}
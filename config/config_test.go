@@ -3,6 +3,7 @@ package config_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/kndrad/piccrack/config"
@@ -24,6 +25,18 @@ http:
   port: "8080"
   tls_enabled: false
 
+ocr:
+  workers: 2
+  min_confidence: 55.5
+  languages: "pol"
+  page_seg_mode: 6
+  cache_size: 64
+  timeout: 30s
+
+text:
+  aliases: "aliases.txt"
+  stemming: true
+
 database:
   user: testuser
   password: testpassword
@@ -54,6 +67,17 @@ database:
 	require.Equal(t, "8080", cfg.HTTP.Port)
 	require.Equal(t, false, cfg.HTTP.TLSEnabled)
 
+	require.Equal(t, 2, cfg.OCR.Workers)
+	require.InDelta(t, 55.5, cfg.OCR.MinConfidence, 0.001)
+	require.Equal(t, "pol", cfg.OCR.Languages)
+	require.Empty(t, cfg.OCR.Whitelist)
+	require.Equal(t, 6, cfg.OCR.PageSegMode)
+	require.Equal(t, 64, cfg.OCR.CacheSize)
+	require.Equal(t, "30s", cfg.OCR.Timeout)
+
+	require.Equal(t, "aliases.txt", cfg.Text.Aliases)
+	require.True(t, cfg.Text.Stemming)
+
 	require.Equal(t, "testuser", cfg.Database.User)
 	require.Equal(t, "testpassword", cfg.Database.Password)
 	require.Equal(t, "localhost", cfg.Database.Host)
@@ -67,3 +91,24 @@ database:
 	require.Equal(t, "10s", cfg.Database.Pool.ConnectTimeout)
 	require.Equal(t, "5s", cfg.Database.Pool.DialerKeepAlive)
 }
+
+func TestLoadingConfigDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := []byte(`app:
+  environment: "testing"
+
+ocr:
+  workers: 2
+`)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, cfg.OCR.Workers)
+	require.Zero(t, cfg.OCR.MinConfidence)
+	require.Equal(t, "eng+pol", cfg.OCR.Languages)
+	require.Equal(t, 3, cfg.OCR.PageSegMode)
+	require.Equal(t, 512, cfg.OCR.CacheSize)
+	require.Equal(t, "15s", cfg.OCR.Timeout)
+}
@@ -0,0 +1,5 @@
+ALTER TABLE phrases
+DROP COLUMN IF EXISTS source;
+
+ALTER TABLE words
+DROP COLUMN IF EXISTS source;
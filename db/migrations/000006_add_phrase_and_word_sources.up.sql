@@ -0,0 +1,5 @@
+ALTER TABLE phrases
+ADD COLUMN IF NOT EXISTS source TEXT;
+
+ALTER TABLE words
+ADD COLUMN IF NOT EXISTS source TEXT;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ngrams;
+DROP TABLE IF EXISTS ngram_batches;
+
+DROP INDEX IF EXISTS idx_ngram_batch_id;
+DROP INDEX IF EXISTS idx_ngram_n_value;
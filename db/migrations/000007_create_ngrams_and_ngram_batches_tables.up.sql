@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS ngram_batches (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    measure TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (LENGTH(name) > 0)
+);
+
+CREATE TABLE IF NOT EXISTS ngrams (
+    id BIGSERIAL PRIMARY KEY,
+    value TEXT NOT NULL,
+    n INTEGER NOT NULL,
+    count INTEGER NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    batch_id BIGINT REFERENCES ngram_batches (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (LENGTH(value) > 0),
+    CHECK (n > 1),
+    CHECK (count > 0)
+);
+
+CREATE INDEX idx_ngram_n_value ON ngrams (n, value)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_ngram_batch_id ON ngrams (batch_id)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS phrase_terms;
+
+DROP INDEX IF EXISTS idx_phrase_term_batch_id;
+DROP INDEX IF EXISTS idx_phrase_term_term;
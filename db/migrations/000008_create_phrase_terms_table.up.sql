@@ -0,0 +1,37 @@
+-- Terms of phrases of a batch, tokenized and normalized by aliases as they
+-- are stored.
+CREATE TABLE IF NOT EXISTS phrase_terms (
+    id BIGSERIAL PRIMARY KEY,
+    term TEXT NOT NULL,
+    batch_id BIGINT REFERENCES phrase_batches (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_phrase_term_term ON phrase_terms (term)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_phrase_term_batch_id ON phrase_terms (batch_id)
+WHERE deleted_at IS NULL;
+
+-- Backfill terms of phrases stored before this migration, so that their
+-- batches are still ranked. Tokenization approximates the one of the API,
+-- phrases are split on spaces and dashes and punctuation around words is
+-- stripped, keeping leading dots and trailing "#" and "+" of terms such as
+-- ".net", "c#" and "c++". Aliases are not applied to backfilled terms.
+INSERT INTO phrase_terms (term, batch_id)
+SELECT
+    words.term,
+    phrases.batch_id
+FROM phrases
+CROSS JOIN LATERAL (
+    SELECT
+        REGEXP_REPLACE(
+            word, '^[^[:alnum:].#+]+|[^[:alnum:]#+]+$', '', 'g'
+        ) AS term
+    FROM REGEXP_SPLIT_TO_TABLE(LOWER(phrases.value), '[[:space:]–—]+') AS word
+) AS words
+WHERE
+    phrases.deleted_at IS NULL
+    AND words.term ~ '[[:alnum:]]'
+ORDER BY phrases.id;
@@ -0,0 +1,12 @@
+-- Local edits of the default skills taxonomy. A NULL category removes
+-- the term from the taxonomy.
+CREATE TABLE IF NOT EXISTS skill_terms (
+    id BIGSERIAL PRIMARY KEY,
+    term TEXT NOT NULL,
+    category TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT skill_terms_term_unique UNIQUE (term),
+    CHECK (LENGTH(term) > 0),
+    CHECK (LENGTH(category) > 0)
+);
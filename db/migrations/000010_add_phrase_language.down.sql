@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_phrase_language;
+
+ALTER TABLE phrases
+DROP COLUMN IF EXISTS language;
@@ -0,0 +1,5 @@
+ALTER TABLE phrases
+ADD COLUMN IF NOT EXISTS language TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_phrase_language ON phrases (language)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE phrase_terms
+DROP COLUMN IF EXISTS section;
+
+DROP INDEX IF EXISTS idx_phrase_section;
+
+ALTER TABLE phrases
+DROP COLUMN IF EXISTS section;
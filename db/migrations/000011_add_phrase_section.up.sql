@@ -0,0 +1,10 @@
+ALTER TABLE phrases
+ADD COLUMN IF NOT EXISTS section TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_phrase_section ON phrases (section)
+WHERE deleted_at IS NULL;
+
+-- Terms keep sections of phrases they are found in, so that weighted
+-- rankings count them by their stored terms.
+ALTER TABLE phrase_terms
+ADD COLUMN IF NOT EXISTS section TEXT;
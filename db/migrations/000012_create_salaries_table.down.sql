@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS salaries;
+
+DROP INDEX IF EXISTS idx_salary_batch_id;
@@ -0,0 +1,19 @@
+-- Salaries offered in phrases of a batch, amounts are paid per period.
+CREATE TABLE IF NOT EXISTS salaries (
+    id BIGSERIAL PRIMARY KEY,
+    min_amount DOUBLE PRECISION NOT NULL,
+    max_amount DOUBLE PRECISION NOT NULL,
+    currency TEXT NOT NULL,
+    period TEXT NOT NULL,
+    contract TEXT,
+    source TEXT NOT NULL,
+    batch_id BIGINT REFERENCES phrase_batches (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (min_amount > 0),
+    CHECK (max_amount >= min_amount),
+    CHECK (period IN ('hour', 'month', 'year'))
+);
+
+CREATE INDEX idx_salary_batch_id ON salaries (batch_id)
+WHERE deleted_at IS NULL;
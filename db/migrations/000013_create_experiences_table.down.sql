@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS experiences;
+
+DROP INDEX IF EXISTS idx_experience_batch_id;
+DROP INDEX IF EXISTS idx_experience_term;
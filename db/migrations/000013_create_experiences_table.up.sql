@@ -0,0 +1,20 @@
+-- Years of experience required by phrases of a batch, linked to the nearest
+-- technology. A NULL max_years is an open-ended requirement.
+CREATE TABLE IF NOT EXISTS experiences (
+    id BIGSERIAL PRIMARY KEY,
+    term TEXT,
+    min_years INTEGER NOT NULL,
+    max_years INTEGER,
+    source TEXT NOT NULL,
+    batch_id BIGINT REFERENCES phrase_batches (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (min_years >= 0),
+    CHECK (max_years >= min_years)
+);
+
+CREATE INDEX idx_experience_term ON experiences (term)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_experience_batch_id ON experiences (batch_id)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS phrase_batch_attributes;
+
+DROP INDEX IF EXISTS idx_phrase_batch_attribute_name_value;
@@ -0,0 +1,15 @@
+-- Attributes of a job offer stated in phrases of a batch, e.g. its work mode
+-- or contract, used to filter rankings.
+CREATE TABLE IF NOT EXISTS phrase_batch_attributes (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL,
+    batch_id BIGINT NOT NULL REFERENCES phrase_batches (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (batch_id, name, value)
+);
+
+CREATE INDEX idx_phrase_batch_attribute_name_value
+ON phrase_batch_attributes (name, value)
+WHERE deleted_at IS NULL;
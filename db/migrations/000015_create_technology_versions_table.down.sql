@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS technology_versions;
+
+DROP INDEX IF EXISTS idx_technology_version_batch_id;
+DROP INDEX IF EXISTS idx_technology_version_term;
@@ -0,0 +1,17 @@
+-- Versions of technologies asked for by phrases of a batch, e.g. "17" of
+-- "Java 17".
+CREATE TABLE IF NOT EXISTS technology_versions (
+    id BIGSERIAL PRIMARY KEY,
+    term TEXT NOT NULL,
+    version TEXT NOT NULL,
+    source TEXT NOT NULL,
+    batch_id BIGINT REFERENCES phrase_batches (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_technology_version_term ON technology_versions (term)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_technology_version_batch_id ON technology_versions (batch_id)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS language_requirements;
+
+DROP INDEX IF EXISTS idx_language_requirement_batch_id;
+DROP INDEX IF EXISTS idx_language_requirement_language;
@@ -0,0 +1,17 @@
+-- Spoken languages required by phrases of a batch at a CEFR level, or native.
+CREATE TABLE IF NOT EXISTS language_requirements (
+    id BIGSERIAL PRIMARY KEY,
+    language TEXT NOT NULL,
+    proficiency TEXT NOT NULL,
+    source TEXT NOT NULL,
+    batch_id BIGINT REFERENCES phrase_batches (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CHECK (proficiency IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2', 'native'))
+);
+
+CREATE INDEX idx_language_requirement_language ON language_requirements (language)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_language_requirement_batch_id ON language_requirements (batch_id)
+WHERE deleted_at IS NULL;
@@ -1,8 +1,8 @@
 package v1
 
 import (
-	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,9 +10,14 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/imgprep"
+	"github.com/kndrad/piccrack/pkg/imgsniff"
 	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/pdftext"
+	"github.com/kndrad/piccrack/pkg/textproc"
 )
 
 func limitValue(values url.Values) (int32, error) {
@@ -53,6 +58,104 @@ func offsetValue(values url.Values) (int32, error) {
 	return int32(n), nil
 }
 
+// batchFilterValue returns a filter of phrase batches of comma-separated
+// attributes and mentioned terms passed in query values, e.g.
+// ?work_mode=remote&contract=b2b&mentions=go.
+func batchFilterValue(values url.Values) (BatchFilter, error) {
+	var f BatchFilter
+	for _, name := range textproc.AttributeNames {
+		for _, v := range splitValue(values.Get(name)) {
+			a, err := textproc.ParseAttribute(name, v)
+			if err != nil {
+				return f, fmt.Errorf("parse attribute: %w", err)
+			}
+			f.Attributes = append(f.Attributes, a)
+		}
+	}
+	f.Mentions = splitValue(values.Get("mentions"))
+
+	return f, nil
+}
+
+// splitValue returns non-empty comma-separated values of v.
+func splitValue(v string) []string {
+	parts := make([]string, 0)
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return parts
+}
+
+// ocrOptions overrides opts with recognition options passed in query values.
+func ocrOptions(values url.Values, opts ocr.Options) (ocr.Options, error) {
+	if values.Has("preprocess") {
+		steps, err := imgprep.ParseSteps(values.Get("preprocess"))
+		if err != nil {
+			return opts, fmt.Errorf("parse steps: %w", err)
+		}
+		opts.Preprocess = steps
+	}
+	if values.Has("lang") {
+		langs, err := ocr.ParseLanguages(values.Get("lang"))
+		if err != nil {
+			return opts, fmt.Errorf("parse languages: %w", err)
+		}
+		opts.Languages = langs
+	}
+	if values.Has("region") {
+		regions, err := ocr.ParseRegions(values["region"]...)
+		if err != nil {
+			return opts, fmt.Errorf("parse regions: %w", err)
+		}
+		opts.Regions = regions
+	}
+
+	return opts, nil
+}
+
+// scanErrorCode returns http status code of an error returned during ocr.
+func scanErrorCode(err error) int {
+	switch {
+	case errors.Is(err, ocr.ErrNotAnImage), errors.Is(err, imgprep.ErrEmptyRegion):
+		return http.StatusBadRequest
+	case errors.Is(err, ocr.ErrTimeout):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// pdfErrorCode returns http status code of an error returned
+// during pdf text extraction.
+func pdfErrorCode(err error) int {
+	switch {
+	case errors.Is(err, pdftext.ErrNotPDF):
+		return http.StatusBadRequest
+	case errors.Is(err, pdftext.ErrNoTextLayer):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// sniffImage returns format of an image read from the start of rs.
+// Offset of rs is restored to the start afterwards.
+func sniffImage(rs io.ReadSeeker) (imgsniff.Format, error) {
+	data := make([]byte, 512)
+	n, err := io.ReadFull(rs, data)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return imgsniff.Unknown, fmt.Errorf("read full: %w", err)
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return imgsniff.Unknown, fmt.Errorf("seek: %w", err)
+	}
+
+	return imgsniff.Sniff(data[:n]), nil
+}
+
 func encode[T any](w http.ResponseWriter, _ *http.Request, status int, v T) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -227,17 +330,13 @@ func uploadWordsHandler(svc Service, logger *slog.Logger) http.HandlerFunc {
 			slog.String("filename", fheader.Filename),
 		)
 
-		// Read many words one by one from file
-		scanner := bufio.NewScanner(f)
-		scanner.Split(bufio.ScanWords)
+		content, err := io.ReadAll(f)
+		if err != nil {
+			respondJSON(w, "Failed to read file", err, http.StatusInternalServerError)
 
-		var words []string
-		for scanner.Scan() {
-			words = append(words, scanner.Text())
-		}
-		if err := scanner.Err(); err != nil {
-			respondJSON(w, "Scanner returned an error", err, http.StatusInternalServerError)
+			return
 		}
+		words := textproc.Tokens(string(content))
 		count := 0
 		for _, word := range words {
 			_, err := svc.CreateWord(r.Context(), word)
@@ -259,7 +358,7 @@ func uploadWordsHandler(svc Service, logger *slog.Logger) http.HandlerFunc {
 	}
 }
 
-func uploadImageWordsHandler(svc Service, logger *slog.Logger) http.HandlerFunc {
+func uploadImageWordsHandler(svc Service, e ocr.Engine, opts ocr.Options, logger *slog.Logger) http.HandlerFunc {
 	var maxSize int64 = 1024 * 1024 * 50 // 50 MB
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -267,59 +366,48 @@ func uploadImageWordsHandler(svc Service, logger *slog.Logger) http.HandlerFunc
 
 		if err := r.ParseMultipartForm(maxSize); err != nil {
 			respondJSON(w, "Image file too big", err, http.StatusBadRequest)
+
+			return
 		}
 		f, header, err := r.FormFile("image")
 		if err != nil {
 			respondJSON(w, "Failed to get image file", err, http.StatusBadRequest)
-		}
-		defer f.Close()
 
-		// Detect content type of file and check if it's a text
-		data := make([]byte, 512)
-		if _, err := f.Read(data); err != nil {
-			respondJSON(w, "Failed to read image file into buffer", err, http.StatusInternalServerError)
+			return
 		}
-		contentType := http.DetectContentType(data)
+		defer f.Close()
 
-		var allowed bool
+		format, err := sniffImage(f)
+		if err != nil {
+			respondJSON(w, "Failed to read image file", err, http.StatusInternalServerError)
 
-		switch contentType {
-		case "image/jpeg", "image/png":
-			allowed = true
-		default:
-			allowed = false
-		}
-		// Return pointer back to the start of the file after content type detection
-		if _, err := f.Seek(0, io.SeekStart); err != nil {
-			respondJSON(w, "Failed to seek to start of the file", err, http.StatusInternalServerError)
-		}
-		if !allowed {
-			respondJSON(w,
-				fmt.Sprintf("Content type %s not allowed. Upload text file", contentType),
-				nil,
-				http.StatusBadRequest,
-			)
+			return
 		}
-		logger.Info("Received form", slog.String("header_filename", header.Filename))
+		if format == imgsniff.Unknown {
+			respondJSON(w, "Unsupported image format", nil, http.StatusBadRequest)
 
-		content := make([]byte, ocr.MaxImageSize)
-		if _, err := f.Read(content); err != nil {
-			respondJSON(w,
-				"Failed to read file content for image words recognition.",
-				err,
-				http.StatusInternalServerError,
-			)
+			return
 		}
-		c := ocr.NewClient()
-		defer c.Close()
+		logger.Info("Received form",
+			slog.String("header_filename", header.Filename),
+			slog.String("format", string(format)),
+		)
 
-		result, err := ocr.ScanFile(c, header.Filename)
+		opts, err := ocrOptions(r.URL.Query(), opts)
+		if err != nil {
+			respondJSON(w, "Invalid recognition options", err, http.StatusBadRequest)
+
+			return
+		}
+		result, err := ocr.ScanFrom(r.Context(), e, f, opts)
 		if err != nil {
 			respondJSON(w,
 				"Failed to recognize words from an image",
 				err,
-				http.StatusInternalServerError,
+				scanErrorCode(err),
 			)
+
+			return
 		}
 
 		var words []string
@@ -330,6 +418,8 @@ func uploadImageWordsHandler(svc Service, logger *slog.Logger) http.HandlerFunc
 		row, err := svc.CreateWordsBatch(r.Context(), header.Filename, words)
 		if err != nil {
 			respondJSON(w, "Failed to insert words batch", err, http.StatusInternalServerError)
+
+			return
 		}
 
 		response := struct {
@@ -408,3 +498,179 @@ func listWordsByBatchNameHandler(svc Service, l *slog.Logger) http.HandlerFunc {
 		}
 	}
 }
+
+// listTfidfRankingsHandler ranks words by TF-IDF, treating every word and
+// phrase batch as a document. Words are scored for batches of the name passed
+// in query values, or for all batches, and optionally only those of categories
+// and grouped by category.
+func listTfidfRankingsHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	type response struct {
+		Batch  string                                     `json:"batch,omitempty"`
+		Rows   []database.ListTfidfRankingsRow            `json:"rows,omitempty"`
+		Groups map[string][]database.ListTfidfRankingsRow `json:"groups,omitempty"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+
+		limit, err := limitValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid limit", err, http.StatusBadRequest)
+
+			return
+		}
+		offset, err := offsetValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid offset", err, http.StatusBadRequest)
+
+			return
+		}
+		cq, err := parseCategoryQuery(r.Context(), svc, values)
+		if err != nil {
+			respondJSON(w, "Invalid category", err, categoryErrorCode(err))
+
+			return
+		}
+		batch := values.Get("batch")
+
+		l.Info("Ranking words by tf-idf", "batch", batch)
+
+		queryLimit, queryOffset := cq.page(limit, offset)
+		rows, err := svc.ListTfidfRankings(r.Context(), batch, cq.terms, queryLimit, queryOffset)
+		if err != nil {
+			respondJSON(w, "Failed to list tf-idf rankings", err, http.StatusInternalServerError)
+
+			return
+		}
+		resp := response{
+			Batch: batch,
+			Rows:  rows,
+		}
+		if cq.group {
+			resp.Rows = nil
+			resp.Groups = textproc.GroupByCategory(cq.taxonomy, rows,
+				func(row database.ListTfidfRankingsRow) string { return row.Value },
+				int(limit),
+			)
+		}
+		if err := encode(w, r, http.StatusOK, resp); err != nil {
+			respondJSON(w, "Failed to serve response", err, http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// listWeightedRankingsHandler ranks words of phrases by weights of offer
+// sections they are found in, so a requirement outranks a nice to have
+// keyword. Words are ranked in batches of the name passed in query values,
+// or in all batches, and optionally only those of categories and grouped
+// by category. Batches are optionally only those of job offers having
+// attributes and mentioning terms, e.g.
+// ?category=databases&work_mode=remote&contract=b2b&mentions=go.
+func listWeightedRankingsHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+
+		limit, err := limitValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid limit", err, http.StatusBadRequest)
+
+			return
+		}
+		offset, err := offsetValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid offset", err, http.StatusBadRequest)
+
+			return
+		}
+		cq, err := parseCategoryQuery(r.Context(), svc, values)
+		if err != nil {
+			respondJSON(w, "Invalid category", err, categoryErrorCode(err))
+
+			return
+		}
+		f, err := batchFilterValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid batch filter", err, http.StatusBadRequest)
+
+			return
+		}
+		batch := values.Get("batch")
+
+		l.Info("Ranking words by section weights", "batch", batch)
+
+		queryLimit, queryOffset := cq.page(limit, offset)
+		rows, err := svc.ListWeightedRankings(r.Context(), batch, cq.terms, f, queryLimit, queryOffset)
+		if err != nil {
+			respondJSON(w, "Failed to list weighted rankings", err, http.StatusInternalServerError)
+
+			return
+		}
+		respondRankings(w, r, cq, rows, func(row database.ListWeightedRankingsRow) string { return row.Value }, limit)
+	}
+}
+
+func uploadPDFWordsHandler(svc Service, logger *slog.Logger) http.HandlerFunc {
+	var maxSize int64 = 1024 * 1024 * 50 // 50 MB
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+
+		if err := r.ParseMultipartForm(maxSize); err != nil {
+			respondJSON(w, "PDF file too big", err, http.StatusBadRequest)
+
+			return
+		}
+		f, header, err := r.FormFile("pdf")
+		if err != nil {
+			respondJSON(w, "Failed to get pdf file", err, http.StatusBadRequest)
+
+			return
+		}
+		defer f.Close()
+
+		logger.Info("Received form", slog.String("header_filename", header.Filename))
+
+		content, err := io.ReadAll(f)
+		if err != nil {
+			respondJSON(w, "Failed to read pdf file", err, http.StatusInternalServerError)
+
+			return
+		}
+		pages, err := pdftext.Extract(content)
+		if err != nil {
+			respondJSON(w, "Failed to read pdf text", err, pdfErrorCode(err))
+
+			return
+		}
+
+		var words, sources []string
+		for _, page := range pages {
+			for line := range textproc.ScanLines(page.Text) {
+				for _, word := range textproc.Tokens(line) {
+					words = append(words, word)
+					sources = append(sources, page.Source(header.Filename))
+				}
+			}
+		}
+
+		row, err := svc.CreateWordsBatchWithSources(r.Context(), header.Filename, words, sources)
+		if err != nil {
+			respondJSON(w, "Failed to insert words batch", err, http.StatusInternalServerError)
+
+			return
+		}
+
+		response := struct {
+			Pages int                                     `json:"pages"`
+			Row   database.CreateWordsBatchWithSourcesRow `json:"row"`
+		}{
+			Pages: len(pages),
+			Row:   row,
+		}
+		if err := encode(w, r, http.StatusOK, response); err != nil {
+			respondJSON(w, "Failed to encode response", err, http.StatusInternalServerError)
+		}
+	}
+}
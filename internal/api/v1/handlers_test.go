@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/gif"
 	"io"
 	"math"
 	"math/rand/v2"
@@ -13,10 +15,15 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/imgprep"
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -302,6 +309,91 @@ func TestGetOffsetFromQuery(t *testing.T) {
 	}
 }
 
+func TestOCROptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		query       string
+		want        imgprep.Steps
+		wantLangs   []string
+		wantRegions []image.Rectangle
+		wantErr     bool
+	}{
+		{
+			desc:  "keeps_default_without_param",
+			query: "",
+			want:  imgprep.Grayscale,
+		},
+		{
+			desc:      "overrides_languages",
+			query:     "lang=pol",
+			want:      imgprep.Grayscale,
+			wantLangs: []string{"pol"},
+		},
+		{
+			desc:    "invalid_language_fails",
+			query:   "lang=po/l",
+			wantErr: true,
+		},
+		{
+			desc:  "overrides_steps",
+			query: "preprocess=invert,upscale",
+			want:  imgprep.Invert | imgprep.Upscale,
+		},
+		{
+			desc:  "empty_param_disables_steps",
+			query: "preprocess=",
+			want:  imgprep.None,
+		},
+		{
+			desc:    "unknown_step_fails",
+			query:   "preprocess=blur",
+			wantErr: true,
+		},
+		{
+			desc:  "many_regions",
+			query: "region=0,0,10,20&region=5,5,10,10",
+			want:  imgprep.Grayscale,
+			wantRegions: []image.Rectangle{
+				image.Rect(0, 0, 10, 20),
+				image.Rect(5, 5, 15, 15),
+			},
+		},
+		{
+			desc:    "invalid_region_fails",
+			query:   "region=0,0,10",
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			values, err := url.ParseQuery(tC.query)
+			require.NoError(t, err)
+
+			base := ocr.DefaultOptions()
+			base.Preprocess = imgprep.Grayscale
+
+			opts, err := ocrOptions(values, base)
+			if tC.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tC.want, opts.Preprocess)
+
+			wantLangs := tC.wantLangs
+			if wantLangs == nil {
+				wantLangs = ocr.DefaultLanguages
+			}
+			require.Equal(t, wantLangs, opts.Languages)
+			require.Equal(t, tC.wantRegions, opts.Regions)
+		})
+	}
+}
+
 func TestUploadWordsHandler(t *testing.T) {
 	t.Parallel()
 
@@ -448,3 +540,445 @@ func writeTestWords(w io.Writer, total int) error {
 
 	return nil
 }
+
+func TestUploadImageWordsHandler(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		minConfidence float64
+		wantWords     []string
+	}{
+		{
+			desc: "inserts_every_recognized_word",
+
+			minConfidence: 0,
+			wantWords:     []string{"go", "developer", "rnodern"},
+		},
+		{
+			desc: "skips_low_confidence_words",
+
+			minConfidence: 50,
+			wantWords:     []string{"go", "developer"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			q := NewQueriesMock()
+			svc := NewService(q, testLogger())
+
+			e := ocrtest.NewEngineWithWords(
+				append(ocrtest.Words("golang developer", 90), ocrtest.Words("rnodern,", 10)...)...,
+			)
+			opts := ocr.DefaultOptions()
+			opts.MinConfidence = tC.minConfidence
+
+			body := new(bytes.Buffer)
+			w := multipart.NewWriter(body)
+
+			part, err := w.CreateFormFile("image", "0.png")
+			require.NoError(t, err)
+
+			img, err := os.ReadFile(filepath.Join("testdata", "0.png"))
+			require.NoError(t, err)
+			_, err = part.Write(img)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", w.FormDataContentType())
+
+			rr := httptest.NewRecorder()
+			uploadImageWordsHandler(svc, e, opts, testLogger())(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.Len(t, q.wordsBatches, 1)
+			require.ElementsMatch(t, tC.wantWords, q.wordsBatches[0].Column2)
+		})
+	}
+}
+
+func TestUploadImageWordsHandlerRegions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		query     string
+		wantCode  int
+		wantScans int
+	}{
+		{
+			desc: "every_region_is_scanned",
+
+			query:     "?region=0,0,40,20&region=40,20,40,20",
+			wantCode:  http.StatusOK,
+			wantScans: 2,
+		},
+		{
+			desc: "region_outside_of_image",
+
+			query:    "?region=100000,100000,10,10",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			q := NewQueriesMock()
+			svc := NewService(q, testLogger())
+			e := ocrtest.NewEngine("golang developer")
+
+			body := new(bytes.Buffer)
+			w := multipart.NewWriter(body)
+			part, err := w.CreateFormFile("image", "0.png")
+			require.NoError(t, err)
+			img, err := os.ReadFile(filepath.Join("testdata", "0.png"))
+			require.NoError(t, err)
+			_, err = part.Write(img)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			req := httptest.NewRequest(http.MethodPost, "/"+tC.query, body)
+			req.Header.Set("Content-Type", w.FormDataContentType())
+
+			rr := httptest.NewRecorder()
+			uploadImageWordsHandler(svc, e, ocr.DefaultOptions(), testLogger())(rr, req)
+
+			require.Equal(t, tC.wantCode, rr.Code)
+			require.Equal(t, tC.wantScans, e.Scans())
+			if tC.wantCode == http.StatusOK {
+				require.Len(t, q.wordsBatches[0].Column2, 2*tC.wantScans)
+			}
+		})
+	}
+}
+
+func TestUploadImageHandlersFormat(t *testing.T) {
+	t.Parallel()
+
+	img := image.NewGray(image.Rect(0, 0, 8, 8))
+	gifBuf := new(bytes.Buffer)
+	require.NoError(t, gif.Encode(gifBuf, img, nil))
+
+	testCases := []struct {
+		desc string
+
+		content  []byte
+		wantCode int
+	}{
+		{
+			desc: "gif_is_accepted",
+
+			content:  gifBuf.Bytes(),
+			wantCode: http.StatusOK,
+		},
+		{
+			desc: "text_is_rejected",
+
+			content:  []byte("golang developer"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc: "jpeg_2000_is_rejected",
+
+			content:  []byte{0xFF, 0x4F, 0xFF, 0x51, 0x00, 0x2F},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	handlers := map[string]func(Service, ocr.Engine) http.HandlerFunc{
+		"words": func(svc Service, e ocr.Engine) http.HandlerFunc {
+			return uploadImageWordsHandler(svc, e, ocr.DefaultOptions(), testLogger())
+		},
+		"phrases": func(svc Service, e ocr.Engine) http.HandlerFunc {
+			return uploadImagePhrasesHandler(svc, e, ocr.DefaultOptions(), testLogger())
+		},
+	}
+	for name, handler := range handlers {
+		for _, tC := range testCases {
+			t.Run(name+"_"+tC.desc, func(t *testing.T) {
+				t.Parallel()
+
+				svc := NewService(NewQueriesMock(), testLogger())
+				e := ocrtest.NewEngine("golang developer")
+
+				body := new(bytes.Buffer)
+				w := multipart.NewWriter(body)
+				part, err := w.CreateFormFile("image", "upload")
+				require.NoError(t, err)
+				_, err = part.Write(tC.content)
+				require.NoError(t, err)
+				require.NoError(t, w.Close())
+
+				req := httptest.NewRequest(http.MethodPost, "/", body)
+				req.Header.Set("Content-Type", w.FormDataContentType())
+
+				rr := httptest.NewRecorder()
+				handler(svc, e)(rr, req)
+
+				require.Equal(t, tC.wantCode, rr.Code)
+				if tC.wantCode != http.StatusOK {
+					require.Zero(t, e.Scans())
+				}
+			})
+		}
+	}
+}
+
+func TestUploadImageHandlersTimeout(t *testing.T) {
+	t.Parallel()
+
+	opts := ocr.DefaultOptions()
+	opts.Timeout = 10 * time.Millisecond
+
+	handlers := map[string]func(Service, ocr.Engine) http.HandlerFunc{
+		"words": func(svc Service, e ocr.Engine) http.HandlerFunc {
+			return uploadImageWordsHandler(svc, e, opts, testLogger())
+		},
+		"phrases": func(svc Service, e ocr.Engine) http.HandlerFunc {
+			return uploadImagePhrasesHandler(svc, e, opts, testLogger())
+		},
+	}
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			q := NewQueriesMock()
+			svc := NewService(q, testLogger())
+			e := ocrtest.NewSlowEngine("golang developer", time.Hour)
+
+			body := new(bytes.Buffer)
+			w := multipart.NewWriter(body)
+			part, err := w.CreateFormFile("image", "0.png")
+			require.NoError(t, err)
+			content, err := os.ReadFile(filepath.Join("testdata", "0.png"))
+			require.NoError(t, err)
+			_, err = part.Write(content)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set("Content-Type", w.FormDataContentType())
+
+			rr := httptest.NewRecorder()
+			handler(svc, e)(rr, req)
+
+			require.Equal(t, http.StatusGatewayTimeout, rr.Code)
+			require.Equal(t, 1, e.Scans())
+		})
+	}
+}
+
+func TestUploadPDFWordsHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	rr := httptest.NewRecorder()
+	uploadPDFWordsHandler(svc, testLogger())(rr, pdfUploadRequest(t, filepath.Join("testdata", "job_offer.pdf")))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Len(t, q.sourcedWordsBatches, 1)
+
+	batch := q.sourcedWordsBatches[0]
+	require.Equal(t, []string{
+		"senior", "go", "developer", "requirements", "docker", "kubernetes",
+		"nice", "to", "have", "postgresql", "remote", "work",
+	}, batch.Column2)
+	require.Equal(t, "job_offer.pdf#page=1", batch.Column3[0])
+	require.Equal(t, "job_offer.pdf#page=2", batch.Column3[len(batch.Column3)-1])
+}
+
+func TestListTfidfRankingsHandler(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		query     string
+		wantCode  int
+		wantBatch string
+		wantLimit int32
+		wantTerms []string
+		wantTop   string
+	}{
+		{
+			desc: "all_batches",
+
+			query:     "",
+			wantCode:  http.StatusOK,
+			wantLimit: 1000,
+			wantTop:   "experience",
+		},
+		{
+			desc: "batch_by_name",
+
+			query:     "?batch=offer.pdf&limit=10&offset=5",
+			wantCode:  http.StatusOK,
+			wantBatch: "offer.pdf",
+			wantLimit: 10,
+			wantTop:   "experience",
+		},
+		{
+			desc: "category",
+
+			query:     "?category=databases",
+			wantCode:  http.StatusOK,
+			wantLimit: 1000,
+			wantTerms: []string{"postgresql", "redis"},
+			wantTop:   "postgresql",
+		},
+		{
+			desc: "invalid_limit",
+
+			query:    "?limit=-1",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			q := NewQueriesMock(rankedWordsMock("experience", "postgresql", "go")...)
+			svc := NewService(q, testLogger())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/"+tC.query, nil)
+			listTfidfRankingsHandler(svc, testLogger())(rr, req)
+
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				require.Empty(t, q.tfidfParams)
+
+				return
+			}
+			require.Len(t, q.tfidfParams, 1)
+			require.Equal(t, tC.wantBatch, q.tfidfParams[0].Column1)
+			require.Equal(t, tC.wantLimit, q.tfidfParams[0].Limit)
+			for _, term := range tC.wantTerms {
+				require.Contains(t, q.tfidfParams[0].Column4, term)
+			}
+
+			var resp struct {
+				Batch string                          `json:"batch"`
+				Rows  []database.ListTfidfRankingsRow `json:"rows"`
+			}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			require.Equal(t, tC.wantBatch, resp.Batch)
+			require.NotEmpty(t, resp.Rows)
+			require.Equal(t, tC.wantTop, resp.Rows[0].Value)
+			require.Equal(t, int64(1), resp.Rows[0].Ranking)
+		})
+	}
+}
+
+func TestListWeightedRankingsHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	_, err := svc.CreatePhrasesBatchWithSources(context.Background(), "offer.pdf",
+		[]string{"Go Docker", "Kafka, Golang.", "Docker Kafka"},
+		[]string{"offer.pdf#page=1", "offer.pdf#page=1", "offer.pdf#page=2"},
+		[]string{"en", "en", "en"},
+		[]string{"requirements", "nice_to_have", "benefits"},
+	)
+	require.NoError(t, err)
+	_, err = svc.CreatePhrasesBatch(context.Background(), "offer.png",
+		[]string{"kafka kafka kafka"},
+		[]string{"en"},
+		[]string{""},
+	)
+	require.NoError(t, err)
+	_, err = svc.CreateBatchAttributes(context.Background(), 1, []string{"Remote, B2B"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+
+		query      string
+		wantCode   int
+		wantValues []string
+		wantScores []float64
+	}{
+		{
+			desc: "all_batches",
+
+			query:      "",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"kafka", "go", "docker"},
+			wantScores: []float64{2.4, 1.4, 1.2},
+		},
+		{
+			desc: "requirements_outrank_nice_to_have",
+
+			query:      "?batch=offer.pdf",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"go", "docker", "kafka"},
+			wantScores: []float64{1.4, 1.2, 0.6},
+		},
+		{
+			desc: "remote_b2b_offers_mentioning_an_alias",
+
+			query:      "?work_mode=remote&contract=b2b&mentions=golang",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"go", "docker", "kafka"},
+			wantScores: []float64{1.4, 1.2, 0.6},
+		},
+		{
+			desc: "offers_mentioning_a_term",
+
+			query:      "?mentions=kafka",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"kafka", "go", "docker"},
+			wantScores: []float64{2.4, 1.4, 1.2},
+		},
+		{
+			desc: "no_offers_of_attributes",
+
+			query:      "?contract=uop",
+			wantCode:   http.StatusOK,
+			wantValues: []string{},
+			wantScores: []float64{},
+		},
+		{
+			desc: "invalid_work_mode",
+
+			query:    "?work_mode=sometimes",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc: "invalid_offset",
+
+			query:    "?offset=-1",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/"+tC.query, nil)
+			listWeightedRankingsHandler(svc, testLogger())(rr, req)
+
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				return
+			}
+
+			var rows []database.ListWeightedRankingsRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+			require.Len(t, rows, len(tC.wantValues))
+			for i, row := range rows {
+				require.Equal(t, tC.wantValues[i], row.Value)
+				require.InDelta(t, tC.wantScores[i], row.Score, 1e-9)
+				require.Equal(t, int64(i)+1, row.Ranking)
+			}
+		})
+	}
+}
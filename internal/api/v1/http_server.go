@@ -13,6 +13,7 @@ import (
 
 	"github.com/kndrad/piccrack/config"
 	"github.com/kndrad/piccrack/pkg/middleware"
+	"github.com/kndrad/piccrack/pkg/ocr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -25,22 +26,44 @@ type server struct {
 	l   *slog.Logger
 }
 
-func NewServer(cfg config.HTTPConfig, svc Service, logger *slog.Logger) (*server, error) {
+// NewServer returns a server which recognizes uploaded images with e,
+// opts are used for every recognition.
+func NewServer(cfg config.HTTPConfig, svc Service, e ocr.Engine, opts ocr.Options, logger *slog.Logger) (*server, error) {
 	if logger == nil {
 		panic("logger cannot be nil")
 	}
+	if e == nil {
+		panic("engine cannot be nil")
+	}
 	const prefix = "/api/" + Version
 
 	mux := http.NewServeMux()
 
 	reg := prometheus.NewRegistry()
 	m := NewMetrics(reg)
+	if ce, ok := e.(*ocr.CachedEngine); ok {
+		reg.MustRegister(cacheCollectors(ce.Stats)...)
+	}
 
 	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
 	mux.Handle("GET "+prefix+"/healthz", m.WrapHandlerFunc(healthzHandler(logger)))
+	mux.Handle("GET "+prefix+"/phrases", listPhrasesHandler(svc, logger))
 	mux.Handle("POST "+prefix+"/phrases",
 		middleware.LogTime(
-			m.WrapHandlerFunc(uploadImagePhrasesHandler(svc, logger)),
+			m.WrapHandlerFunc(uploadImagePhrasesHandler(svc, e, opts, logger)),
+			logger,
+		),
+	)
+	mux.Handle("POST "+prefix+"/phrases/pdf",
+		middleware.LogTime(
+			m.WrapHandlerFunc(uploadPDFPhrasesHandler(svc, logger)),
+			logger,
+		),
+	)
+
+	mux.Handle("POST "+prefix+"/ocr",
+		middleware.LogTime(
+			m.WrapHandlerFunc(recognizeImageHandler(e, opts, logger)),
 			logger,
 		),
 	)
@@ -48,8 +71,30 @@ func NewServer(cfg config.HTTPConfig, svc Service, logger *slog.Logger) (*server
 	mux.Handle("GET "+prefix+"/words", listWordsHandler(svc, logger))
 	mux.Handle("POST "+prefix+"/words", createWordHandler(svc, logger))
 	mux.Handle("POST "+prefix+"/words/file", uploadWordsHandler(svc, logger))
-	mux.Handle("POST "+prefix+"/words/image", uploadImageWordsHandler(svc, logger))
+	mux.Handle("POST "+prefix+"/words/image", uploadImageWordsHandler(svc, e, opts, logger))
+	mux.Handle("POST "+prefix+"/words/pdf", uploadPDFWordsHandler(svc, logger))
 	mux.Handle("GET "+prefix+"/words/batches", middleware.LogTime(listWordsByBatchNameHandler(svc, logger), logger))
+	mux.Handle("GET "+prefix+"/words/tfidf", middleware.LogTime(listTfidfRankingsHandler(svc, logger), logger))
+	mux.Handle("GET "+prefix+"/words/weighted", middleware.LogTime(listWeightedRankingsHandler(svc, logger), logger))
+	mux.Handle("GET "+prefix+"/words/rankings", middleware.LogTime(listWordRankingsHandler(svc, logger), logger))
+
+	mux.Handle("GET "+prefix+"/salaries/stats", listSalaryStatsHandler(svc, logger))
+	mux.Handle("GET "+prefix+"/experiences", listExperienceDistributionHandler(svc, logger))
+	mux.Handle("GET "+prefix+"/versions", listVersionDistributionHandler(svc, logger))
+	mux.Handle("GET "+prefix+"/languages", listLanguageRequirementSummaryHandler(svc, logger))
+	mux.Handle("GET "+prefix+"/attributes", listBatchAttributesHandler(svc, logger))
+
+	mux.Handle("GET "+prefix+"/skills", listSkillsHandler(svc, logger))
+	mux.Handle("PUT "+prefix+"/skills/{term...}", setSkillHandler(svc, logger))
+	mux.Handle("DELETE "+prefix+"/skills/{term...}", deleteSkillHandler(svc, logger))
+
+	mux.Handle("GET "+prefix+"/ngrams", listNgramRankingsHandler(svc, logger))
+	mux.Handle("POST "+prefix+"/ngrams",
+		middleware.LogTime(
+			m.WrapHandlerFunc(uploadNgramsHandler(svc, logger)),
+			logger,
+		),
+	)
 
 	var handler http.Handler = mux
 
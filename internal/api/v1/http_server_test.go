@@ -7,9 +7,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/kndrad/piccrack/config"
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,6 +52,8 @@ func TestServerStart(t *testing.T) {
 					q:      NewQueriesMock(NewWordsMock()...),
 					logger: testLogger(),
 				},
+				ocrtest.NewEngine(""),
+				ocr.DefaultOptions(),
 				testLogger(),
 			)
 			require.NoError(t, err)
@@ -69,6 +76,26 @@ func TestServerStart(t *testing.T) {
 	}
 }
 
+func TestCacheCollectors(t *testing.T) {
+	t.Parallel()
+
+	e := ocr.NewCachedEngine(ocrtest.NewEngine("golang"), ocr.NewMemoryCache(10))
+	for range 3 {
+		_, err := ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "0.png"), ocr.DefaultOptions())
+		require.NoError(t, err)
+	}
+
+	collectors := cacheCollectors(e.Stats)
+	require.Len(t, collectors, 2)
+	require.InDelta(t, 2.0, testutil.ToFloat64(collectors[0]), 0.001) // Hits
+	require.InDelta(t, 1.0, testutil.ToFloat64(collectors[1]), 0.001) // Misses
+
+	// Both counters share a name
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(collectors[0]))
+	require.NoError(t, reg.Register(collectors[1]))
+}
+
 func TestWriteJSONErr(t *testing.T) {
 	t.Parallel()
 
@@ -3,6 +3,7 @@ package v1
 import (
 	"net/http"
 
+	"github.com/kndrad/piccrack/pkg/ocr"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -42,3 +43,29 @@ func (m *metrics) WrapHandlerFunc(next http.HandlerFunc) http.HandlerFunc {
 		next.ServeHTTP(w, r)
 	}
 }
+
+// cacheCollectors returns counters of ocr cache lookups labeled by result,
+// hit ratio is a rate of hits divided by a rate of all lookups.
+func cacheCollectors(stats func() ocr.CacheStats) []prometheus.Collector {
+	const name = "ocr_cache_lookups_total"
+	const help = "Counter for ocr result cache lookups with a result (hit or miss)."
+
+	return []prometheus.Collector{
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Name:        name,
+				Help:        help,
+				ConstLabels: prometheus.Labels{"result": "hit"},
+			},
+			func() float64 { return float64(stats().Hits) },
+		),
+		prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Name:        name,
+				Help:        help,
+				ConstLabels: prometheus.Labels{"result": "miss"},
+			},
+			func() float64 { return float64(stats().Misses) },
+		),
+	}
+}
@@ -1,11 +1,16 @@
 package v1
 
 import (
+	"cmp"
 	"context"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -174,6 +179,24 @@ type QueriesMock struct {
 	wordsRows            []database.ListWordsRow
 	wordsFrequenciesRows []database.ListWordFrequenciesRow
 	wordsRankRows        []database.ListWordRankingsRow
+
+	mu                    sync.Mutex
+	wordsBatches          []database.CreateWordsBatchParams
+	phrasesBatches        []database.CreatePhrasesBatchParams
+	sourcedWordsBatches   []database.CreateWordsBatchWithSourcesParams
+	sourcedPhrasesBatches []database.CreatePhrasesBatchWithSourcesParams
+	phraseTerms           []database.CreatePhraseTermsParams
+	ngramsBatches         []database.CreateNgramsBatchParams
+	tfidfParams           []database.ListTfidfRankingsParams
+	salaries              []database.CreateSalariesParams
+	experiences           []database.CreateExperiencesParams
+	attributes            []database.CreateBatchAttributesParams
+	versions              []database.CreateTechnologyVersionsParams
+	languages             []database.CreateLanguageRequirementsParams
+	batchNames            []string
+	salaryStatsParams     []database.ListSalaryStatsParams
+	skillTerms            map[string]pgtype.Text
+	skillTermsLists       int
 }
 
 func NewQueriesMock(words ...WordMock) *QueriesMock {
@@ -191,8 +214,166 @@ func NewQueriesMock(words ...WordMock) *QueriesMock {
 	}
 }
 
+func (q *QueriesMock) CreateBatchAttributes(ctx context.Context, arg database.CreateBatchAttributesParams) ([]database.CreateBatchAttributesRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.attributes = append(q.attributes, arg)
+
+	rows := make([]database.CreateBatchAttributesRow, 0, len(arg.Column2))
+	for i := range arg.Column2 {
+		rows = append(rows, database.CreateBatchAttributesRow{
+			ID:      int64(i) + 1,
+			Name:    arg.Column2[i],
+			Value:   arg.Column3[i],
+			BatchID: arg.Column1,
+		})
+	}
+
+	return rows, nil
+}
+
+// hasAttribute reports whether a batch of id has an attribute of name
+// and value.
+func (q *QueriesMock) hasAttribute(id int64, name, value string) bool {
+	for _, a := range q.attributes {
+		if a.Column1 != id {
+			continue
+		}
+		for i := range a.Column2 {
+			if a.Column2[i] == name && a.Column3[i] == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (q *QueriesMock) CreateExperiences(ctx context.Context, arg database.CreateExperiencesParams) ([]database.CreateExperiencesRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.experiences = append(q.experiences, arg)
+
+	rows := make([]database.CreateExperiencesRow, 0, len(arg.Column2))
+	for i := range arg.Column2 {
+		rows = append(rows, database.CreateExperiencesRow{
+			ID:       int64(i) + 1,
+			Term:     pgtype.Text{String: arg.Column2[i], Valid: arg.Column2[i] != ""},
+			MinYears: arg.Column3[i],
+			MaxYears: pgtype.Int4{Int32: arg.Column4[i], Valid: arg.Column4[i] != 0},
+			Source:   arg.Column5[i],
+			BatchID:  pgtype.Int8{Int64: arg.Column1, Valid: true},
+		})
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) CreateLanguageRequirements(ctx context.Context, arg database.CreateLanguageRequirementsParams) ([]database.CreateLanguageRequirementsRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.languages = append(q.languages, arg)
+
+	rows := make([]database.CreateLanguageRequirementsRow, 0, len(arg.Column2))
+	for i := range arg.Column2 {
+		rows = append(rows, database.CreateLanguageRequirementsRow{
+			ID:          int64(i) + 1,
+			Language:    arg.Column2[i],
+			Proficiency: arg.Column3[i],
+			Source:      arg.Column4[i],
+			BatchID:     pgtype.Int8{Int64: arg.Column1, Valid: true},
+		})
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) CreateNgramsBatch(ctx context.Context, arg database.CreateNgramsBatchParams) (database.CreateNgramsBatchRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.ngramsBatches = append(q.ngramsBatches, arg)
+
+	return database.CreateNgramsBatchRow{}, nil
+}
+
+func (q *QueriesMock) CreatePhraseTerms(ctx context.Context, arg database.CreatePhraseTermsParams) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.phraseTerms = append(q.phraseTerms, arg)
+
+	return nil
+}
+
 func (q *QueriesMock) CreatePhrasesBatch(ctx context.Context, arg database.CreatePhrasesBatchParams) (database.CreatePhrasesBatchRow, error) {
-	return database.CreatePhrasesBatchRow{}, nil
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.phrasesBatches = append(q.phrasesBatches, arg)
+	q.batchNames = append(q.batchNames, arg.Name)
+
+	return database.CreatePhrasesBatchRow{
+		BatchID: pgtype.Int8{Int64: int64(len(q.batchNames)), Valid: true},
+	}, nil
+}
+
+func (q *QueriesMock) CreatePhrasesBatchWithSources(ctx context.Context, arg database.CreatePhrasesBatchWithSourcesParams) (database.CreatePhrasesBatchWithSourcesRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.sourcedPhrasesBatches = append(q.sourcedPhrasesBatches, arg)
+	q.batchNames = append(q.batchNames, arg.Name)
+
+	return database.CreatePhrasesBatchWithSourcesRow{
+		BatchID: pgtype.Int8{Int64: int64(len(q.batchNames)), Valid: true},
+	}, nil
+}
+
+func (q *QueriesMock) CreateSalaries(ctx context.Context, arg database.CreateSalariesParams) ([]database.CreateSalariesRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.salaries = append(q.salaries, arg)
+
+	rows := make([]database.CreateSalariesRow, 0, len(arg.Column2))
+	for i := range arg.Column2 {
+		rows = append(rows, database.CreateSalariesRow{
+			ID:        int64(i) + 1,
+			MinAmount: arg.Column2[i],
+			MaxAmount: arg.Column3[i],
+			Currency:  arg.Column4[i],
+			Period:    arg.Column5[i],
+			Contract:  pgtype.Text{String: arg.Column6[i], Valid: arg.Column6[i] != ""},
+			Source:    arg.Column7[i],
+			BatchID:   pgtype.Int8{Int64: arg.Column1, Valid: true},
+		})
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) CreateTechnologyVersions(ctx context.Context, arg database.CreateTechnologyVersionsParams) ([]database.CreateTechnologyVersionsRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.versions = append(q.versions, arg)
+
+	rows := make([]database.CreateTechnologyVersionsRow, 0, len(arg.Column2))
+	for i := range arg.Column2 {
+		rows = append(rows, database.CreateTechnologyVersionsRow{
+			ID:      int64(i) + 1,
+			Term:    arg.Column2[i],
+			Version: arg.Column3[i],
+			Source:  arg.Column4[i],
+			BatchID: pgtype.Int8{Int64: arg.Column1, Valid: true},
+		})
+	}
+
+	return rows, nil
 }
 
 func (q *QueriesMock) CreateWord(ctx context.Context, value string) (database.CreateWordRow, error) {
@@ -211,13 +392,364 @@ func (q *QueriesMock) CreateWord(ctx context.Context, value string) (database.Cr
 }
 
 func (q *QueriesMock) CreateWordsBatch(ctx context.Context, arg database.CreateWordsBatchParams) (database.CreateWordsBatchRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.wordsBatches = append(q.wordsBatches, arg)
+
 	return database.CreateWordsBatchRow{}, nil
 }
 
+func (q *QueriesMock) CreateWordsBatchWithSources(ctx context.Context, arg database.CreateWordsBatchWithSourcesParams) (database.CreateWordsBatchWithSourcesRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.sourcedWordsBatches = append(q.sourcedWordsBatches, arg)
+
+	return database.CreateWordsBatchWithSourcesRow{}, nil
+}
+
 func (q *QueriesMock) ListWords(ctx context.Context, arg database.ListWordsParams) ([]database.ListWordsRow, error) {
 	return q.wordsRows, nil
 }
 
+func (q *QueriesMock) ListBatchAttributes(ctx context.Context, arg database.ListBatchAttributesParams) ([]database.ListBatchAttributesRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	rows := make([]database.ListBatchAttributesRow, 0)
+	for _, b := range q.attributes {
+		if arg.Column1 != "" && q.batchNames[b.Column1-1] != arg.Column1 {
+			continue
+		}
+		for i, name := range b.Column2 {
+			if arg.Column2 != "" && arg.Column2 != name {
+				continue
+			}
+			j := slices.IndexFunc(rows, func(row database.ListBatchAttributesRow) bool {
+				return row.Name == name && row.Value == b.Column3[i]
+			})
+			if j < 0 {
+				rows = append(rows, database.ListBatchAttributesRow{Name: name, Value: b.Column3[i]})
+				j = len(rows) - 1
+			}
+			rows[j].Total++
+		}
+	}
+	slices.SortFunc(rows, func(a, b database.ListBatchAttributesRow) int {
+		return cmp.Or(
+			strings.Compare(a.Name, b.Name),
+			cmp.Compare(b.Total, a.Total),
+			strings.Compare(a.Value, b.Value),
+		)
+	})
+
+	return rows, nil
+}
+
+func (q *QueriesMock) ListExperienceDistribution(ctx context.Context, arg database.ListExperienceDistributionParams) ([]database.ListExperienceDistributionRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	rows := make([]database.ListExperienceDistributionRow, 0)
+	for _, b := range q.experiences {
+		for i, term := range b.Column2 {
+			if term == "" || (len(arg.Column2) > 0 && !slices.Contains(arg.Column2, term)) {
+				continue
+			}
+			j := slices.IndexFunc(rows, func(row database.ListExperienceDistributionRow) bool {
+				return row.Term == term && row.MinYears == b.Column3[i]
+			})
+			if j < 0 {
+				rows = append(rows, database.ListExperienceDistributionRow{Term: term, MinYears: b.Column3[i]})
+				j = len(rows) - 1
+			}
+			rows[j].Total++
+		}
+	}
+	slices.SortFunc(rows, func(a, b database.ListExperienceDistributionRow) int {
+		if c := strings.Compare(a.Term, b.Term); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.MinYears, b.MinYears)
+	})
+
+	return rows, nil
+}
+
+func (q *QueriesMock) ListLanguageRequirementSummary(ctx context.Context, arg database.ListLanguageRequirementSummaryParams) ([]database.ListLanguageRequirementSummaryRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// highest levels of languages required by every batch
+	required := make(map[int64]map[string]string)
+	for _, b := range q.languages {
+		if arg.Column1 != "" && q.batchNames[b.Column1-1] != arg.Column1 {
+			continue
+		}
+		if required[b.Column1] == nil {
+			required[b.Column1] = make(map[string]string)
+		}
+		for i, language := range b.Column2 {
+			if arg.Column2 != "" && arg.Column2 != language {
+				continue
+			}
+			required[b.Column1][language] = max(required[b.Column1][language], b.Column3[i])
+		}
+	}
+
+	rows := make([]database.ListLanguageRequirementSummaryRow, 0)
+	for _, levels := range required {
+		for language, level := range levels {
+			j := slices.IndexFunc(rows, func(row database.ListLanguageRequirementSummaryRow) bool {
+				return row.Language == language && row.Proficiency == level
+			})
+			if j < 0 {
+				rows = append(rows, database.ListLanguageRequirementSummaryRow{Language: language, Proficiency: level})
+				j = len(rows) - 1
+			}
+			rows[j].Total++
+		}
+	}
+	slices.SortFunc(rows, func(a, b database.ListLanguageRequirementSummaryRow) int {
+		return cmp.Or(strings.Compare(a.Language, b.Language), strings.Compare(a.Proficiency, b.Proficiency))
+	})
+
+	return rows, nil
+}
+
+// ListNgramRankings ranks n-grams of created batches by their total count.
+func (q *QueriesMock) ListNgramRankings(ctx context.Context, arg database.ListNgramRankingsParams) ([]database.ListNgramRankingsRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	totals := make(map[string]int64)
+	lengths := make(map[string]int32)
+	for _, batch := range q.ngramsBatches {
+		for i, value := range batch.Column3 {
+			if arg.Column1 != 0 && batch.Column4[i] != arg.Column1 {
+				continue
+			}
+			if len(arg.Column4) > 0 && !slices.Contains(arg.Column4, value) {
+				continue
+			}
+			totals[value] += int64(batch.Column5[i])
+			lengths[value] = batch.Column4[i]
+		}
+	}
+
+	rows := make([]database.ListNgramRankingsRow, 0, len(totals))
+	for value, total := range totals {
+		rows = append(rows, database.ListNgramRankingsRow{
+			Value: value,
+			N:     lengths[value],
+			Total: total,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Total != rows[j].Total {
+			return rows[i].Total > rows[j].Total
+		}
+
+		return rows[i].Value < rows[j].Value
+	})
+	for i := range rows {
+		rows[i].Ranking = int64(i) + 1
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) ListPhrases(ctx context.Context, arg database.ListPhrasesParams) ([]database.ListPhrasesRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	rows := make([]database.ListPhrasesRow, 0)
+	add := func(name string, values, sources, languages, sections []string) {
+		for i, value := range values {
+			row := database.ListPhrasesRow{
+				ID:        int64(len(rows)) + 1,
+				Value:     value,
+				BatchName: name,
+			}
+			if i < len(sources) {
+				row.Source = pgtype.Text{String: sources[i], Valid: true}
+			}
+			if i < len(languages) && languages[i] != "" {
+				row.Language = pgtype.Text{String: languages[i], Valid: true}
+			}
+			if i < len(sections) && sections[i] != "" {
+				row.Section = pgtype.Text{String: sections[i], Valid: true}
+			}
+			if (arg.Column1 == "" || arg.Column1 == name) &&
+				(arg.Column2 == "" || arg.Column2 == row.Language.String) &&
+				(arg.Column3 == "" || arg.Column3 == row.Section.String) {
+				rows = append(rows, row)
+			}
+		}
+	}
+	for _, b := range q.phrasesBatches {
+		add(b.Name, b.Column2, nil, b.Column3, b.Column4)
+	}
+	for _, b := range q.sourcedPhrasesBatches {
+		add(b.Name, b.Column2, b.Column3, b.Column4, b.Column5)
+	}
+
+	start := min(int(arg.Offset), len(rows))
+	end := min(start+int(arg.Limit), len(rows))
+
+	return rows[start:end], nil
+}
+
+func (q *QueriesMock) ListTfidfRankings(ctx context.Context, arg database.ListTfidfRankingsParams) ([]database.ListTfidfRankingsRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.tfidfParams = append(q.tfidfParams, arg)
+
+	rows := make([]database.ListTfidfRankingsRow, 0, len(q.wordsRankRows))
+	for _, row := range q.wordsRankRows {
+		if len(arg.Column4) > 0 && !slices.Contains(arg.Column4, row.Value) {
+			continue
+		}
+		rows = append(rows, database.ListTfidfRankingsRow{
+			Value:   row.Value,
+			Score:   1 / float64(len(rows)+1),
+			Ranking: int64(len(rows)) + 1,
+		})
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) ListVersionDistribution(ctx context.Context, arg database.ListVersionDistributionParams) ([]database.ListVersionDistributionRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	rows := make([]database.ListVersionDistributionRow, 0)
+	for _, b := range q.versions {
+		if arg.Column1 != "" && q.batchNames[b.Column1-1] != arg.Column1 {
+			continue
+		}
+		for i, term := range b.Column2 {
+			if len(arg.Column2) > 0 && !slices.Contains(arg.Column2, term) {
+				continue
+			}
+			j := slices.IndexFunc(rows, func(row database.ListVersionDistributionRow) bool {
+				return row.Term == term && row.Version == b.Column3[i]
+			})
+			if j < 0 {
+				rows = append(rows, database.ListVersionDistributionRow{Term: term, Version: b.Column3[i]})
+				j = len(rows) - 1
+			}
+			rows[j].Total++
+		}
+	}
+	slices.SortFunc(rows, func(a, b database.ListVersionDistributionRow) int {
+		return cmp.Or(
+			strings.Compare(a.Term, b.Term),
+			cmp.Compare(b.Total, a.Total),
+			compareVersions(b.Version, a.Version),
+		)
+	})
+
+	return rows, nil
+}
+
+// compareVersions compares versions of numbers separated by dots.
+func compareVersions(a, b string) int {
+	toInts := func(v string) []int {
+		ints := make([]int, 0)
+		for _, part := range strings.Split(v, ".") {
+			n, _ := strconv.Atoi(part)
+			ints = append(ints, n)
+		}
+
+		return ints
+	}
+
+	return slices.Compare(toInts(a), toInts(b))
+}
+
+func (q *QueriesMock) ListWeightedRankings(ctx context.Context, arg database.ListWeightedRankingsParams) ([]database.ListWeightedRankingsRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	weights := make(map[string]float64, len(arg.Column2))
+	for i, section := range arg.Column2 {
+		weights[section] = arg.Column3[i]
+	}
+	// matches reports whether a batch of id has all wanted attributes and
+	// mentions every wanted term.
+	matches := func(id int64) bool {
+		for i := range arg.Column7 {
+			if !q.hasAttribute(id, arg.Column7[i], arg.Column8[i]) {
+				return false
+			}
+		}
+		mentioned := make(map[int32]bool)
+		for _, b := range q.phraseTerms {
+			if b.Column1 != id {
+				continue
+			}
+			for _, term := range b.Column2 {
+				if i := slices.Index(arg.Column9, term); i >= 0 {
+					mentioned[arg.Column10[i]] = true
+				}
+			}
+		}
+		for _, idx := range arg.Column10 {
+			if !mentioned[idx] {
+				return false
+			}
+		}
+
+		return true
+	}
+	scores := make(map[string]*database.ListWeightedRankingsRow)
+	for _, b := range q.phraseTerms {
+		if arg.Column1 != "" && arg.Column1 != q.batchNames[b.Column1-1] {
+			continue
+		}
+		if !matches(b.Column1) {
+			continue
+		}
+		for i, term := range b.Column2 {
+			if len(arg.Column4) > 0 && !slices.Contains(arg.Column4, term) {
+				continue
+			}
+			row, ok := scores[term]
+			if !ok {
+				row = &database.ListWeightedRankingsRow{Value: term}
+				scores[term] = row
+			}
+			row.Score += weights[b.Column3[i]]
+			row.Total++
+		}
+	}
+
+	rows := make([]database.ListWeightedRankingsRow, 0, len(scores))
+	for _, row := range scores {
+		rows = append(rows, *row)
+	}
+	slices.SortFunc(rows, func(a, b database.ListWeightedRankingsRow) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Value, b.Value)
+	})
+	for i := range rows {
+		rows[i].Ranking = int64(i) + 1
+	}
+
+	start := min(int(arg.Offset), len(rows))
+	end := min(start+int(arg.Limit), len(rows))
+
+	return rows[start:end], nil
+}
+
 func (q *QueriesMock) ListWordBatches(ctx context.Context, arg database.ListWordBatchesParams) ([]database.ListWordBatchesRow, error) {
 	return []database.ListWordBatchesRow{}, nil
 }
@@ -227,7 +759,96 @@ func (q *QueriesMock) ListWordFrequencies(ctx context.Context, arg database.List
 }
 
 func (q *QueriesMock) ListWordRankings(ctx context.Context, arg database.ListWordRankingsParams) ([]database.ListWordRankingsRow, error) {
-	return q.wordsRankRows, nil
+	if len(arg.Column3) == 0 {
+		return q.wordsRankRows, nil
+	}
+
+	rows := make([]database.ListWordRankingsRow, 0)
+	for _, row := range q.wordsRankRows {
+		if slices.Contains(arg.Column3, row.Value) {
+			row.Ranking = int64(len(rows)) + 1
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) ListSalaryStats(ctx context.Context, arg database.ListSalaryStatsParams) ([]database.ListSalaryStatsRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.salaryStatsParams = append(q.salaryStatsParams, arg)
+
+	rows := make([]database.ListSalaryStatsRow, 0)
+	for _, b := range q.salaries {
+		for i, currency := range b.Column4 {
+			if (arg.Column2 != "" && arg.Column2 != currency) || (arg.Column3 != "" && arg.Column3 != b.Column6[i]) {
+				continue
+			}
+			j := slices.IndexFunc(rows, func(row database.ListSalaryStatsRow) bool {
+				return row.Currency == currency && row.Contract == b.Column6[i]
+			})
+			if j < 0 {
+				rows = append(rows, database.ListSalaryStatsRow{
+					Currency:  currency,
+					Contract:  b.Column6[i],
+					MinAmount: b.Column2[i],
+				})
+				j = len(rows) - 1
+			}
+			rows[j].Total++
+			rows[j].MinAmount = min(rows[j].MinAmount, b.Column2[i])
+			rows[j].MaxAmount = max(rows[j].MaxAmount, b.Column3[i])
+		}
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) ListSkillTerms(ctx context.Context) ([]database.ListSkillTermsRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.skillTermsLists++
+	rows := make([]database.ListSkillTermsRow, 0, len(q.skillTerms))
+	for _, term := range slices.Sorted(maps.Keys(q.skillTerms)) {
+		rows = append(rows, database.ListSkillTermsRow{
+			ID:       int64(len(rows)) + 1,
+			Term:     term,
+			Category: q.skillTerms[term],
+		})
+	}
+
+	return rows, nil
+}
+
+func (q *QueriesMock) UpsertSkillTerm(ctx context.Context, arg database.UpsertSkillTermParams) (database.UpsertSkillTermRow, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.skillTerms == nil {
+		q.skillTerms = make(map[string]pgtype.Text)
+	}
+	q.skillTerms[arg.Term] = arg.Category
+
+	return database.UpsertSkillTermRow{
+		ID:       int64(len(q.skillTerms)),
+		Term:     arg.Term,
+		Category: arg.Category,
+	}, nil
+}
+
+func (q *QueriesMock) DeleteSkillTerm(ctx context.Context, term string) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.skillTerms[term]; !ok {
+		return 0, nil
+	}
+	delete(q.skillTerms, term)
+
+	return 1, nil
 }
 
 func (q *QueriesMock) ListWordsByBatchName(ctx context.Context, name string) ([]database.ListWordsByBatchNameRow, error) {
@@ -0,0 +1,191 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/textproc"
+)
+
+var ErrInvalidNgramLength = fmt.Errorf("n must be 0 or between 2 and %d", textproc.MaxNGram)
+
+// ngramLength returns length of n-grams passed in query values,
+// 0 meaning any length.
+func ngramLength(values url.Values) (int, error) {
+	if !values.Has("n") {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(values.Get("n"))
+	if err != nil {
+		return 0, fmt.Errorf("atoi: %w", err)
+	}
+	if n != 0 && (n < 2 || n > textproc.MaxNGram) {
+		return 0, ErrInvalidNgramLength
+	}
+
+	return n, nil
+}
+
+// uploadNgramsHandler extracts collocations from lines of an uploaded text
+// file and stores them as a batch. Query values choose length of n-grams,
+// measure scoring them and their minimum count.
+func uploadNgramsHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	const (
+		maxSize         int64 = 1024 * 1024 * 20 // 20 MB
+		defaultMinCount       = 2
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+
+		n, err := ngramLength(values)
+		if err != nil {
+			respondJSON(w, "Invalid n-gram length", err, http.StatusBadRequest)
+
+			return
+		}
+		measure := textproc.PMI
+		if values.Has("measure") {
+			if measure, err = textproc.ParseMeasure(values.Get("measure")); err != nil {
+				respondJSON(w, "Invalid collocation measure", err, http.StatusBadRequest)
+
+				return
+			}
+		}
+		minCount := defaultMinCount
+		if values.Has("min_count") {
+			if minCount, err = strconv.Atoi(values.Get("min_count")); err != nil {
+				respondJSON(w, "Invalid minimum count", err, http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+
+		if err := r.ParseMultipartForm(maxSize); err != nil {
+			respondJSON(w, "File too big", err, http.StatusBadRequest)
+
+			return
+		}
+
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			respondJSON(w, "Failed to get file", err, http.StatusBadRequest)
+
+			return
+		}
+		defer f.Close()
+
+		content, err := io.ReadAll(f)
+		if err != nil {
+			respondJSON(w, "Failed to read file", err, http.StatusInternalServerError)
+
+			return
+		}
+		if contentType := http.DetectContentType(content); !strings.HasPrefix(contentType, "text/plain") {
+			respondJSON(w,
+				fmt.Sprintf("Content type %s not allowed. Upload text file", contentType),
+				nil,
+				http.StatusBadRequest,
+			)
+
+			return
+		}
+
+		l.Info("Received form",
+			slog.String("header_filename", fh.Filename),
+			slog.String("measure", string(measure)),
+		)
+
+		ngrams := textproc.ExtractNGrams(textproc.ScanLines(string(content)), n, measure, minCount)
+
+		name := values.Get("name")
+		if name == "" {
+			name = fh.Filename
+		}
+
+		row, err := svc.CreateNgramsBatch(r.Context(), name, measure, ngrams)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, ErrNoNgrams) {
+				code = http.StatusUnprocessableEntity
+			}
+			respondJSON(w, "Failed to create n-grams batch", err, code)
+
+			return
+		}
+
+		response := struct {
+			Name    string                        `json:"batch_name"`
+			Message string                        `json:"message"`
+			Measure textproc.Measure              `json:"measure"`
+			Ngrams  []textproc.NGram              `json:"ngrams"`
+			Row     database.CreateNgramsBatchRow `json:"row"`
+		}{
+			Name:    name,
+			Message: "Created n-grams batch",
+			Measure: measure,
+			Ngrams:  ngrams,
+			Row:     row,
+		}
+		if err := encode(w, r, http.StatusOK, response); err != nil {
+			respondJSON(w, "Failed to encode response", err, http.StatusInternalServerError)
+
+			return
+		}
+	}
+}
+
+// listNgramRankingsHandler ranks stored n-grams of length passed in query
+// values, or of any length, by their total count. N-grams are optionally
+// only those of categories and grouped by category.
+func listNgramRankingsHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+
+		n, err := ngramLength(values)
+		if err != nil {
+			respondJSON(w, "Invalid n-gram length", err, http.StatusBadRequest)
+
+			return
+		}
+		limit, err := limitValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid limit", err, http.StatusBadRequest)
+
+			return
+		}
+		offset, err := offsetValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid offset", err, http.StatusBadRequest)
+
+			return
+		}
+
+		cq, err := parseCategoryQuery(r.Context(), svc, values)
+		if err != nil {
+			respondJSON(w, "Invalid category", err, categoryErrorCode(err))
+
+			return
+		}
+
+		queryLimit, queryOffset := cq.page(limit, offset)
+		rows, err := svc.ListNgramRankings(r.Context(), int32(n), cq.terms, queryLimit, queryOffset)
+		if err != nil {
+			respondJSON(w, "Failed to list n-gram rankings", err, http.StatusInternalServerError)
+
+			return
+		}
+		respondRankings(w, r, cq, rows, func(row database.ListNgramRankingsRow) string { return row.Value }, limit)
+	}
+}
@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kndrad/piccrack/internal/database"
+	"github.com/stretchr/testify/require"
+)
+
+const testOffer = `Experience with machine learning and distributed systems.
+Knowledge of distributed systems, machine learning
+You will build machine learning pipelines on event driven architecture
+Event driven architecture in Golang services
+`
+
+func textUploadRequest(t *testing.T, target, content string) *http.Request {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+
+	f, err := w.CreateFormFile("file", "offer.txt")
+	require.NoError(t, err)
+	_, err = f.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, target, buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return req
+}
+
+func TestUploadNgramsHandler(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		target      string
+		content     string
+		wantCode    int
+		wantMeasure string
+		wantValues  []string
+	}{
+		{
+			desc: "bigrams_and_trigrams_by_pmi",
+
+			target:      "/?name=offer",
+			content:     testOffer,
+			wantCode:    http.StatusOK,
+			wantMeasure: "pmi",
+			wantValues:  []string{"event driven", "driven architecture", "event driven architecture", "distributed systems", "machine learning"},
+		},
+		{
+			desc: "bigrams_by_log_likelihood",
+
+			target:      "/?n=2&measure=llr&min_count=3",
+			content:     testOffer,
+			wantCode:    http.StatusOK,
+			wantMeasure: "llr",
+			wantValues:  []string{"machine learning"},
+		},
+		{
+			desc: "invalid_length",
+
+			target:   "/?n=4",
+			content:  testOffer,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc: "unknown_measure",
+
+			target:   "/?measure=dice",
+			content:  testOffer,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc: "no_collocations",
+
+			target:   "/",
+			content:  "golang developer\n",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			q := NewQueriesMock()
+			svc := NewService(q, testLogger())
+
+			rr := httptest.NewRecorder()
+			uploadNgramsHandler(svc, testLogger())(rr, textUploadRequest(t, tC.target, tC.content))
+
+			require.Equal(t, tC.wantCode, rr.Code, rr.Body.String())
+			if tC.wantCode != http.StatusOK {
+				require.Empty(t, q.ngramsBatches)
+
+				return
+			}
+			require.Len(t, q.ngramsBatches, 1)
+
+			batch := q.ngramsBatches[0]
+			require.Equal(t, tC.wantMeasure, batch.Measure)
+			require.ElementsMatch(t, tC.wantValues, batch.Column3)
+			for i, value := range batch.Column3 {
+				require.Equal(t, int32(len(strings.Fields(value))), batch.Column4[i])
+			}
+		})
+	}
+}
+
+func TestListNgramRankingsHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	rr := httptest.NewRecorder()
+	uploadNgramsHandler(svc, testLogger())(rr, textUploadRequest(t, "/?min_count=1", testOffer))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	testCases := []struct {
+		desc string
+
+		target   string
+		wantCode int
+		wantTop  string
+		wantN    int32
+	}{
+		{
+			desc: "any_length",
+
+			target:   "/",
+			wantCode: http.StatusOK,
+			wantTop:  "machine learning",
+			wantN:    2,
+		},
+		{
+			desc: "trigrams",
+
+			target:   "/?n=3",
+			wantCode: http.StatusOK,
+			wantTop:  "event driven architecture",
+			wantN:    3,
+		},
+		{
+			desc: "limited",
+
+			target:   "/?n=2&limit=1&offset=0",
+			wantCode: http.StatusOK,
+			wantTop:  "machine learning",
+			wantN:    2,
+		},
+		{
+			desc: "category",
+
+			target:   "/?category=practices",
+			wantCode: http.StatusOK,
+			wantTop:  "distributed systems",
+			wantN:    2,
+		},
+		{
+			desc: "invalid_length",
+
+			target:   "/?n=1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc: "unknown_category",
+
+			target:   "/?category=hobbies",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tC.target, nil)
+			listNgramRankingsHandler(svc, testLogger())(rr, req)
+
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				return
+			}
+
+			var rows []database.ListNgramRankingsRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+			require.NotEmpty(t, rows)
+			require.Equal(t, tC.wantTop, rows[0].Value)
+			require.Equal(t, tC.wantN, rows[0].N)
+			require.Equal(t, int64(1), rows[0].Ranking)
+			for _, row := range rows {
+				require.NotContains(t, row.Value, "golang")
+			}
+		})
+	}
+}
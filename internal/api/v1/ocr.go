@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"bytes"
+	"cmp"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kndrad/piccrack/pkg/imgsniff"
+	"github.com/kndrad/piccrack/pkg/ocr"
+)
+
+// formatMediaTypes maps media types accepted by clients to result formats,
+// every format has its own media type and some generic aliases.
+var formatMediaTypes = map[string]ocr.Format{
+	"application/json":      ocr.FormatJSON,
+	"text/plain":            ocr.FormatText,
+	"text/vnd.hocr+html":    ocr.FormatHOCR,
+	"text/html":             ocr.FormatHOCR,
+	"application/xhtml+xml": ocr.FormatHOCR,
+	"application/alto+xml":  ocr.FormatALTO,
+	"application/xml":       ocr.FormatALTO,
+	"text/xml":              ocr.FormatALTO,
+}
+
+// acceptedFormat returns the result format most preferred by Accept header,
+// JSON if the header is empty or accepts anything. False if none of the
+// formats is acceptable.
+func acceptedFormat(accept string) (ocr.Format, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return ocr.FormatJSON, true
+	}
+
+	type mediaRange struct {
+		typ string
+		q   float64
+	}
+	ranges := make([]mediaRange, 0)
+	for _, s := range strings.Split(accept, ",") {
+		typ, params, err := mime.ParseMediaType(s)
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, mediaRange{typ, q})
+	}
+	// Keep order of the header among ranges of the same quality
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	for _, r := range ranges {
+		if r.typ == "*/*" || r.typ == "application/*" {
+			return ocr.FormatJSON, true
+		}
+		if r.typ == "text/*" {
+			return ocr.FormatText, true
+		}
+		if f, ok := formatMediaTypes[r.typ]; ok {
+			return f, true
+		}
+	}
+
+	return "", false
+}
+
+// recognizeImageHandler returns text recognized in an uploaded image with its
+// layout, in a format negotiated by Accept header. Nothing is stored.
+func recognizeImageHandler(e ocr.Engine, opts ocr.Options, l *slog.Logger) http.HandlerFunc {
+	const maxSize int64 = 1024 * 1024 * 50 // 50 MB
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		format, ok := acceptedFormat(r.Header.Get("Accept"))
+		if !ok {
+			respondJSON(w, "None of accepted media types is supported", nil, http.StatusNotAcceptable)
+
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+
+		if err := r.ParseMultipartForm(maxSize); err != nil {
+			respondJSON(w, "File too big", err, http.StatusBadRequest)
+
+			return
+		}
+
+		f, fh, err := r.FormFile("image")
+		if err != nil {
+			respondJSON(w, "Failed to get image file", err, http.StatusBadRequest)
+
+			return
+		}
+		defer f.Close()
+
+		imgFormat, err := sniffImage(f)
+		if err != nil {
+			respondJSON(w, "Failed to read image file", err, http.StatusInternalServerError)
+
+			return
+		}
+		if imgFormat == imgsniff.Unknown {
+			respondJSON(w, "Unsupported image format", nil, http.StatusBadRequest)
+
+			return
+		}
+
+		l.Info("Received form",
+			slog.String("header_filename", fh.Filename),
+			slog.String("format", string(imgFormat)),
+			slog.String("result_format", string(format)),
+		)
+
+		opts, err := ocrOptions(r.URL.Query(), opts)
+		if err != nil {
+			respondJSON(w, "Invalid recognition options", err, http.StatusBadRequest)
+
+			return
+		}
+
+		res, err := ocr.ScanFrom(r.Context(), e, f, opts)
+		if err != nil {
+			respondJSON(w, "Failed to recognize image", err, scanErrorCode(err))
+
+			return
+		}
+
+		// Encode first so a failure can still be reported with a status code
+		buf := new(bytes.Buffer)
+		if err := res.Encode(buf, format); err != nil {
+			respondJSON(w, "Failed to encode result", err, http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", format.MediaType()+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := buf.WriteTo(w); err != nil {
+			l.Error("Failed to write result", "err", err)
+		}
+	}
+}
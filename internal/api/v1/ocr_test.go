@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptedFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		accept string
+		want   ocr.Format
+		wantOK bool
+	}{
+		{
+			desc: "empty_is_json",
+
+			accept: "",
+			want:   ocr.FormatJSON,
+			wantOK: true,
+		},
+		{
+			desc: "anything_is_json",
+
+			accept: "*/*",
+			want:   ocr.FormatJSON,
+			wantOK: true,
+		},
+		{
+			desc: "hocr",
+
+			accept: "text/vnd.hocr+html",
+			want:   ocr.FormatHOCR,
+			wantOK: true,
+		},
+		{
+			desc: "alto_preferred_by_quality",
+
+			accept: "text/plain;q=0.5, application/alto+xml",
+			want:   ocr.FormatALTO,
+			wantOK: true,
+		},
+		{
+			desc: "first_of_equal_quality",
+
+			accept: "text/html, application/json",
+			want:   ocr.FormatHOCR,
+			wantOK: true,
+		},
+		{
+			desc: "unsupported_types_are_skipped",
+
+			accept: "image/png, text/plain;q=0.1",
+			want:   ocr.FormatText,
+			wantOK: true,
+		},
+		{
+			desc: "zero_quality_is_not_acceptable",
+
+			accept: "text/plain;q=0",
+		},
+		{
+			desc: "nothing_supported",
+
+			accept: "image/png",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			f, ok := acceptedFormat(tC.accept)
+			require.Equal(t, tC.wantOK, ok)
+			require.Equal(t, tC.want, f)
+		})
+	}
+}
+
+func imageUploadRequest(t *testing.T, path string) *http.Request {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+
+	f, err := w.CreateFormFile("image", filepath.Base(path))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = f.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return req
+}
+
+func TestRecognizeImageHandler(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		path            string
+		accept          string
+		wantCode        int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			desc: "json_by_default",
+
+			path:            filepath.Join("testdata", "0.png"),
+			wantCode:        http.StatusOK,
+			wantContentType: "application/json; charset=utf-8",
+			wantBody:        `"text":"golang developer"`,
+		},
+		{
+			desc: "text",
+
+			path:            filepath.Join("testdata", "0.png"),
+			accept:          "text/plain",
+			wantCode:        http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "golang developer\n",
+		},
+		{
+			desc: "hocr",
+
+			path:            filepath.Join("testdata", "0.png"),
+			accept:          "text/vnd.hocr+html",
+			wantCode:        http.StatusOK,
+			wantContentType: "text/vnd.hocr+html; charset=utf-8",
+			wantBody:        `<span class="ocrx_word" id="word_1_1_2" title="bbox 10 0 20 10; x_wconf 100">developer</span>`,
+		},
+		{
+			desc: "alto",
+
+			path:            filepath.Join("testdata", "0.png"),
+			accept:          "application/alto+xml",
+			wantCode:        http.StatusOK,
+			wantContentType: "application/alto+xml; charset=utf-8",
+			wantBody:        `CONTENT="developer"`,
+		},
+		{
+			desc: "not_acceptable",
+
+			path:     filepath.Join("testdata", "0.png"),
+			accept:   "image/png",
+			wantCode: http.StatusNotAcceptable,
+		},
+		{
+			desc: "not_an_image",
+
+			path:     filepath.Join("testdata", "job_offer.pdf"),
+			accept:   "text/plain",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := imageUploadRequest(t, tC.path)
+			if tC.accept != "" {
+				req.Header.Set("Accept", tC.accept)
+			}
+
+			rr := httptest.NewRecorder()
+			e := ocrtest.NewEngine("golang developer")
+			recognizeImageHandler(e, ocr.DefaultOptions(), testLogger())(rr, req)
+
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				require.True(t, json.Valid(rr.Body.Bytes()))
+				require.Zero(t, e.Scans())
+
+				return
+			}
+			require.Equal(t, tC.wantContentType, rr.Header().Get("Content-Type"))
+			require.Contains(t, rr.Body.String(), tC.wantBody)
+		})
+	}
+}
@@ -1,15 +1,19 @@
 package v1
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 
 	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/imgsniff"
 	"github.com/kndrad/piccrack/pkg/ocr"
 	"github.com/kndrad/piccrack/pkg/picphrase"
+	"github.com/kndrad/piccrack/pkg/textproc"
 )
 
-func uploadImagePhrasesHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+func uploadImagePhrasesHandler(svc Service, e ocr.Engine, opts ocr.Options, l *slog.Logger) http.HandlerFunc {
 	const maxSize int64 = 1024 * 1024 * 50 // 50 MB
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -29,28 +33,73 @@ func uploadImagePhrasesHandler(svc Service, l *slog.Logger) http.HandlerFunc {
 		}
 		defer f.Close()
 
-		l.Info("Received form", slog.String("header_filename", fh.Filename))
+		format, err := sniffImage(f)
+		if err != nil {
+			respondJSON(w, "Failed to read image file", err, http.StatusInternalServerError)
+
+			return
+		}
+		if format == imgsniff.Unknown {
+			respondJSON(w, "Unsupported image format", nil, http.StatusBadRequest)
+
+			return
+		}
+
+		l.Info("Received form",
+			slog.String("header_filename", fh.Filename),
+			slog.String("format", string(format)),
+		)
 
-		img, err := fh.Open()
+		opts, err := ocrOptions(r.URL.Query(), opts)
 		if err != nil {
-			respondJSON(w, "Failed to ocr", err, http.StatusInternalServerError)
+			respondJSON(w, "Invalid recognition options", err, http.StatusBadRequest)
 
 			return
 		}
 
-		tc := ocr.NewClient()
-		defer tc.Close()
+		phrases, err := picphrase.ScanReader(r.Context(), e, f, opts)
+		if err != nil {
+			respondJSON(w, "Failed to ocr", err, scanErrorCode(err))
+
+			return
+		}
 
-		phrases, err := picphrase.ScanReader(r.Context(), img)
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			name = fh.Filename
+		}
+
+		respondPhrasesUpload(w, r, svc, newPhrasesUpload(name, phrases, false))
+	}
+}
+
+func uploadPDFPhrasesHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	const maxSize int64 = 1024 * 1024 * 50 // 50 MB
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+
+		if err := r.ParseMultipartForm(maxSize); err != nil {
+			respondJSON(w, "File too big", err, http.StatusBadRequest)
+
+			return
+		}
+
+		f, fh, err := r.FormFile("pdf")
 		if err != nil {
-			respondJSON(w, "Failed to ocr", err, http.StatusInternalServerError)
+			respondJSON(w, "Failed to get pdf file", err, http.StatusBadRequest)
 
 			return
 		}
+		defer f.Close()
 
-		values := make([]string, 0)
-		for phrase := range phrases {
-			values = append(values, phrase.String())
+		l.Info("Received form", slog.String("header_filename", fh.Filename))
+
+		phrases, err := picphrase.ScanPDF(r.Context(), f, fh.Filename)
+		if err != nil {
+			respondJSON(w, "Failed to read pdf text", err, pdfErrorCode(err))
+
+			return
 		}
 
 		name := r.URL.Query().Get("name")
@@ -58,26 +107,235 @@ func uploadImagePhrasesHandler(svc Service, l *slog.Logger) http.HandlerFunc {
 			name = fh.Filename
 		}
 
-		row, err := svc.CreatePhrasesBatch(r.Context(), name, values)
+		respondPhrasesUpload(w, r, svc, newPhrasesUpload(name, phrases, true))
+	}
+}
+
+// newPhrasesUpload returns an upload of phrases of a batch named name,
+// along with their sources if sourced.
+func newPhrasesUpload(name string, phrases <-chan *picphrase.Phrase, sourced bool) PhrasesUpload {
+	u := PhrasesUpload{
+		Name:      name,
+		Values:    make([]string, 0),
+		Languages: make([]string, 0),
+		Sections:  make([]string, 0),
+	}
+	if sourced {
+		u.Sources = make([]string, 0)
+	}
+	for phrase := range phrases {
+		u.Values = append(u.Values, phrase.String())
+		u.Languages = append(u.Languages, phrase.Language())
+		u.Sections = append(u.Sections, string(phrase.Section()))
+		if sourced {
+			u.Sources = append(u.Sources, phrase.Source())
+		}
+	}
+
+	return u
+}
+
+// respondPhrasesUpload stores an upload of phrases and responds with rows
+// stored.
+func respondPhrasesUpload(w http.ResponseWriter, r *http.Request, svc Service, u PhrasesUpload) {
+	res, err := svc.CreatePhrasesUpload(r.Context(), u)
+	if err != nil {
+		respondJSON(w, "Failed to create phrases batch", err, http.StatusInternalServerError)
+
+		return
+	}
+
+	response := struct {
+		Name    string `json:"batch_name"`
+		Message string `json:"message"`
+		PhrasesUploadResult
+	}{
+		Name:                u.Name,
+		Message:             "Created phrases batch",
+		PhrasesUploadResult: res,
+	}
+	if err := encode(w, r, http.StatusOK, response); err != nil {
+		respondJSON(w, "Failed to encode response", err, http.StatusInternalServerError)
+
+		return
+	}
+}
+
+// listPhrasesHandler lists stored phrases, optionally only those of a batch,
+// a language and a section of offers, e.g.
+// ?batch=offer.pdf&language=pl&section=requirements.
+func listPhrasesHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+
+		limit, err := limitValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid limit", err, http.StatusBadRequest)
+
+			return
+		}
+		offset, err := offsetValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid offset", err, http.StatusBadRequest)
+
+			return
+		}
+
+		var section textproc.Section
+		if s := values.Get("section"); s != "" {
+			section, err = textproc.ParseSection(s)
+			if err != nil {
+				respondJSON(w, "Invalid section", err, http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		rows, err := svc.ListPhrases(r.Context(),
+			values.Get("batch"),
+			values.Get("language"),
+			string(section),
+			limit,
+			offset,
+		)
 		if err != nil {
-			respondJSON(w, "Failed to create phrases batch", err, http.StatusInternalServerError)
+			respondJSON(w, "Failed to list phrases", err, http.StatusInternalServerError)
 
 			return
 		}
+		if err := encode(w, r, http.StatusOK, rows); err != nil {
+			respondJSON(w, "Failed to encode rows", err, http.StatusInternalServerError)
+		}
+	}
+}
 
-		response := struct {
-			Name    string                         `json:"batch_name"`
-			Message string                         `json:"message"`
-			Row     database.CreatePhrasesBatchRow `json:"row"`
-		}{
-			Name:    name,
-			Message: "Created phrases batch",
-			Row:     row,
+// listSalaryStatsHandler aggregates salaries offered in phrase batches,
+// normalized to a month, per currency and contract. Salaries are optionally
+// only those of a batch, a currency and a contract, e.g.
+// ?batch=offer.pdf&currency=PLN&contract=b2b.
+func listSalaryStatsHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+		rows, err := svc.ListSalaryStats(r.Context(),
+			values.Get("batch"),
+			values.Get("currency"),
+			values.Get("contract"),
+		)
+		if err != nil {
+			respondJSON(w, "Failed to list salary stats", err, http.StatusInternalServerError)
+
+			return
+		}
+		if err := encode(w, r, http.StatusOK, rows); err != nil {
+			respondJSON(w, "Failed to encode rows", err, http.StatusInternalServerError)
 		}
-		if err := encode(w, r, http.StatusOK, response); err != nil {
-			respondJSON(w, "Failed to encode response", err, http.StatusInternalServerError)
+	}
+}
+
+// listExperienceDistributionHandler counts technologies requiring every
+// minimum number of years of experience in phrase batches, optionally only
+// those of a batch and categories, and grouped by category, e.g.
+// ?batch=offer.pdf&category=languages.
+func listExperienceDistributionHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+		cq, err := parseCategoryQuery(r.Context(), svc, values)
+		if err != nil {
+			respondJSON(w, "Invalid category", err, categoryErrorCode(err))
+
+			return
+		}
+
+		rows, err := svc.ListExperienceDistribution(r.Context(), values.Get("batch"), cq.terms)
+		if err != nil {
+			respondJSON(w, "Failed to list experience distribution", err, http.StatusInternalServerError)
 
 			return
 		}
+		respondRankings(w, r, cq, rows, func(row database.ListExperienceDistributionRow) string { return row.Term }, 0)
+	}
+}
+
+// listVersionDistributionHandler counts versions of every technology asked
+// for in phrase batches, optionally only those of a batch, of terms or of
+// categories, and grouped by category, e.g. ?batch=offer.pdf&term=java,go
+// or ?category=languages.
+func listVersionDistributionHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+		cq, err := parseCategoryQuery(r.Context(), svc, values)
+		if err != nil {
+			respondJSON(w, "Invalid category", err, categoryErrorCode(err))
+
+			return
+		}
+		terms := cq.terms
+		if values.Has("term") {
+			terms = splitValue(values.Get("term"))
+		}
+
+		rows, err := svc.ListVersionDistribution(r.Context(), values.Get("batch"), terms)
+		if err != nil {
+			respondJSON(w, "Failed to list version distribution", err, http.StatusInternalServerError)
+
+			return
+		}
+		respondRankings(w, r, cq, rows, func(row database.ListVersionDistributionRow) string { return row.Term }, 0)
+	}
+}
+
+// listLanguageRequirementSummaryHandler counts phrase batches requiring
+// every spoken language at every level, optionally only those of a batch
+// and a language, e.g. ?batch=offer.pdf&language=en.
+func listLanguageRequirementSummaryHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+		rows, err := svc.ListLanguageRequirementSummary(r.Context(), values.Get("batch"), values.Get("language"))
+		if err != nil {
+			respondJSON(w, "Failed to list language requirement summary", err, http.StatusInternalServerError)
+
+			return
+		}
+		if err := encode(w, r, http.StatusOK, rows); err != nil {
+			respondJSON(w, "Failed to encode rows", err, http.StatusInternalServerError)
+		}
+	}
+}
+
+// listBatchAttributesHandler counts phrase batches having every value of
+// attributes of job offers, optionally only those of a batch and of
+// attributes of a name, e.g. ?batch=offer.pdf&name=work_mode.
+func listBatchAttributesHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+		name := values.Get("name")
+		if name != "" && !slices.Contains(textproc.AttributeNames, name) {
+			err := fmt.Errorf("%w: unknown name %q", textproc.ErrInvalidAttribute, name)
+			respondJSON(w, "Invalid attribute name", err, http.StatusBadRequest)
+
+			return
+		}
+
+		rows, err := svc.ListBatchAttributes(r.Context(), values.Get("batch"), name)
+		if err != nil {
+			respondJSON(w, "Failed to list batch attributes", err, http.StatusInternalServerError)
+
+			return
+		}
+		if err := encode(w, r, http.StatusOK, rows); err != nil {
+			respondJSON(w, "Failed to encode rows", err, http.StatusInternalServerError)
+		}
 	}
 }
@@ -3,6 +3,7 @@ package v1
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -11,9 +12,16 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/kndrad/piccrack/pkg/textproc"
 	"github.com/stretchr/testify/require"
 )
 
+const testText = `Your main tasks will be:
+Designing and developing scalable backend solutions using Go and Python.`
+
 func TestUploadImagePhrasesHandler(t *testing.T) {
 	t.Parallel()
 
@@ -22,14 +30,29 @@ func TestUploadImagePhrasesHandler(t *testing.T) {
 	testCases := []struct {
 		desc string
 
-		path string
-		svc  Service
+		path         string
+		text         string
+		wantSalaries []textproc.Salary
 	}{
 		{
 			desc: "uploads_phrases_from_an_image",
 			path: filepath.Join("testdata", "0.png"),
 
-			svc: NewService(NewQueriesMock(NewWordsMock()...), l),
+			text: testText,
+		},
+		{
+			desc: "stores_salaries_of_an_offer",
+			path: filepath.Join("testdata", "0.png"),
+
+			text: "Job Type: Full-time\nPay: 185,000.00zł - 285,000.00zł per year",
+			wantSalaries: []textproc.Salary{
+				{
+					Min:      185000,
+					Max:      285000,
+					Currency: textproc.CurrencyPLN,
+					Period:   textproc.PeriodYear,
+				},
+			},
 		},
 	}
 	for _, tC := range testCases {
@@ -62,7 +85,8 @@ func TestUploadImagePhrasesHandler(t *testing.T) {
 			)
 			req.Header.Set("Content-Type", w.FormDataContentType())
 
-			handler := uploadImagePhrasesHandler(tC.svc, l)
+			q := NewQueriesMock(NewWordsMock()...)
+			handler := uploadImagePhrasesHandler(NewService(q, l), ocrtest.NewEngine(tC.text), ocr.DefaultOptions(), l)
 
 			rr := httptest.NewRecorder()
 			handler(rr, req)
@@ -72,6 +96,499 @@ func TestUploadImagePhrasesHandler(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotEmpty(t, data)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			var body struct {
+				Name     string                       `json:"batch_name"`
+				Salaries []database.CreateSalariesRow `json:"salaries"`
+			}
+			require.NoError(t, json.Unmarshal(data, &body))
+			require.Equal(t, "testbatch", body.Name)
+
+			if tC.wantSalaries == nil {
+				require.Empty(t, q.salaries)
+				require.Empty(t, body.Salaries)
+
+				return
+			}
+			require.Len(t, q.salaries, 1)
+			salaries := q.salaries[0]
+			require.Len(t, salaries.Column2, len(tC.wantSalaries))
+			require.Len(t, body.Salaries, len(tC.wantSalaries))
+			for i, want := range tC.wantSalaries {
+				require.InDelta(t, want.Min, salaries.Column2[i], 1e-9)
+				require.InDelta(t, want.Max, salaries.Column3[i], 1e-9)
+				require.Equal(t, string(want.Currency), salaries.Column4[i])
+				require.Equal(t, string(want.Period), salaries.Column5[i])
+
+				require.InDelta(t, want.Min, body.Salaries[i].MinAmount, 1e-9)
+				require.InDelta(t, want.Max, body.Salaries[i].MaxAmount, 1e-9)
+				require.Equal(t, string(want.Currency), body.Salaries[i].Currency)
+			}
+		})
+	}
+}
+
+func pdfUploadRequest(t *testing.T, path string) *http.Request {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+
+	f, err := w.CreateFormFile("pdf", filepath.Base(path))
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = f.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return req
+}
+
+func TestUploadPDFPhrasesHandler(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		path        string
+		wantCode    int
+		wantSources []string
+	}{
+		{
+			desc: "every_page_is_a_source",
+
+			path:     filepath.Join("testdata", "job_offer.pdf"),
+			wantCode: http.StatusOK,
+			wantSources: []string{
+				"job_offer.pdf#page=1",
+				"job_offer.pdf#page=1",
+				"job_offer.pdf#page=2",
+				"job_offer.pdf#page=2",
+			},
+		},
+		{
+			desc: "pdf_without_text_layer",
+
+			path:     filepath.Join("testdata", "blank.pdf"),
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			desc: "not_a_pdf",
+
+			path:     filepath.Join("testdata", "0.png"),
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			q := NewQueriesMock()
+			svc := NewService(q, testLogger())
+
+			rr := httptest.NewRecorder()
+			uploadPDFPhrasesHandler(svc, testLogger())(rr, pdfUploadRequest(t, tC.path))
+
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				require.Empty(t, q.sourcedPhrasesBatches)
+
+				return
+			}
+			require.Len(t, q.sourcedPhrasesBatches, 1)
+
+			batch := q.sourcedPhrasesBatches[0]
+			require.Equal(t, "job_offer.pdf", batch.Name)
+			require.Len(t, batch.Column2, len(tC.wantSources))
+			require.ElementsMatch(t, tC.wantSources, batch.Column3)
+			require.Len(t, batch.Column4, len(tC.wantSources))
+		})
+	}
+}
+
+func TestListPhrasesHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	_, err := svc.CreatePhrasesBatch(context.Background(), "offer.png",
+		[]string{"wymagania", "strong experience with go", "2024"},
+		[]string{"pl", "en", ""},
+		[]string{"requirements", "requirements", ""},
+	)
+	require.NoError(t, err)
+	_, err = svc.CreatePhrasesBatchWithSources(context.Background(), "offer.pdf",
+		[]string{"oferujemy pracę zdalną"},
+		[]string{"offer.pdf#page=1"},
+		[]string{"pl"},
+		[]string{"benefits"},
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+
+		query      string
+		wantCode   int
+		wantValues []string
+	}{
+		{
+			desc: "all_phrases",
+
+			query:      "",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"wymagania", "strong experience with go", "2024", "oferujemy pracę zdalną"},
+		},
+		{
+			desc: "language",
+
+			query:      "?language=PL",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"wymagania", "oferujemy pracę zdalną"},
+		},
+		{
+			desc: "batch_and_language",
+
+			query:      "?batch=offer.png&language=en",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"strong experience with go"},
+		},
+		{
+			desc: "section",
+
+			query:      "?section=requirements",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"wymagania", "strong experience with go"},
+		},
+		{
+			desc: "unknown_section",
+
+			query:    "?section=hobbies",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			listPhrasesHandler(svc, testLogger())(rr, httptest.NewRequest(http.MethodGet, "/"+tC.query, nil))
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				return
+			}
+
+			var rows []database.ListPhrasesRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+
+			values := make([]string, 0, len(rows))
+			for _, row := range rows {
+				values = append(values, row.Value)
+			}
+			require.Equal(t, tC.wantValues, values)
+		})
+	}
+
+	_, err = svc.CreatePhrasesBatch(context.Background(), "offer.png", []string{"go"}, nil, []string{""})
+	require.ErrorIs(t, err, ErrLanguagesMismatch)
+	_, err = svc.CreatePhrasesBatch(context.Background(), "offer.png", []string{"go"}, []string{"en"}, nil)
+	require.ErrorIs(t, err, ErrSectionsMismatch)
+}
+
+func TestListSalaryStatsHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	_, err := svc.CreateSalaries(context.Background(), 1, textproc.Salaries(`15 000 - 20 000 PLN netto/mies. (B2B)
+12 000 zł brutto, umowa o pracę
+€60-80k a year`))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	listSalaryStatsHandler(svc, testLogger())(rr, httptest.NewRequest(http.MethodGet, "/?currency=pln&contract=B2B", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	require.Equal(t, []database.ListSalaryStatsParams{{Column2: "PLN", Column3: "b2b"}}, q.salaryStatsParams)
+
+	var rows []database.ListSalaryStatsRow
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+	require.Len(t, rows, 1)
+	require.Equal(t, int64(1), rows[0].Total)
+	require.InDelta(t, 15000, rows[0].MinAmount, 1e-9)
+	require.InDelta(t, 20000, rows[0].MaxAmount, 1e-9)
+}
+
+func TestListExperienceDistributionHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	_, err := svc.CreateExperiences(context.Background(), 1, []string{
+		"Proficiency in Golang (3+ years) and Python",
+		"2-3 years of Go",
+		"At least 5 years of PostgreSQL",
+		"At least 3 years of commercial development experience.",
+	})
+	require.NoError(t, err)
+	require.Len(t, q.experiences, 1)
+	require.Equal(t, []string{"go", "go", "postgresql", ""}, q.experiences[0].Column2)
+
+	testCases := []struct {
+		desc string
+
+		query    string
+		wantCode int
+		wantRows []database.ListExperienceDistributionRow
+	}{
+		{
+			desc: "every_technology",
+
+			query:    "",
+			wantCode: http.StatusOK,
+			wantRows: []database.ListExperienceDistributionRow{
+				{Term: "go", MinYears: 2, Total: 1},
+				{Term: "go", MinYears: 3, Total: 1},
+				{Term: "postgresql", MinYears: 5, Total: 1},
+			},
+		},
+		{
+			desc: "category",
+
+			query:    "?category=databases",
+			wantCode: http.StatusOK,
+			wantRows: []database.ListExperienceDistributionRow{
+				{Term: "postgresql", MinYears: 5, Total: 1},
+			},
+		},
+		{
+			desc: "unknown_category",
+
+			query:    "?category=hobbies",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			listExperienceDistributionHandler(svc, testLogger())(rr, httptest.NewRequest(http.MethodGet, "/"+tC.query, nil))
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				return
+			}
+
+			var rows []database.ListExperienceDistributionRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+			require.Equal(t, tC.wantRows, rows)
+		})
+	}
+}
+
+func TestListVersionDistributionHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	for name, lines := range map[string][]string{
+		"first.png":  {"Java 17/21 and Golang 1.22", "Python 3.x"},
+		"second.png": {"Java 21, Java 8 or Go 1.9"},
+	} {
+		row, err := svc.CreatePhrasesBatch(context.Background(), name, lines, make([]string, len(lines)), make([]string, len(lines)))
+		require.NoError(t, err)
+		_, err = svc.CreateTechnologyVersions(context.Background(), row.BatchID.Int64, lines)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		desc string
+
+		query    string
+		wantCode int
+		wantRows []database.ListVersionDistributionRow
+	}{
+		{
+			desc: "terms_of_aliases",
+
+			query:    "?term=java,golang",
+			wantCode: http.StatusOK,
+			wantRows: []database.ListVersionDistributionRow{
+				{Term: "go", Version: "1.22", Total: 1},
+				{Term: "go", Version: "1.9", Total: 1},
+				{Term: "java", Version: "21", Total: 2},
+				{Term: "java", Version: "17", Total: 1},
+				{Term: "java", Version: "8", Total: 1},
+			},
+		},
+		{
+			desc: "batch",
+
+			query:    "?batch=second.png&term=go",
+			wantCode: http.StatusOK,
+			wantRows: []database.ListVersionDistributionRow{
+				{Term: "go", Version: "1.9", Total: 1},
+			},
+		},
+		{
+			desc: "unknown_category",
+
+			query:    "?category=hobbies",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			listVersionDistributionHandler(svc, testLogger())(rr, httptest.NewRequest(http.MethodGet, "/"+tC.query, nil))
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				return
+			}
+
+			var rows []database.ListVersionDistributionRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+			require.Equal(t, tC.wantRows, rows)
+		})
+	}
+}
+
+func TestListLanguageRequirementSummaryHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	for name, lines := range map[string][]string{
+		"first.png":  {"English B2, Polish native", "Fluent English is a plus"},
+		"second.png": {"Komunikatywny język angielski"},
+		"third.png":  {"Angielski na poziomie B2+"},
+	} {
+		row, err := svc.CreatePhrasesBatch(context.Background(), name, lines, make([]string, len(lines)), make([]string, len(lines)))
+		require.NoError(t, err)
+		_, err = svc.CreateLanguageRequirements(context.Background(), row.BatchID.Int64, lines)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		desc string
+
+		query    string
+		wantRows []database.ListLanguageRequirementSummaryRow
+	}{
+		{
+			desc: "highest_level_of_every_batch",
+
+			query: "",
+			wantRows: []database.ListLanguageRequirementSummaryRow{
+				{Language: "en", Proficiency: "B1", Total: 1},
+				{Language: "en", Proficiency: "B2", Total: 1},
+				{Language: "en", Proficiency: "C1", Total: 1},
+				{Language: "pl", Proficiency: "native", Total: 1},
+			},
+		},
+		{
+			desc: "batch_and_language",
+
+			query: "?batch=first.png&language=PL",
+			wantRows: []database.ListLanguageRequirementSummaryRow{
+				{Language: "pl", Proficiency: "native", Total: 1},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			listLanguageRequirementSummaryHandler(svc, testLogger())(rr, httptest.NewRequest(http.MethodGet, "/"+tC.query, nil))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var rows []database.ListLanguageRequirementSummaryRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+			require.Equal(t, tC.wantRows, rows)
+		})
+	}
+}
+
+func TestListBatchAttributesHandler(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	for _, lines := range [][]string{
+		{"Work Location: Hybrid remote in 00-850 Warszawa", "Job Type: Full-time"},
+		{"Praca zdalna, B2B"},
+		{"Remote", "Kontrakt B2B lub umowa o pracę"},
+	} {
+		row, err := svc.CreatePhrasesBatch(context.Background(), lines[0], lines, make([]string, len(lines)), make([]string, len(lines)))
+		require.NoError(t, err)
+		_, err = svc.CreateBatchAttributes(context.Background(), row.BatchID.Int64, lines)
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		desc string
+
+		query    string
+		wantCode int
+		wantRows []database.ListBatchAttributesRow
+	}{
+		{
+			desc: "name",
+
+			query:    "?name=work_mode",
+			wantCode: http.StatusOK,
+			wantRows: []database.ListBatchAttributesRow{
+				{Name: "work_mode", Value: "remote", Total: 2},
+				{Name: "work_mode", Value: "hybrid", Total: 1},
+			},
+		},
+		{
+			desc: "batch",
+
+			query:    "?batch=Remote",
+			wantCode: http.StatusOK,
+			wantRows: []database.ListBatchAttributesRow{
+				{Name: "contract", Value: "b2b", Total: 1},
+				{Name: "contract", Value: "uop", Total: 1},
+				{Name: "work_mode", Value: "remote", Total: 1},
+			},
+		},
+		{
+			desc: "unknown_name",
+
+			query:    "?name=salary",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr := httptest.NewRecorder()
+			listBatchAttributesHandler(svc, testLogger())(rr, httptest.NewRequest(http.MethodGet, "/"+tC.query, nil))
+			require.Equal(t, tC.wantCode, rr.Code)
+			if tC.wantCode != http.StatusOK {
+				return
+			}
+
+			var rows []database.ListBatchAttributesRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+			require.Equal(t, tC.wantRows, rows)
 		})
 	}
 }
@@ -2,10 +2,16 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"strings"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/textproc"
 )
 
 type Service interface {
@@ -14,21 +20,100 @@ type Service interface {
 	ListWordBatches(ctx context.Context, limit, offset int32) ([]database.ListWordBatchesRow, error)
 	CreateWordsBatch(ctx context.Context, name string, values []string) (database.CreateWordsBatchRow, error)
 	ListWordsByBatchName(ctx context.Context, name string) ([]database.ListWordsByBatchNameRow, error)
-	CreatePhrasesBatch(ctx context.Context, name string, values []string) (database.CreatePhrasesBatchRow, error)
+	CreatePhrasesBatch(ctx context.Context, name string, values, languages, sections []string) (database.CreatePhrasesBatchRow, error)
+	CreatePhrasesBatchWithSources(ctx context.Context, name string, values, sources, languages, sections []string) (database.CreatePhrasesBatchWithSourcesRow, error)
+	CreatePhrasesUpload(ctx context.Context, u PhrasesUpload) (PhrasesUploadResult, error)
+	ListPhrases(ctx context.Context, batch, language, section string, limit, offset int32) ([]database.ListPhrasesRow, error)
+	CreateWordsBatchWithSources(ctx context.Context, name string, values, sources []string) (database.CreateWordsBatchWithSourcesRow, error)
+	CreateSalaries(ctx context.Context, batchID int64, salaries []textproc.Salary) ([]database.CreateSalariesRow, error)
+	ListSalaryStats(ctx context.Context, batch, currency, contract string) ([]database.ListSalaryStatsRow, error)
+	CreateExperiences(ctx context.Context, batchID int64, lines []string) ([]database.CreateExperiencesRow, error)
+	ListExperienceDistribution(ctx context.Context, batch string, terms []string) ([]database.ListExperienceDistributionRow, error)
+	CreateTechnologyVersions(ctx context.Context, batchID int64, lines []string) ([]database.CreateTechnologyVersionsRow, error)
+	ListVersionDistribution(ctx context.Context, batch string, terms []string) ([]database.ListVersionDistributionRow, error)
+	CreateLanguageRequirements(ctx context.Context, batchID int64, lines []string) ([]database.CreateLanguageRequirementsRow, error)
+	ListLanguageRequirementSummary(ctx context.Context, batch, language string) ([]database.ListLanguageRequirementSummaryRow, error)
+	CreateBatchAttributes(ctx context.Context, batchID int64, lines []string) ([]database.CreateBatchAttributesRow, error)
+	ListBatchAttributes(ctx context.Context, batch, name string) ([]database.ListBatchAttributesRow, error)
+	CreateNgramsBatch(ctx context.Context, name string, m textproc.Measure, ngrams []textproc.NGram) (database.CreateNgramsBatchRow, error)
+	ListNgramRankings(ctx context.Context, n int32, terms []string, limit, offset int32) ([]database.ListNgramRankingsRow, error)
+	ListTfidfRankings(ctx context.Context, batch string, terms []string, limit, offset int32) ([]database.ListTfidfRankingsRow, error)
+	ListWeightedRankings(ctx context.Context, batch string, terms []string, f BatchFilter, limit, offset int32) ([]database.ListWeightedRankingsRow, error)
+	ListWordRankings(ctx context.Context, terms []string, limit, offset int32) ([]database.ListWordRankingsRow, error)
+	Taxonomy(ctx context.Context) (textproc.Taxonomy, error)
+	ListSkillTerms(ctx context.Context) ([]database.ListSkillTermsRow, error)
+	SetSkillTerm(ctx context.Context, term, category string) (database.UpsertSkillTermRow, error)
+	DeleteSkillTerm(ctx context.Context, term string) error
 }
 
 type service struct {
-	q      database.Querier
-	logger *slog.Logger
+	q        database.Querier
+	db       beginner
+	logger   *slog.Logger
+	aliases  textproc.Aliases
+	taxonomy textproc.Taxonomy
+	stemming bool
 }
 
 var _ Service = (*service)(nil)
 
-func NewService(q database.Querier, l *slog.Logger) Service {
-	return &service{
-		q:      q,
-		logger: l,
+// ServiceOption configures a service created by NewService.
+type ServiceOption func(*service)
+
+// WithAliases normalizes created words with aliases instead of
+// textproc.DefaultAliases.
+func WithAliases(a textproc.Aliases) ServiceOption {
+	return func(svc *service) {
+		svc.aliases = a
+	}
+}
+
+// WithTaxonomy categorizes terms with t, edited by skill terms stored in
+// a database, instead of textproc.DefaultTaxonomy.
+func WithTaxonomy(t textproc.Taxonomy) ServiceOption {
+	return func(svc *service) {
+		svc.taxonomy = t
+	}
+}
+
+// WithStemming stores terms of phrases reduced to their stems, see
+// textproc.NewTermStemming, keeping terms of the taxonomy of the service
+// intact, see Taxonomy.
+func WithStemming() ServiceOption {
+	return func(svc *service) {
+		svc.stemming = true
+	}
+}
+
+// beginner begins transactions, e.g. *pgx.Conn.
+type beginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// WithTransactions runs multi-step writes of the service, e.g.
+// CreatePhrasesUpload, in transactions begun on db, so that none of their
+// rows are stored if any step fails. Querier q of the service must query
+// db.
+func WithTransactions(db beginner) ServiceOption {
+	return func(svc *service) {
+		svc.db = db
+	}
+}
+
+// NewService returns a service storing words in their canonical form,
+// normalized by textproc.DefaultAliases unless configured otherwise.
+func NewService(q database.Querier, l *slog.Logger, opts ...ServiceOption) Service {
+	svc := &service{
+		q:        q,
+		logger:   l,
+		aliases:  textproc.DefaultAliases(),
+		taxonomy: textproc.DefaultTaxonomy(),
+	}
+	for _, opt := range opts {
+		opt(svc)
 	}
+
+	return svc
 }
 
 func (svc *service) ListWords(ctx context.Context, limit, offset int32) ([]database.ListWordsRow, error) {
@@ -47,7 +132,7 @@ func (svc *service) CreateWord(ctx context.Context, value string) (database.Crea
 	if value == "" {
 		panic("value cannot be empty")
 	}
-	row, err := svc.q.CreateWord(ctx, value)
+	row, err := svc.q.CreateWord(ctx, svc.aliases.Normalize(value))
 	if err != nil {
 		return row, fmt.Errorf("insert word: %w", err)
 	}
@@ -70,7 +155,7 @@ func (svc *service) ListWordBatches(ctx context.Context, limit, offset int32) ([
 func (svc *service) CreateWordsBatch(ctx context.Context, name string, values []string) (database.CreateWordsBatchRow, error) {
 	row, err := svc.q.CreateWordsBatch(ctx, database.CreateWordsBatchParams{
 		Name:    name,
-		Column2: values,
+		Column2: svc.aliases.NormalizeAll(values),
 	})
 	if err != nil {
 		return row, fmt.Errorf("create word batch: %w", err)
@@ -88,14 +173,680 @@ func (svc *service) ListWordsByBatchName(ctx context.Context, name string) ([]da
 	return rows, nil
 }
 
-func (svc *service) CreatePhrasesBatch(ctx context.Context, name string, values []string) (database.CreatePhrasesBatchRow, error) {
+// CreatePhrasesBatch stores phrases along with ISO 639-1 codes of their
+// languages and sections of offers they come from, empty if not detected.
+func (svc *service) CreatePhrasesBatch(ctx context.Context, name string, values, languages, sections []string) (database.CreatePhrasesBatchRow, error) {
+	var row database.CreatePhrasesBatchRow
+	if len(values) != len(languages) {
+		return row, ErrLanguagesMismatch
+	}
+	if len(values) != len(sections) {
+		return row, ErrSectionsMismatch
+	}
 	row, err := svc.q.CreatePhrasesBatch(ctx, database.CreatePhrasesBatchParams{
 		Name:    name,
 		Column2: values,
+		Column3: languages,
+		Column4: sections,
 	})
 	if err != nil {
 		return row, fmt.Errorf("create word batch: %w", err)
 	}
+	if err := svc.createPhraseTerms(ctx, row.BatchID.Int64, values, sections); err != nil {
+		return row, err
+	}
+
+	return row, nil
+}
+
+// ErrSourcesMismatch is returned if batch values and their sources differ in length.
+var ErrSourcesMismatch = errors.New("every value must have a source")
+
+// ErrLanguagesMismatch is returned if phrases and their languages differ in length.
+var ErrLanguagesMismatch = errors.New("every phrase must have a language")
+
+// ErrSectionsMismatch is returned if phrases and their sections differ in length.
+var ErrSectionsMismatch = errors.New("every phrase must have a section")
+
+func (svc *service) CreatePhrasesBatchWithSources(ctx context.Context, name string, values, sources, languages, sections []string) (database.CreatePhrasesBatchWithSourcesRow, error) {
+	var row database.CreatePhrasesBatchWithSourcesRow
+	if len(values) != len(sources) {
+		return row, ErrSourcesMismatch
+	}
+	if len(values) != len(languages) {
+		return row, ErrLanguagesMismatch
+	}
+	if len(values) != len(sections) {
+		return row, ErrSectionsMismatch
+	}
+	row, err := svc.q.CreatePhrasesBatchWithSources(ctx, database.CreatePhrasesBatchWithSourcesParams{
+		Name:    name,
+		Column2: values,
+		Column3: sources,
+		Column4: languages,
+		Column5: sections,
+	})
+	if err != nil {
+		return row, fmt.Errorf("create phrases batch with sources: %w", err)
+	}
+	if err := svc.createPhraseTerms(ctx, row.BatchID.Int64, values, sections); err != nil {
+		return row, err
+	}
+
+	return row, nil
+}
+
+// createPhraseTerms stores terms of phrases of a batch, tokenized and
+// normalized by aliases, so that rankings of phrases count "Golang," and
+// "go" as the same term, along with sections of phrases they are found in.
+// Terms are stemmed if enabled, see WithStemming.
+func (svc *service) createPhraseTerms(ctx context.Context, batchID int64, values, sections []string) error {
+	var stemming *textproc.Stemming
+	if svc.stemming {
+		t, err := svc.Taxonomy(ctx)
+		if err != nil {
+			return fmt.Errorf("taxonomy: %w", err)
+		}
+		stemming = textproc.NewTermStemming(svc.aliases, t)
+	}
+
+	params := database.CreatePhraseTermsParams{
+		Column1: batchID,
+		Column2: make([]string, 0, len(values)),
+		Column3: make([]string, 0, len(values)),
+	}
+	for i, value := range values {
+		terms := svc.aliases.NormalizeAll(textproc.Tokens(value))
+		if stemming != nil {
+			terms, _ = stemming.Line(value, terms)
+		}
+		for _, term := range terms {
+			params.Column2 = append(params.Column2, term)
+			params.Column3 = append(params.Column3, sections[i])
+		}
+	}
+	if err := svc.q.CreatePhraseTerms(ctx, params); err != nil {
+		return fmt.Errorf("create phrase terms: %w", err)
+	}
+
+	return nil
+}
+
+// inTx calls fn with a service querying a transaction, committed if fn
+// returns no error, or with svc itself if it has no transactions, see
+// WithTransactions.
+func (svc *service) inTx(ctx context.Context, fn func(svc *service) error) error {
+	if svc.db == nil {
+		return fn(svc)
+	}
+
+	return pgx.BeginFunc(ctx, svc.db, func(tx pgx.Tx) error {
+		txSvc := *svc
+		txSvc.q = database.New(tx)
+
+		return fn(&txSvc)
+	})
+}
+
+// PhrasesUpload is a batch of phrases of a job offer to store, along with
+// ISO 639-1 codes of their languages and sections of the offer.
+type PhrasesUpload struct {
+	Name      string
+	Values    []string
+	Languages []string
+	Sections  []string
+	// Sources are where phrases were found, e.g. pages of a pdf document.
+	// Nil if unknown.
+	Sources []string
+}
+
+// PhrasesUploadResult holds rows stored by CreatePhrasesUpload.
+type PhrasesUploadResult struct {
+	Row         database.CreatePhrasesBatchWithSourcesRow `json:"row"`
+	Salaries    []database.CreateSalariesRow              `json:"salaries,omitempty"`
+	Experiences []database.CreateExperiencesRow           `json:"experiences,omitempty"`
+	Versions    []database.CreateTechnologyVersionsRow    `json:"versions,omitempty"`
+	Languages   []database.CreateLanguageRequirementsRow  `json:"languages,omitempty"`
+	Attributes  []database.CreateBatchAttributesRow       `json:"attributes,omitempty"`
+}
+
+// CreatePhrasesUpload stores a batch of phrases along with salaries,
+// experiences, technology versions, language requirements and attributes
+// of the offer found in them, all or none of them, see WithTransactions.
+func (svc *service) CreatePhrasesUpload(ctx context.Context, u PhrasesUpload) (PhrasesUploadResult, error) {
+	var res PhrasesUploadResult
+
+	salaries := make([]textproc.Salary, 0)
+	for _, value := range u.Values {
+		if s, ok := textproc.ParseSalary(value); ok {
+			salaries = append(salaries, s)
+		}
+	}
+
+	err := svc.inTx(ctx, func(svc *service) error {
+		var err error
+		if u.Sources == nil {
+			var row database.CreatePhrasesBatchRow
+			row, err = svc.CreatePhrasesBatch(ctx, u.Name, u.Values, u.Languages, u.Sections)
+			res.Row = database.CreatePhrasesBatchWithSourcesRow{
+				ID:       row.ID,
+				Value:    row.Value,
+				Language: row.Language,
+				Section:  row.Section,
+				BatchID:  row.BatchID,
+			}
+		} else {
+			res.Row, err = svc.CreatePhrasesBatchWithSources(ctx, u.Name, u.Values, u.Sources, u.Languages, u.Sections)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Both extractors link findings to the same taxonomy, loaded once
+		t, err := svc.Taxonomy(ctx)
+		if err != nil {
+			return fmt.Errorf("taxonomy: %w", err)
+		}
+
+		batchID := res.Row.BatchID.Int64
+		if res.Salaries, err = svc.CreateSalaries(ctx, batchID, salaries); err != nil {
+			return err
+		}
+		res.Experiences, err = svc.createExperiences(ctx, textproc.NewExperienceExtractor(t, svc.aliases), batchID, u.Values)
+		if err != nil {
+			return err
+		}
+		res.Versions, err = svc.createTechnologyVersions(ctx, textproc.NewVersionExtractor(t, svc.aliases), batchID, u.Values)
+		if err != nil {
+			return err
+		}
+		if res.Languages, err = svc.CreateLanguageRequirements(ctx, batchID, u.Values); err != nil {
+			return err
+		}
+		if res.Attributes, err = svc.CreateBatchAttributes(ctx, batchID, u.Values); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return PhrasesUploadResult{}, fmt.Errorf("create phrases upload: %w", err)
+	}
+
+	return res, nil
+}
+
+// ListPhrases lists phrases of batches named batch in language, an ISO
+// 639-1 code, found in section of offers. Empty batch, language or section
+// matches any.
+func (svc *service) ListPhrases(ctx context.Context, batch, language, section string, limit, offset int32) ([]database.ListPhrasesRow, error) {
+	rows, err := svc.q.ListPhrases(ctx, database.ListPhrasesParams{
+		Column1: batch,
+		Column2: strings.ToLower(language),
+		Column3: section,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list phrases: %w", err)
+	}
+
+	return rows, nil
+}
+
+func (svc *service) CreateWordsBatchWithSources(ctx context.Context, name string, values, sources []string) (database.CreateWordsBatchWithSourcesRow, error) {
+	var row database.CreateWordsBatchWithSourcesRow
+	if len(values) != len(sources) {
+		return row, ErrSourcesMismatch
+	}
+	row, err := svc.q.CreateWordsBatchWithSources(ctx, database.CreateWordsBatchWithSourcesParams{
+		Name:    name,
+		Column2: svc.aliases.NormalizeAll(values),
+		Column3: sources,
+	})
+	if err != nil {
+		return row, fmt.Errorf("create words batch with sources: %w", err)
+	}
 
 	return row, nil
 }
+
+// CreateSalaries stores salaries offered in phrases of a batch. Nothing is
+// stored if there are none.
+func (svc *service) CreateSalaries(ctx context.Context, batchID int64, salaries []textproc.Salary) ([]database.CreateSalariesRow, error) {
+	if len(salaries) == 0 {
+		return nil, nil
+	}
+
+	params := database.CreateSalariesParams{
+		Column1: batchID,
+		Column2: make([]float64, 0, len(salaries)),
+		Column3: make([]float64, 0, len(salaries)),
+		Column4: make([]string, 0, len(salaries)),
+		Column5: make([]string, 0, len(salaries)),
+		Column6: make([]string, 0, len(salaries)),
+		Column7: make([]string, 0, len(salaries)),
+	}
+	for _, s := range salaries {
+		params.Column2 = append(params.Column2, s.Min)
+		params.Column3 = append(params.Column3, s.Max)
+		params.Column4 = append(params.Column4, string(s.Currency))
+		params.Column5 = append(params.Column5, string(s.Period))
+		params.Column6 = append(params.Column6, string(s.Contract))
+		params.Column7 = append(params.Column7, s.Source)
+	}
+	rows, err := svc.q.CreateSalaries(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create salaries: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListSalaryStats aggregates monthly salaries of phrase batches named batch
+// per currency and contract. Empty batch, currency or contract matches any.
+func (svc *service) ListSalaryStats(ctx context.Context, batch, currency, contract string) ([]database.ListSalaryStatsRow, error) {
+	rows, err := svc.q.ListSalaryStats(ctx, database.ListSalaryStatsParams{
+		Column1: batch,
+		Column2: strings.ToUpper(currency),
+		Column3: strings.ToLower(contract),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list salary stats: %w", err)
+	}
+
+	return rows, nil
+}
+
+// CreateExperiences stores years of experience required by lines of phrases
+// of a batch, linked to technologies of the taxonomy, see Taxonomy. Nothing
+// is stored if there are none.
+func (svc *service) CreateExperiences(ctx context.Context, batchID int64, lines []string) ([]database.CreateExperiencesRow, error) {
+	t, err := svc.Taxonomy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("taxonomy: %w", err)
+	}
+
+	return svc.createExperiences(ctx, textproc.NewExperienceExtractor(t, svc.aliases), batchID, lines)
+}
+
+// createExperiences stores years of experience found by e in lines of
+// phrases of a batch.
+func (svc *service) createExperiences(ctx context.Context, e *textproc.ExperienceExtractor, batchID int64, lines []string) ([]database.CreateExperiencesRow, error) {
+	params := database.CreateExperiencesParams{
+		Column1: batchID,
+		Column2: make([]string, 0),
+		Column3: make([]int32, 0),
+		Column4: make([]int32, 0),
+		Column5: make([]string, 0),
+	}
+	for _, line := range lines {
+		for _, x := range e.Line(line) {
+			params.Column2 = append(params.Column2, x.Term)
+			params.Column3 = append(params.Column3, int32(x.MinYears))
+			params.Column4 = append(params.Column4, int32(x.MaxYears))
+			params.Column5 = append(params.Column5, x.Source)
+		}
+	}
+	if len(params.Column2) == 0 {
+		return nil, nil
+	}
+	rows, err := svc.q.CreateExperiences(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create experiences: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListExperienceDistribution counts technologies requiring every minimum
+// number of years of experience in phrase batches named batch, or in all
+// batches if batch is empty. Only terms are counted unless empty.
+func (svc *service) ListExperienceDistribution(ctx context.Context, batch string, terms []string) ([]database.ListExperienceDistributionRow, error) {
+	rows, err := svc.q.ListExperienceDistribution(ctx, database.ListExperienceDistributionParams{
+		Column1: batch,
+		Column2: terms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list experience distribution: %w", err)
+	}
+
+	return rows, nil
+}
+
+// CreateTechnologyVersions stores versions of technologies of the taxonomy
+// asked for by lines of phrases of a batch, see Taxonomy. Nothing is stored
+// if there are none.
+func (svc *service) CreateTechnologyVersions(ctx context.Context, batchID int64, lines []string) ([]database.CreateTechnologyVersionsRow, error) {
+	t, err := svc.Taxonomy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("taxonomy: %w", err)
+	}
+
+	return svc.createTechnologyVersions(ctx, textproc.NewVersionExtractor(t, svc.aliases), batchID, lines)
+}
+
+// createTechnologyVersions stores versions of technologies found by e in
+// lines of phrases of a batch.
+func (svc *service) createTechnologyVersions(ctx context.Context, e *textproc.VersionExtractor, batchID int64, lines []string) ([]database.CreateTechnologyVersionsRow, error) {
+	params := database.CreateTechnologyVersionsParams{
+		Column1: batchID,
+		Column2: make([]string, 0),
+		Column3: make([]string, 0),
+		Column4: make([]string, 0),
+	}
+	for _, line := range lines {
+		for _, v := range e.Line(line) {
+			params.Column2 = append(params.Column2, v.Term)
+			params.Column3 = append(params.Column3, v.Version)
+			params.Column4 = append(params.Column4, v.Source)
+		}
+	}
+	if len(params.Column2) == 0 {
+		return nil, nil
+	}
+	rows, err := svc.q.CreateTechnologyVersions(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create technology versions: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListVersionDistribution counts versions of every technology asked for in
+// phrase batches named batch, or in all batches if batch is empty. Only
+// versions of terms, in any of their aliases, are counted unless empty.
+func (svc *service) ListVersionDistribution(ctx context.Context, batch string, terms []string) ([]database.ListVersionDistributionRow, error) {
+	normalized := make([]string, 0, len(terms))
+	for _, term := range terms {
+		normalized = append(normalized, svc.normalizeTerm(term))
+	}
+	rows, err := svc.q.ListVersionDistribution(ctx, database.ListVersionDistributionParams{
+		Column1: batch,
+		Column2: normalized,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list version distribution: %w", err)
+	}
+
+	return rows, nil
+}
+
+// CreateLanguageRequirements stores spoken languages required by lines of
+// phrases of a batch, see textproc.ParseLanguageRequirements. Nothing is
+// stored if there are none.
+func (svc *service) CreateLanguageRequirements(ctx context.Context, batchID int64, lines []string) ([]database.CreateLanguageRequirementsRow, error) {
+	params := database.CreateLanguageRequirementsParams{
+		Column1: batchID,
+		Column2: make([]string, 0),
+		Column3: make([]string, 0),
+		Column4: make([]string, 0),
+	}
+	for _, line := range lines {
+		for _, req := range textproc.ParseLanguageRequirements(line) {
+			params.Column2 = append(params.Column2, req.Language)
+			params.Column3 = append(params.Column3, string(req.Proficiency))
+			params.Column4 = append(params.Column4, req.Source)
+		}
+	}
+	if len(params.Column2) == 0 {
+		return nil, nil
+	}
+	rows, err := svc.q.CreateLanguageRequirements(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create language requirements: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListLanguageRequirementSummary counts phrase batches named batch requiring
+// every spoken language at every level, the highest one of a batch. Empty
+// batch or language matches any.
+func (svc *service) ListLanguageRequirementSummary(ctx context.Context, batch, language string) ([]database.ListLanguageRequirementSummaryRow, error) {
+	rows, err := svc.q.ListLanguageRequirementSummary(ctx, database.ListLanguageRequirementSummaryParams{
+		Column1: batch,
+		Column2: strings.ToLower(language),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list language requirement summary: %w", err)
+	}
+
+	return rows, nil
+}
+
+// CreateBatchAttributes stores attributes of a job offer stated in lines of
+// phrases of a batch, see textproc.Attributes. Nothing is stored if there
+// are none.
+func (svc *service) CreateBatchAttributes(ctx context.Context, batchID int64, lines []string) ([]database.CreateBatchAttributesRow, error) {
+	attrs := textproc.Attributes(lines)
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+
+	params := database.CreateBatchAttributesParams{
+		Column1: batchID,
+		Column2: make([]string, 0, len(attrs)),
+		Column3: make([]string, 0, len(attrs)),
+	}
+	for _, a := range attrs {
+		params.Column2 = append(params.Column2, a.Name)
+		params.Column3 = append(params.Column3, a.Value)
+	}
+	rows, err := svc.q.CreateBatchAttributes(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("create batch attributes: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListBatchAttributes counts phrase batches named batch having every value
+// of attributes named name. Empty batch or name matches any.
+func (svc *service) ListBatchAttributes(ctx context.Context, batch, name string) ([]database.ListBatchAttributesRow, error) {
+	rows, err := svc.q.ListBatchAttributes(ctx, database.ListBatchAttributesParams{
+		Column1: batch,
+		Column2: name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list batch attributes: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ErrNoNgrams is returned if a batch of n-grams to create is empty.
+var ErrNoNgrams = errors.New("no n-grams")
+
+func (svc *service) CreateNgramsBatch(ctx context.Context, name string, m textproc.Measure, ngrams []textproc.NGram) (database.CreateNgramsBatchRow, error) {
+	var row database.CreateNgramsBatchRow
+	if len(ngrams) == 0 {
+		return row, ErrNoNgrams
+	}
+
+	params := database.CreateNgramsBatchParams{
+		Name:    name,
+		Measure: string(m),
+		Column3: make([]string, 0, len(ngrams)),
+		Column4: make([]int32, 0, len(ngrams)),
+		Column5: make([]int32, 0, len(ngrams)),
+		Column6: make([]float64, 0, len(ngrams)),
+	}
+	for _, g := range ngrams {
+		params.Column3 = append(params.Column3, strings.Join(svc.aliases.NormalizeAll(g.Words), " "))
+		params.Column4 = append(params.Column4, int32(len(g.Words)))
+		params.Column5 = append(params.Column5, int32(g.Count))
+		params.Column6 = append(params.Column6, g.Score)
+	}
+	row, err := svc.q.CreateNgramsBatch(ctx, params)
+	if err != nil {
+		return row, fmt.Errorf("create ngrams batch: %w", err)
+	}
+
+	return row, nil
+}
+
+func (svc *service) ListNgramRankings(ctx context.Context, n int32, terms []string, limit, offset int32) ([]database.ListNgramRankingsRow, error) {
+	rows, err := svc.q.ListNgramRankings(ctx, database.ListNgramRankingsParams{
+		Column1: n,
+		Limit:   limit,
+		Offset:  offset,
+		Column4: terms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list ngram rankings: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListTfidfRankings ranks words by their mean TF-IDF in batches named batch,
+// or in all batches if batch is empty. Only terms are ranked unless empty.
+func (svc *service) ListTfidfRankings(ctx context.Context, batch string, terms []string, limit, offset int32) ([]database.ListTfidfRankingsRow, error) {
+	rows, err := svc.q.ListTfidfRankings(ctx, database.ListTfidfRankingsParams{
+		Column1: batch,
+		Limit:   limit,
+		Offset:  offset,
+		Column4: terms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tfidf rankings: %w", err)
+	}
+
+	return rows, nil
+}
+
+// BatchFilter narrows phrase batches to job offers having all attributes
+// and mentioning all terms, e.g. remote B2B offers mentioning Go.
+type BatchFilter struct {
+	Attributes []textproc.Attribute
+	// Mentions are single word terms, any of their aliases is a mention.
+	Mentions []string
+}
+
+// ListWeightedRankings ranks words of phrases of batches named batch, or of
+// all batches if batch is empty, by a sum of weights of offer sections they
+// are found in, see textproc.Section.Weight. Only terms are ranked unless
+// empty, and only batches matching f.
+func (svc *service) ListWeightedRankings(ctx context.Context, batch string, terms []string, f BatchFilter, limit, offset int32) ([]database.ListWeightedRankingsRow, error) {
+	sections := append([]textproc.Section{textproc.SectionNone}, textproc.Sections...)
+	params := database.ListWeightedRankingsParams{
+		Column1:  batch,
+		Column2:  make([]string, 0, len(sections)),
+		Column3:  make([]float64, 0, len(sections)),
+		Column4:  terms,
+		Limit:    limit,
+		Offset:   offset,
+		Column7:  make([]string, 0, len(f.Attributes)),
+		Column8:  make([]string, 0, len(f.Attributes)),
+		Column9:  make([]string, 0, len(f.Mentions)),
+		Column10: make([]int32, 0, len(f.Mentions)),
+	}
+	for _, s := range sections {
+		params.Column2 = append(params.Column2, string(s))
+		params.Column3 = append(params.Column3, s.Weight())
+	}
+	for _, a := range f.Attributes {
+		params.Column7 = append(params.Column7, a.Name)
+		params.Column8 = append(params.Column8, a.Value)
+	}
+	// Terms of phrases are stored in their canonical form, see createPhraseTerms
+	for i, term := range f.Mentions {
+		params.Column9 = append(params.Column9, svc.aliases.Normalize(strings.ToLower(term)))
+		params.Column10 = append(params.Column10, int32(i))
+	}
+	rows, err := svc.q.ListWeightedRankings(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("list weighted rankings: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListWordRankings ranks words by their count. Only terms are ranked
+// unless empty.
+func (svc *service) ListWordRankings(ctx context.Context, terms []string, limit, offset int32) ([]database.ListWordRankingsRow, error) {
+	rows, err := svc.q.ListWordRankings(ctx, database.ListWordRankingsParams{
+		Limit:   limit,
+		Offset:  offset,
+		Column3: terms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list word rankings: %w", err)
+	}
+
+	return rows, nil
+}
+
+// Taxonomy returns taxonomy of the service edited by stored skill terms.
+func (svc *service) Taxonomy(ctx context.Context) (textproc.Taxonomy, error) {
+	rows, err := svc.q.ListSkillTerms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list skill terms: %w", err)
+	}
+
+	t := maps.Clone(svc.taxonomy)
+	if t == nil {
+		t = make(textproc.Taxonomy)
+	}
+	for _, row := range rows {
+		t.Edit(row.Term, row.Category.String)
+	}
+
+	return t, nil
+}
+
+func (svc *service) ListSkillTerms(ctx context.Context) ([]database.ListSkillTermsRow, error) {
+	rows, err := svc.q.ListSkillTerms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list skill terms: %w", err)
+	}
+
+	return rows, nil
+}
+
+var ErrEmptyTerm = errors.New("term cannot be empty")
+
+// SetSkillTerm stores an edit of taxonomy moving term, normalized by aliases,
+// to category. Empty category removes the term from taxonomy.
+func (svc *service) SetSkillTerm(ctx context.Context, term, category string) (database.UpsertSkillTermRow, error) {
+	var row database.UpsertSkillTermRow
+
+	term = svc.normalizeTerm(term)
+	if term == "" {
+		return row, ErrEmptyTerm
+	}
+	category = strings.ToLower(strings.TrimSpace(category))
+
+	row, err := svc.q.UpsertSkillTerm(ctx, database.UpsertSkillTermParams{
+		Term:     term,
+		Category: pgtype.Text{String: category, Valid: category != ""},
+	})
+	if err != nil {
+		return row, fmt.Errorf("upsert skill term: %w", err)
+	}
+
+	return row, nil
+}
+
+// ErrSkillTermNotFound is returned if there is no stored edit of a term.
+var ErrSkillTermNotFound = errors.New("skill term not found")
+
+// DeleteSkillTerm deletes a stored edit of term, restoring its category
+// in taxonomy of the service.
+func (svc *service) DeleteSkillTerm(ctx context.Context, term string) error {
+	n, err := svc.q.DeleteSkillTerm(ctx, svc.normalizeTerm(term))
+	if err != nil {
+		return fmt.Errorf("delete skill term: %w", err)
+	}
+	if n == 0 {
+		return ErrSkillTermNotFound
+	}
+
+	return nil
+}
+
+// normalizeTerm returns canonical forms of words of term joined by a space.
+func (svc *service) normalizeTerm(term string) string {
+	return strings.Join(svc.aliases.NormalizeAll(strings.Fields(strings.ToLower(term))), " ")
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceNormalizesWords(t *testing.T) {
+	t.Parallel()
+
+	custom, err := textproc.ParseAliases(strings.NewReader("golang:\nkubernetes: k8s, kube"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+
+		opts []ServiceOption
+		want []string
+	}{
+		{
+			desc: "default_aliases",
+
+			want: []string{"go", "kubernetes", "kube", "postgresql"},
+		},
+		{
+			desc: "configured_aliases",
+
+			opts: []ServiceOption{WithAliases(custom)},
+			want: []string{"golang", "kubernetes", "kubernetes", "postgres"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			values := []string{"golang", "k8s", "kube", "postgres"}
+
+			q := NewQueriesMock()
+			svc := NewService(q, testLogger(), tC.opts...)
+
+			_, err := svc.CreateWordsBatch(ctx, "batch", values)
+			require.NoError(t, err)
+			_, err = svc.CreateWordsBatchWithSources(ctx, "batch", values, values)
+			require.NoError(t, err)
+			row, err := svc.CreateWord(ctx, "golang")
+			require.NoError(t, err)
+
+			require.Equal(t, tC.want, q.wordsBatches[0].Column2)
+			require.Equal(t, tC.want, q.sourcedWordsBatches[0].Column2)
+			require.Equal(t, values, q.sourcedWordsBatches[0].Column3, "sources are not normalized")
+			require.Equal(t, tC.want[0], row.Value)
+		})
+	}
+}
+
+func TestServiceStoresPhraseTerms(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	values := []string{"Golang, K8s (Kubernetes).", "C++/Go developers!"}
+	sections := []string{"requirements", ""}
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	_, err := svc.CreatePhrasesBatch(ctx, "offer.png", values, []string{"en", "en"}, sections)
+	require.NoError(t, err)
+	_, err = svc.CreatePhrasesBatchWithSources(ctx, "offer.pdf", values, []string{"offer.pdf#page=1", "offer.pdf#page=1"}, []string{"en", "en"}, sections)
+	require.NoError(t, err)
+
+	require.Len(t, q.phraseTerms, 2)
+	for i, terms := range q.phraseTerms {
+		require.Equal(t, int64(i)+1, terms.Column1)
+		require.Equal(t, []string{"go", "kubernetes", "kubernetes", "c++", "go", "developers"}, terms.Column2)
+		require.Equal(t, []string{"requirements", "requirements", "requirements", "", "", ""}, terms.Column3)
+	}
+}
+
+func TestServiceStemsPhraseTerms(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	values := []string{"We are building our deployment pipelines with Jenkins and Docker"}
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger(), WithStemming())
+
+	// Stored skill terms are kept intact, removed ones are stemmed
+	_, err := svc.SetSkillTerm(ctx, "pipelines", "tools")
+	require.NoError(t, err)
+	_, err = svc.SetSkillTerm(ctx, "jenkins", "")
+	require.NoError(t, err)
+
+	_, err = svc.CreatePhrasesBatch(ctx, "offer.png", values, []string{"en"}, []string{""})
+	require.NoError(t, err)
+
+	require.Len(t, q.phraseTerms, 1)
+	require.Equal(t, []string{
+		"we", "are", "build", "our", "deploy", "pipelines", "with", "jenkin", "and", "docker",
+	}, q.phraseTerms[0].Column2)
+}
+
+func TestServiceCreatesPhrasesUpload(t *testing.T) {
+	t.Parallel()
+
+	values := []string{
+		"Pay: 15 000 - 20 000 PLN netto/mies. (B2B)",
+		"3+ years of experience with Go 1.22",
+		"English B2, remote work",
+	}
+
+	testCases := []struct {
+		desc string
+
+		sources []string
+	}{
+		{
+			desc: "phrases_of_an_image",
+		},
+		{
+			desc: "phrases_of_a_pdf_document",
+
+			sources: []string{"offer.pdf#page=1", "offer.pdf#page=1", "offer.pdf#page=2"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			q := NewQueriesMock()
+			svc := NewService(q, testLogger())
+
+			res, err := svc.CreatePhrasesUpload(context.Background(), PhrasesUpload{
+				Name:      "offer",
+				Values:    values,
+				Languages: []string{"pl", "en", "en"},
+				Sections:  make([]string, len(values)),
+				Sources:   tC.sources,
+			})
+			require.NoError(t, err)
+
+			if tC.sources == nil {
+				require.Len(t, q.phrasesBatches, 1)
+				require.Empty(t, q.sourcedPhrasesBatches)
+			} else {
+				require.Empty(t, q.phrasesBatches)
+				require.Len(t, q.sourcedPhrasesBatches, 1)
+			}
+			require.Equal(t, int64(1), res.Row.BatchID.Int64)
+			require.Len(t, res.Salaries, 1)
+			require.Equal(t, res.Row.BatchID, res.Salaries[0].BatchID)
+			require.Len(t, res.Experiences, 1)
+			require.Equal(t, "go", res.Experiences[0].Term.String)
+			require.Len(t, res.Versions, 1)
+			require.Equal(t, "1.22", res.Versions[0].Version)
+			require.Len(t, res.Languages, 1)
+			require.Equal(t, "en", res.Languages[0].Language)
+			require.NotEmpty(t, res.Attributes)
+			require.Equal(t, 1, q.skillTermsLists, "taxonomy is loaded once")
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kndrad/piccrack/internal/database"
+	"github.com/kndrad/piccrack/pkg/textproc"
+)
+
+var ErrInvalidGrouping = errors.New("rankings can only be grouped by category")
+
+// categoryQuery filters and groups rankings by categories of a taxonomy.
+type categoryQuery struct {
+	taxonomy textproc.Taxonomy
+	terms    []string // Ranked terms, all if empty
+	group    bool
+}
+
+// parseCategoryQuery returns terms of comma separated categories passed in
+// query values and whether rankings are grouped by category. Grouped
+// rankings without categories include terms of every category.
+func parseCategoryQuery(ctx context.Context, svc Service, values url.Values) (categoryQuery, error) {
+	var cq categoryQuery
+
+	categories := make([]string, 0)
+	for _, c := range strings.Split(values.Get("category"), ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	switch values.Get("group_by") {
+	case "":
+	case "category":
+		cq.group = true
+	default:
+		return cq, fmt.Errorf("%w: %q", ErrInvalidGrouping, values.Get("group_by"))
+	}
+	if len(categories) == 0 && !cq.group {
+		return cq, nil
+	}
+
+	t, err := svc.Taxonomy(ctx)
+	if err != nil {
+		return cq, fmt.Errorf("taxonomy: %w", err)
+	}
+	cq.taxonomy = t
+
+	if len(categories) == 0 {
+		categories = t.Categories()
+	}
+	if cq.terms, err = t.Terms(categories...); err != nil {
+		return cq, fmt.Errorf("terms: %w", err)
+	}
+
+	return cq, nil
+}
+
+// page returns limit and offset of a query, every row if rankings are
+// grouped and limited afterwards.
+func (cq categoryQuery) page(limit, offset int32) (int32, int32) {
+	if cq.group {
+		return math.MaxInt32, 0
+	}
+
+	return limit, offset
+}
+
+// categoryErrorCode returns http status code of an error returned
+// by parseCategoryQuery.
+func categoryErrorCode(err error) int {
+	if errors.Is(err, textproc.ErrUnknownCategory) || errors.Is(err, ErrInvalidGrouping) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// respondRankings encodes rows, grouped by category of their value
+// with at most limit rows in every group if requested.
+func respondRankings[T any](w http.ResponseWriter, r *http.Request, cq categoryQuery, rows []T, value func(T) string, limit int32) {
+	var v any = rows
+	if cq.group {
+		v = struct {
+			Groups map[string][]T `json:"groups"`
+		}{
+			Groups: textproc.GroupByCategory(cq.taxonomy, rows, value, int(limit)),
+		}
+	}
+	if err := encode(w, r, http.StatusOK, v); err != nil {
+		respondJSON(w, "Failed to encode rows", err, http.StatusInternalServerError)
+	}
+}
+
+// listWordRankingsHandler ranks words by their count, optionally only those
+// of categories and grouped by category.
+func listWordRankingsHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		values := r.URL.Query()
+
+		limit, err := limitValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid limit", err, http.StatusBadRequest)
+
+			return
+		}
+		offset, err := offsetValue(values)
+		if err != nil {
+			respondJSON(w, "Invalid offset", err, http.StatusBadRequest)
+
+			return
+		}
+		cq, err := parseCategoryQuery(r.Context(), svc, values)
+		if err != nil {
+			respondJSON(w, "Invalid category", err, categoryErrorCode(err))
+
+			return
+		}
+
+		queryLimit, queryOffset := cq.page(limit, offset)
+		rows, err := svc.ListWordRankings(r.Context(), cq.terms, queryLimit, queryOffset)
+		if err != nil {
+			respondJSON(w, "Failed to list word rankings", err, http.StatusInternalServerError)
+
+			return
+		}
+		respondRankings(w, r, cq, rows, func(row database.ListWordRankingsRow) string { return row.Value }, limit)
+	}
+}
+
+// listSkillsHandler returns terms of every category of taxonomy edited by
+// stored skill terms, along with the edits.
+func listSkillsHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	type response struct {
+		Categories map[string][]string          `json:"categories"`
+		Edits      []database.ListSkillTermsRow `json:"edits"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		l.Info("Received request", slog.String("url", r.URL.String()))
+
+		t, err := svc.Taxonomy(r.Context())
+		if err != nil {
+			respondJSON(w, "Failed to get taxonomy", err, http.StatusInternalServerError)
+
+			return
+		}
+		edits, err := svc.ListSkillTerms(r.Context())
+		if err != nil {
+			respondJSON(w, "Failed to list skill terms", err, http.StatusInternalServerError)
+
+			return
+		}
+
+		resp := response{
+			Categories: make(map[string][]string),
+			Edits:      edits,
+		}
+		for _, c := range t.Categories() {
+			if resp.Categories[c], err = t.Terms(c); err != nil {
+				respondJSON(w, "Failed to get terms", err, http.StatusInternalServerError)
+
+				return
+			}
+		}
+		if err := encode(w, r, http.StatusOK, resp); err != nil {
+			respondJSON(w, "Failed to encode response", err, http.StatusInternalServerError)
+		}
+	}
+}
+
+// setSkillHandler moves a term of the path to a category of the request,
+// removing it from taxonomy if the category is empty.
+func setSkillHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	type request struct {
+		Category string `json:"category"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, err := decode[request](r)
+		if err != nil {
+			respondJSON(w, "Failed to decode request", err, http.StatusBadRequest)
+
+			return
+		}
+
+		row, err := svc.SetSkillTerm(r.Context(), r.PathValue("term"), req.Category)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, ErrEmptyTerm) {
+				code = http.StatusBadRequest
+			}
+			respondJSON(w, "Failed to set skill term", err, code)
+
+			return
+		}
+		l.Info("Set skill term",
+			slog.String("term", row.Term),
+			slog.String("category", row.Category.String),
+		)
+		if err := encode(w, r, http.StatusOK, row); err != nil {
+			respondJSON(w, "Failed to encode row", err, http.StatusInternalServerError)
+		}
+	}
+}
+
+// deleteSkillHandler deletes a stored edit of a term of the path, restoring
+// its default category.
+func deleteSkillHandler(svc Service, l *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		term := r.PathValue("term")
+
+		if err := svc.DeleteSkillTerm(r.Context(), term); err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, ErrSkillTermNotFound) {
+				code = http.StatusNotFound
+			}
+			respondJSON(w, "Failed to delete skill term", err, code)
+
+			return
+		}
+		l.Info("Deleted skill term", slog.String("term", term))
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kndrad/piccrack/internal/database"
+	"github.com/stretchr/testify/require"
+)
+
+// rankedWordsMock returns words occurring fewer times the later they are.
+func rankedWordsMock(values ...string) []WordMock {
+	mocks := make([]WordMock, 0)
+	for i, value := range values {
+		for range len(values) - i {
+			mocks = append(mocks, WordMock{id: int64(len(mocks)) + 1, value: value})
+		}
+	}
+
+	return mocks
+}
+
+func TestListWordRankingsHandler(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		query      string
+		wantCode   int
+		wantValues []string
+		wantGroups map[string][]string
+	}{
+		{
+			desc: "all_words",
+
+			query:      "",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"experience", "go", "postgresql", "aws", "redis", "python"},
+		},
+		{
+			desc: "category",
+
+			query:      "?category=databases",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"postgresql", "redis"},
+		},
+		{
+			desc: "categories",
+
+			query:      "?category=databases,%20Clouds",
+			wantCode:   http.StatusOK,
+			wantValues: []string{"postgresql", "aws", "redis"},
+		},
+		{
+			desc: "grouped_by_category",
+
+			query:    "?group_by=category&limit=1",
+			wantCode: http.StatusOK,
+			wantGroups: map[string][]string{
+				"languages": {"go"},
+				"databases": {"postgresql"},
+				"clouds":    {"aws"},
+			},
+		},
+		{
+			desc: "unknown_category",
+
+			query:    "?category=hobbies",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			desc: "invalid_grouping",
+
+			query:    "?group_by=batch",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			q := NewQueriesMock(rankedWordsMock("experience", "go", "postgresql", "aws", "redis", "python")...)
+			svc := NewService(q, testLogger())
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/"+tC.query, nil)
+			listWordRankingsHandler(svc, testLogger())(rr, req)
+
+			require.Equal(t, tC.wantCode, rr.Code, rr.Body.String())
+			if tC.wantCode != http.StatusOK {
+				return
+			}
+
+			values := func(rows []database.ListWordRankingsRow) []string {
+				values := make([]string, 0, len(rows))
+				for _, row := range rows {
+					values = append(values, row.Value)
+				}
+
+				return values
+			}
+			if tC.wantGroups != nil {
+				var resp struct {
+					Groups map[string][]database.ListWordRankingsRow `json:"groups"`
+				}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+
+				groups := make(map[string][]string)
+				for c, rows := range resp.Groups {
+					groups[c] = values(rows)
+				}
+				require.Equal(t, tC.wantGroups, groups)
+
+				return
+			}
+
+			var rows []database.ListWordRankingsRow
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&rows))
+			require.Equal(t, tC.wantValues, values(rows))
+		})
+	}
+}
+
+func TestSkillsHandlers(t *testing.T) {
+	t.Parallel()
+
+	q := NewQueriesMock()
+	svc := NewService(q, testLogger())
+
+	set := func(term, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+		req.SetPathValue("term", term)
+		setSkillHandler(svc, testLogger())(rr, req)
+
+		return rr
+	}
+	del := func(term string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.SetPathValue("term", term)
+		deleteSkillHandler(svc, testLogger())(rr, req)
+
+		return rr
+	}
+	categories := func() map[string][]string {
+		rr := httptest.NewRecorder()
+		listSkillsHandler(svc, testLogger())(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Categories map[string][]string `json:"categories"`
+		}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+
+		return resp.Categories
+	}
+
+	require.Contains(t, categories()["languages"], "go")
+
+	// Terms are normalized by aliases
+	rr := set("Golang", `{"category": "Tools"}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), `"term":"go"`)
+	require.NotContains(t, categories()["languages"], "go")
+	require.Contains(t, categories()["tools"], "go")
+
+	require.Equal(t, http.StatusOK, set("terraform", `{"category": ""}`).Code)
+	require.NotContains(t, categories()["tools"], "terraform")
+
+	require.Equal(t, http.StatusOK, set("lit", `{"category": "frameworks"}`).Code)
+	require.Contains(t, categories()["frameworks"], "lit")
+
+	require.Equal(t, http.StatusBadRequest, set(" ", `{"category": "tools"}`).Code)
+	require.Equal(t, http.StatusBadRequest, set("go", `{`).Code)
+
+	// Deleted edits restore default categories
+	require.Equal(t, http.StatusNoContent, del("golang").Code)
+	require.Equal(t, http.StatusNoContent, del("terraform").Code)
+	require.Equal(t, http.StatusNotFound, del("terraform").Code)
+	require.Contains(t, categories()["languages"], "go")
+	require.Contains(t, categories()["tools"], "terraform")
+}
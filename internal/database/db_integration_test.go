@@ -18,6 +18,7 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kndrad/piccrack/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -227,9 +228,517 @@ func TestDatabaseQueries(t *testing.T) {
 		}
 	})
 
+	t.Run("create_phrases_batch_with_sources", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		row, err := q.CreatePhrasesBatchWithSources(ctx, CreatePhrasesBatchWithSourcesParams{
+			Name:    "offer.pdf",
+			Column2: []string{"senior golang developer", "remote work"},
+			Column3: []string{"offer.pdf#page=1", "offer.pdf#page=2"},
+			Column4: []string{"en", ""},
+			Column5: []string{"requirements", ""},
+		})
+		require.NoError(t, err)
+		require.True(t, row.Source.Valid)
+		require.True(t, row.BatchID.Valid)
+
+		err = q.CreatePhraseTerms(ctx, CreatePhraseTermsParams{
+			Column1: row.BatchID.Int64,
+			Column2: []string{"senior", "go", "developer", "remote", "work"},
+			Column3: []string{"requirements", "requirements", "requirements", "", ""},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("list_phrases_by_language", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		batch, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "offer.png",
+			Column2: []string{"wymagania", "strong experience with go"},
+			Column3: []string{"pl", "en"},
+			Column4: []string{"requirements", "nice_to_have"},
+		})
+		require.NoError(t, err)
+		err = q.CreatePhraseTerms(ctx, CreatePhraseTermsParams{
+			Column1: batch.BatchID.Int64,
+			Column2: []string{"wymagania", "strong", "experience", "with", "go"},
+			Column3: []string{"requirements", "nice_to_have", "nice_to_have", "nice_to_have", "nice_to_have"},
+		})
+		require.NoError(t, err)
+
+		rows, err := q.ListPhrases(ctx, ListPhrasesParams{
+			Column2: "en",
+			Limit:   DefaultQueryLimit,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, "senior golang developer", rows[0].Value)
+		require.Equal(t, "offer.png", rows[1].BatchName)
+
+		// Undetected language is stored as null
+		rows, err = q.ListPhrases(ctx, ListPhrasesParams{
+			Column1: "offer.pdf",
+			Limit:   DefaultQueryLimit,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.False(t, rows[1].Language.Valid)
+	})
+
+	t.Run("list_phrases_by_section_and_weighted_rankings", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		rows, err := q.ListPhrases(ctx, ListPhrasesParams{
+			Column3: "requirements",
+			Limit:   DefaultQueryLimit,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, "senior golang developer", rows[0].Value)
+		require.Equal(t, "wymagania", rows[1].Value)
+
+		rankings, err := q.ListWeightedRankings(ctx, ListWeightedRankingsParams{
+			Column2: []string{"", "requirements", "nice_to_have"},
+			Column3: []float64{0.6, 1, 0.4},
+			Column4: []string{"go", "remote"},
+			Limit:   DefaultQueryLimit,
+		})
+		require.NoError(t, err)
+		require.Len(t, rankings, 2)
+		require.Equal(t, "go", rankings[0].Value)
+		require.InDelta(t, 1.4, rankings[0].Score, 1e-9)
+		require.Equal(t, "remote", rankings[1].Value)
+		require.Equal(t, int64(2), rankings[1].Ranking)
+	})
+
+	t.Run("create_words_batch_with_sources", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		row, err := q.CreateWordsBatchWithSources(ctx, CreateWordsBatchWithSourcesParams{
+			Name:    "offer.pdf",
+			Column2: []string{"go", "remote"},
+			Column3: []string{"offer.pdf#page=1", "offer.pdf#page=2"},
+		})
+		require.NoError(t, err)
+		require.True(t, row.Source.Valid)
+		require.True(t, row.BatchID.Valid)
+	})
+
+	t.Run("list_tfidf_rankings", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		_, err = q.CreateWordsBatch(ctx, CreateWordsBatchParams{
+			Name:    "other.txt",
+			Column2: []string{"go", "java", "java"},
+		})
+		require.NoError(t, err)
+		// Phrases are ranked by their stored terms
+		batch, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "terms.png",
+			Column2: []string{"Golang, Kafka."},
+			Column3: []string{"en"},
+			Column4: []string{""},
+		})
+		require.NoError(t, err)
+		err = q.CreatePhraseTerms(ctx, CreatePhraseTermsParams{
+			Column1: batch.BatchID.Int64,
+			Column2: []string{"go", "kafka"},
+			Column3: []string{"", ""},
+		})
+		require.NoError(t, err)
+
+		rows, err := q.ListTfidfRankings(ctx, ListTfidfRankingsParams{
+			Column1: "other.txt",
+			Limit:   DefaultQueryLimit,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, "java", rows[0].Value)
+		require.Positive(t, rows[0].Score)
+		// Word of every batch is not distinctive
+		require.Equal(t, "go", rows[1].Value)
+		require.Zero(t, rows[1].Score)
+
+		rows, err = q.ListTfidfRankings(ctx, ListTfidfRankingsParams{
+			Column1: "terms.png",
+			Limit:   DefaultQueryLimit,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, "kafka", rows[0].Value)
+		require.Positive(t, rows[0].Score)
+	})
+
+	t.Run("create_ngrams_batch_and_list_rankings", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		row, err := q.CreateNgramsBatch(ctx, CreateNgramsBatchParams{
+			Name:    "offer.txt",
+			Measure: "pmi",
+			Column3: []string{"machine learning", "distributed systems", "event driven architecture"},
+			Column4: []int32{2, 2, 3},
+			Column5: []int32{3, 2, 2},
+			Column6: []float64{4.5, 5.1, 8.2},
+		})
+		require.NoError(t, err)
+		require.True(t, row.BatchID.Valid)
+
+		rows, err := q.ListNgramRankings(ctx, ListNgramRankingsParams{
+			Column1: 2,
+			Limit:   DefaultQueryLimit,
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, "machine learning", rows[0].Value)
+		require.Equal(t, int64(3), rows[0].Total)
+		require.Equal(t, int64(1), rows[0].Ranking)
+	})
+
+	t.Run("upsert_list_and_delete_skill_terms", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		_, err = q.UpsertSkillTerm(ctx, UpsertSkillTermParams{
+			Term:     "golang",
+			Category: pgtype.Text{String: "tools", Valid: true},
+		})
+		require.NoError(t, err)
+		row, err := q.UpsertSkillTerm(ctx, UpsertSkillTermParams{
+			Term:     "golang",
+			Category: pgtype.Text{String: "languages", Valid: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "languages", row.Category.String)
+
+		rows, err := q.ListSkillTerms(ctx)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+
+		n, err := q.DeleteSkillTerm(ctx, "golang")
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+		n, err = q.DeleteSkillTerm(ctx, "golang")
+		require.NoError(t, err)
+		require.Zero(t, n)
+
+		rows, err = q.ListSkillTerms(ctx)
+		require.NoError(t, err)
+		require.Empty(t, rows)
+
+		rankings, err := q.ListWordRankings(ctx, ListWordRankingsParams{
+			Limit:   DefaultQueryLimit,
+			Column3: []string{"golang"},
+		})
+		require.NoError(t, err)
+		require.Len(t, rankings, 1)
+		require.Equal(t, "golang", rankings[0].Value)
+	})
+
+	t.Run("create_salaries_and_list_stats", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		batch, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "salaries.png",
+			Column2: []string{"pay: 12 000 - 18 000 pln per month", "pay: 180 000 pln per year"},
+			Column3: []string{"en", "en"},
+			Column4: []string{"", ""},
+		})
+		require.NoError(t, err)
+
+		rows, err := q.CreateSalaries(ctx, CreateSalariesParams{
+			Column1: batch.BatchID.Int64,
+			Column2: []float64{12000, 180000},
+			Column3: []float64{18000, 180000},
+			Column4: []string{"PLN", "PLN"},
+			Column5: []string{"month", "year"},
+			Column6: []string{"b2b", ""},
+			Column7: []string{"pay: 12 000 - 18 000 pln per month", "pay: 180 000 pln per year"},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.Equal(t, batch.BatchID, rows[0].BatchID)
+		// Undetected contract is stored as null
+		require.False(t, rows[1].Contract.Valid)
+
+		stats, err := q.ListSalaryStats(ctx, ListSalaryStatsParams{
+			Column1: "salaries.png",
+			Column2: "PLN",
+		})
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		require.Equal(t, "", stats[0].Contract)
+		// Yearly salaries are normalized to a month
+		require.InDelta(t, 15000, stats[0].MinAmount, 1e-9)
+		require.Equal(t, "b2b", stats[1].Contract)
+		require.InDelta(t, 15000, stats[1].MedianAmount, 1e-9)
+	})
+
+	t.Run("create_experiences_and_list_distribution", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		batch, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "experiences.png",
+			Column2: []string{"3+ years of go", "2-3 years of go", "at least 3 years"},
+			Column3: []string{"en", "en", "en"},
+			Column4: []string{"", "", ""},
+		})
+		require.NoError(t, err)
+
+		rows, err := q.CreateExperiences(ctx, CreateExperiencesParams{
+			Column1: batch.BatchID.Int64,
+			Column2: []string{"go", "go", ""},
+			Column3: []int32{3, 2, 3},
+			Column4: []int32{0, 3, 0},
+			Column5: []string{"3+ years of go", "2-3 years of go", "at least 3 years"},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		// Open-ended requirements and unknown technologies are stored as null
+		require.False(t, rows[0].MaxYears.Valid)
+		require.False(t, rows[2].Term.Valid)
+
+		distribution, err := q.ListExperienceDistribution(ctx, ListExperienceDistributionParams{
+			Column1: "experiences.png",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []ListExperienceDistributionRow{
+			{Term: "go", MinYears: 2, Total: 1},
+			{Term: "go", MinYears: 3, Total: 1},
+		}, distribution)
+	})
+
+	t.Run("create_batch_attributes_and_filter_weighted_rankings", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		remote, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "remote.png",
+			Column2: []string{"golang kafka", "remote b2b"},
+			Column3: []string{"en", "en"},
+			Column4: []string{"requirements", ""},
+		})
+		require.NoError(t, err)
+		err = q.CreatePhraseTerms(ctx, CreatePhraseTermsParams{
+			Column1: remote.BatchID.Int64,
+			Column2: []string{"go", "kafka", "remote", "b2b"},
+			Column3: []string{"requirements", "requirements", "", ""},
+		})
+		require.NoError(t, err)
+		onsite, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "onsite.png",
+			Column2: []string{"java kafka", "on-site"},
+			Column3: []string{"en", "en"},
+			Column4: []string{"requirements", ""},
+		})
+		require.NoError(t, err)
+		err = q.CreatePhraseTerms(ctx, CreatePhraseTermsParams{
+			Column1: onsite.BatchID.Int64,
+			Column2: []string{"java", "kafka", "on-site"},
+			Column3: []string{"requirements", "requirements", ""},
+		})
+		require.NoError(t, err)
+
+		params := CreateBatchAttributesParams{
+			Column1: remote.BatchID.Int64,
+			Column2: []string{"contract", "work_mode"},
+			Column3: []string{"b2b", "remote"},
+		}
+		rows, err := q.CreateBatchAttributes(ctx, params)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		// Attributes stored already are skipped
+		rows, err = q.CreateBatchAttributes(ctx, params)
+		require.NoError(t, err)
+		require.Empty(t, rows)
+
+		attributes, err := q.ListBatchAttributes(ctx, ListBatchAttributesParams{
+			Column1: "remote.png",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []ListBatchAttributesRow{
+			{Name: "contract", Value: "b2b", Total: 1},
+			{Name: "work_mode", Value: "remote", Total: 1},
+		}, attributes)
+
+		rankings, err := q.ListWeightedRankings(ctx, ListWeightedRankingsParams{
+			Column2:  []string{"", "requirements"},
+			Column3:  []float64{0.6, 1},
+			Column4:  []string{"kafka", "java", "go"},
+			Limit:    10,
+			Column7:  []string{"work_mode", "contract"},
+			Column8:  []string{"remote", "b2b"},
+			Column9:  []string{"go"},
+			Column10: []int32{0},
+		})
+		require.NoError(t, err)
+		require.Len(t, rankings, 2)
+		require.Equal(t, "go", rankings[0].Value)
+		require.Equal(t, "kafka", rankings[1].Value)
+	})
+
+	t.Run("create_technology_versions_and_list_distribution", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		batch, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "versions.png",
+			Column2: []string{"java 8 or java 17", "java 17/21"},
+			Column3: []string{"en", "en"},
+			Column4: []string{"", ""},
+		})
+		require.NoError(t, err)
+
+		rows, err := q.CreateTechnologyVersions(ctx, CreateTechnologyVersionsParams{
+			Column1: batch.BatchID.Int64,
+			Column2: []string{"java", "java", "java", "java"},
+			Column3: []string{"8", "17", "17", "21"},
+			Column4: []string{"java 8 or java 17", "java 8 or java 17", "java 17/21", "java 17/21"},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 4)
+
+		distribution, err := q.ListVersionDistribution(ctx, ListVersionDistributionParams{
+			Column1: "versions.png",
+			Column2: []string{"java"},
+		})
+		require.NoError(t, err)
+		// Versions are ordered by number, "21" is later than "8"
+		require.Equal(t, []ListVersionDistributionRow{
+			{Term: "java", Version: "17", Total: 2},
+			{Term: "java", Version: "21", Total: 1},
+			{Term: "java", Version: "8", Total: 1},
+		}, distribution)
+	})
+
+	t.Run("create_language_requirements_and_list_summary", func(t *testing.T) {
+		conn, err := pgx.Connect(ctx, fx.ContainerConnStr(t))
+		require.NoError(t, err)
+		defer conn.Close(ctx)
+
+		q := New(conn)
+		batch, err := q.CreatePhrasesBatch(ctx, CreatePhrasesBatchParams{
+			Name:    "languages.png",
+			Column2: []string{"english b2, polish native", "fluent english"},
+			Column3: []string{"en", "en"},
+			Column4: []string{"", ""},
+		})
+		require.NoError(t, err)
+
+		rows, err := q.CreateLanguageRequirements(ctx, CreateLanguageRequirementsParams{
+			Column1: batch.BatchID.Int64,
+			Column2: []string{"en", "pl", "en"},
+			Column3: []string{"B2", "native", "C1"},
+			Column4: []string{"english b2, polish native", "english b2, polish native", "fluent english"},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+
+		summary, err := q.ListLanguageRequirementSummary(ctx, ListLanguageRequirementSummaryParams{
+			Column1: "languages.png",
+		})
+		require.NoError(t, err)
+		// A batch is counted at the highest level of a language only
+		require.Equal(t, []ListLanguageRequirementSummaryRow{
+			{Language: "en", Proficiency: "C1", Total: 1},
+			{Language: "pl", Proficiency: "native", Total: 1},
+		}, summary)
+	})
+
 	fx.RunCleanup(t)
 }
 
+func TestPhraseTermsBackfill(t *testing.T) {
+	t.Parallel()
+
+	fx := newFixture(t)
+	defer fx.RunCleanup(t)
+
+	ctx := context.Background()
+	url := fx.ContainerConnStr(t)
+
+	// Store a batch as it was stored before terms of phrases
+	require.NoError(t, migrateTo(ctx, url, 7))
+
+	conn, err := pgx.Connect(ctx, url)
+	require.NoError(t, err)
+	defer conn.Close(ctx)
+
+	q := New(conn)
+	_, err = q.CreateWordsBatch(ctx, CreateWordsBatchParams{
+		Name:    "other.txt",
+		Column2: []string{"go"},
+	})
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, `
+		WITH batch AS (
+			INSERT INTO phrase_batches (name) VALUES ($1) RETURNING id
+		)
+		INSERT INTO phrases (value, batch_id)
+		SELECT phrase, (SELECT id FROM batch) FROM UNNEST($2::text []) AS phrase`,
+		"before.png", []string{"Go, Kafka.", "C++ nice"},
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, applyMigrations(ctx, url))
+
+	rows, err := q.ListTfidfRankings(ctx, ListTfidfRankingsParams{
+		Column1: "before.png",
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, row.Value)
+	}
+	require.ElementsMatch(t, []string{"go", "kafka", "c++", "nice"}, values)
+
+	// Backfilled terms have no section
+	rankings, err := q.ListWeightedRankings(ctx, ListWeightedRankingsParams{
+		Column1: "before.png",
+		Column2: []string{"", "requirements"},
+		Column3: []float64{0.6, 1},
+		Column4: []string{"kafka", "c++"},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, rankings, 2)
+	require.Equal(t, "c++", rankings[0].Value)
+	require.InDelta(t, 0.6, rankings[0].Score, 1e-9)
+	require.Equal(t, "kafka", rankings[1].Value)
+	require.InDelta(t, 0.6, rankings[1].Score, 1e-9)
+}
+
 func applyMigrations(ctx context.Context, url string) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -246,6 +755,23 @@ func applyMigrations(ctx context.Context, url string) error {
 	return nil
 }
 
+// migrateTo migrates the database up or down to version.
+func migrateTo(ctx context.Context, url string, version uint) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	m, err := migrate.New("file://"+filepath.Join("testdata/migrations"), url)
+	if err != nil {
+		return fmt.Errorf("new migrate: %w", err)
+	}
+
+	if err := m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("migrate to %d: %w", version, err)
+	}
+
+	return nil
+}
+
 func testWords(t *testing.T) []string {
 	// Fill the database with words
 	data, err := os.ReadFile(filepath.Join("testdata", "words.txt"))
@@ -8,12 +8,55 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Experience struct {
+	ID        int64              `json:"id"`
+	Term      pgtype.Text        `json:"term"`
+	MinYears  int32              `json:"min_years"`
+	MaxYears  pgtype.Int4        `json:"max_years"`
+	Source    string             `json:"source"`
+	BatchID   pgtype.Int8        `json:"batch_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type LanguageRequirement struct {
+	ID          int64              `json:"id"`
+	Language    string             `json:"language"`
+	Proficiency string             `json:"proficiency"`
+	Source      string             `json:"source"`
+	BatchID     pgtype.Int8        `json:"batch_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type Ngram struct {
+	ID        int64              `json:"id"`
+	Value     string             `json:"value"`
+	N         int32              `json:"n"`
+	Count     int32              `json:"count"`
+	Score     float64            `json:"score"`
+	BatchID   pgtype.Int8        `json:"batch_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type NgramBatch struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Measure   string             `json:"measure"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
 type Phrase struct {
 	ID        int64              `json:"id"`
 	Value     string             `json:"value"`
 	BatchID   pgtype.Int8        `json:"batch_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	Source    pgtype.Text        `json:"source"`
+	Language  pgtype.Text        `json:"language"`
+	Section   pgtype.Text        `json:"section"`
 }
 
 type PhraseBatch struct {
@@ -23,12 +66,62 @@ type PhraseBatch struct {
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
+type PhraseBatchAttribute struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Value     string             `json:"value"`
+	BatchID   int64              `json:"batch_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type PhraseTerm struct {
+	ID        int64              `json:"id"`
+	Term      string             `json:"term"`
+	Section   pgtype.Text        `json:"section"`
+	BatchID   pgtype.Int8        `json:"batch_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type Salary struct {
+	ID        int64              `json:"id"`
+	MinAmount float64            `json:"min_amount"`
+	MaxAmount float64            `json:"max_amount"`
+	Currency  string             `json:"currency"`
+	Period    string             `json:"period"`
+	Contract  pgtype.Text        `json:"contract"`
+	Source    string             `json:"source"`
+	BatchID   pgtype.Int8        `json:"batch_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type SkillTerm struct {
+	ID        int64              `json:"id"`
+	Term      string             `json:"term"`
+	Category  pgtype.Text        `json:"category"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type TechnologyVersion struct {
+	ID        int64              `json:"id"`
+	Term      string             `json:"term"`
+	Version   string             `json:"version"`
+	Source    string             `json:"source"`
+	BatchID   pgtype.Int8        `json:"batch_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
 type Word struct {
 	ID        int64              `json:"id"`
 	Value     string             `json:"value"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 	BatchID   pgtype.Int8        `json:"batch_id"`
+	Source    pgtype.Text        `json:"source"`
 }
 
 type WordBatch struct {
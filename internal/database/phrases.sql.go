@@ -18,28 +18,325 @@ WITH batch AS (
     RETURNING id
 )
 
-INSERT INTO phrases (value, batch_id)
+INSERT INTO phrases (value, language, section, batch_id)
 SELECT
-    phrase_value,
+    phrase.value,
+    NULLIF(phrase.language, ''),
+    NULLIF(phrase.section, ''),
     (SELECT id FROM batch)
-FROM UNNEST($2::text []) AS phrase_value
-RETURNING id, value, batch_id
+FROM UNNEST($2::text [], $3::text [], $4::text []) AS phrase (value, language, section)
+RETURNING id, value, language, section, batch_id
 `
 
 type CreatePhrasesBatchParams struct {
 	Name    string   `json:"name"`
 	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+	Column4 []string `json:"column_4"`
 }
 
 type CreatePhrasesBatchRow struct {
-	ID      int64       `json:"id"`
-	Value   string      `json:"value"`
-	BatchID pgtype.Int8 `json:"batch_id"`
+	ID       int64       `json:"id"`
+	Value    string      `json:"value"`
+	Language pgtype.Text `json:"language"`
+	Section  pgtype.Text `json:"section"`
+	BatchID  pgtype.Int8 `json:"batch_id"`
 }
 
 func (q *Queries) CreatePhrasesBatch(ctx context.Context, arg CreatePhrasesBatchParams) (CreatePhrasesBatchRow, error) {
-	row := q.db.QueryRow(ctx, createPhrasesBatch, arg.Name, arg.Column2)
+	row := q.db.QueryRow(ctx, createPhrasesBatch,
+		arg.Name,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+	)
 	var i CreatePhrasesBatchRow
-	err := row.Scan(&i.ID, &i.Value, &i.BatchID)
+	err := row.Scan(
+		&i.ID,
+		&i.Value,
+		&i.Language,
+		&i.Section,
+		&i.BatchID,
+	)
 	return i, err
 }
+
+const createPhrasesBatchWithSources = `-- name: CreatePhrasesBatchWithSources :one
+WITH batch AS (
+    INSERT INTO phrase_batches (name)
+    VALUES ($1)
+    RETURNING id
+)
+
+INSERT INTO phrases (value, source, language, section, batch_id)
+SELECT
+    phrase.value,
+    phrase.source,
+    NULLIF(phrase.language, ''),
+    NULLIF(phrase.section, ''),
+    (SELECT id FROM batch)
+FROM UNNEST(
+    $2::text [], $3::text [], $4::text [], $5::text []
+) AS phrase (value, source, language, section)
+RETURNING id, value, source, language, section, batch_id
+`
+
+type CreatePhrasesBatchWithSourcesParams struct {
+	Name    string   `json:"name"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+	Column4 []string `json:"column_4"`
+	Column5 []string `json:"column_5"`
+}
+
+type CreatePhrasesBatchWithSourcesRow struct {
+	ID       int64       `json:"id"`
+	Value    string      `json:"value"`
+	Source   pgtype.Text `json:"source"`
+	Language pgtype.Text `json:"language"`
+	Section  pgtype.Text `json:"section"`
+	BatchID  pgtype.Int8 `json:"batch_id"`
+}
+
+func (q *Queries) CreatePhrasesBatchWithSources(ctx context.Context, arg CreatePhrasesBatchWithSourcesParams) (CreatePhrasesBatchWithSourcesRow, error) {
+	row := q.db.QueryRow(ctx, createPhrasesBatchWithSources,
+		arg.Name,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+	)
+	var i CreatePhrasesBatchWithSourcesRow
+	err := row.Scan(
+		&i.ID,
+		&i.Value,
+		&i.Source,
+		&i.Language,
+		&i.Section,
+		&i.BatchID,
+	)
+	return i, err
+}
+
+const createPhraseTerms = `-- name: CreatePhraseTerms :exec
+INSERT INTO phrase_terms (term, section, batch_id)
+SELECT
+    phrase_term.term,
+    NULLIF(phrase_term.section, ''),
+    $1::bigint
+FROM UNNEST($2::text [], $3::text []) AS phrase_term (term, section)
+`
+
+type CreatePhraseTermsParams struct {
+	Column1 int64    `json:"column_1"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+}
+
+func (q *Queries) CreatePhraseTerms(ctx context.Context, arg CreatePhraseTermsParams) error {
+	_, err := q.db.Exec(ctx, createPhraseTerms, arg.Column1, arg.Column2, arg.Column3)
+	return err
+}
+
+const listPhrases = `-- name: ListPhrases :many
+SELECT
+    phrases.id,
+    phrases.value,
+    phrases.source,
+    phrases.language,
+    phrases.section,
+    phrase_batches.name AS batch_name
+FROM phrases
+INNER JOIN phrase_batches ON phrases.batch_id = phrase_batches.id
+WHERE
+    phrases.deleted_at IS NULL
+    AND phrase_batches.deleted_at IS NULL
+    AND ($1::text = '' OR phrase_batches.name = $1)
+    AND ($2::text = '' OR phrases.language = $2)
+    AND ($3::text = '' OR phrases.section = $3)
+ORDER BY phrases.id
+LIMIT $4 OFFSET $5
+`
+
+type ListPhrasesParams struct {
+	Column1 string `json:"column_1"`
+	Column2 string `json:"column_2"`
+	Column3 string `json:"column_3"`
+	Limit   int32  `json:"limit"`
+	Offset  int32  `json:"offset"`
+}
+
+type ListPhrasesRow struct {
+	ID        int64       `json:"id"`
+	Value     string      `json:"value"`
+	Source    pgtype.Text `json:"source"`
+	Language  pgtype.Text `json:"language"`
+	Section   pgtype.Text `json:"section"`
+	BatchName string      `json:"batch_name"`
+}
+
+func (q *Queries) ListPhrases(ctx context.Context, arg ListPhrasesParams) ([]ListPhrasesRow, error) {
+	rows, err := q.db.Query(ctx, listPhrases,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPhrasesRow
+	for rows.Next() {
+		var i ListPhrasesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Value,
+			&i.Source,
+			&i.Language,
+			&i.Section,
+			&i.BatchName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeightedRankings = `-- name: ListWeightedRankings :many
+WITH weights AS (
+    SELECT
+        weight.section,
+        weight.value
+    FROM UNNEST($2::text [], $3::float8 []) AS weight (section, value)
+),
+
+wanted AS (
+    SELECT
+        attribute.name,
+        attribute.value
+    FROM UNNEST($7::text [], $8::text []) AS attribute (name, value)
+),
+
+mentions AS (
+    SELECT
+        mention.form,
+        mention.idx
+    FROM UNNEST($9::text [], $10::integer []) AS mention (form, idx)
+),
+
+terms AS (
+    SELECT
+        phrase_terms.batch_id,
+        phrase_terms.term,
+        COALESCE(phrase_terms.section, '') AS section
+    FROM phrase_terms
+    INNER JOIN phrase_batches ON phrase_terms.batch_id = phrase_batches.id
+    WHERE
+        phrase_terms.deleted_at IS NULL
+        AND phrase_batches.deleted_at IS NULL
+        AND ($1::text = '' OR phrase_batches.name = $1)
+        AND NOT EXISTS (
+            SELECT 1 FROM wanted
+            WHERE NOT EXISTS (
+                SELECT 1 FROM phrase_batch_attributes
+                WHERE
+                    phrase_batch_attributes.batch_id = phrase_terms.batch_id
+                    AND phrase_batch_attributes.name = wanted.name
+                    AND phrase_batch_attributes.value = wanted.value
+                    AND phrase_batch_attributes.deleted_at IS NULL
+            )
+        )
+),
+
+batches AS (
+    SELECT terms.batch_id
+    FROM terms
+    INNER JOIN mentions ON terms.term = mentions.form
+    GROUP BY terms.batch_id
+    HAVING
+        COUNT(DISTINCT mentions.idx)
+        = (SELECT COUNT(DISTINCT mentions.idx) FROM mentions)
+)
+
+SELECT
+    terms.term AS value,
+    SUM(weights.value)::float8 AS score,
+    COUNT(*) AS total,
+    RANK() OVER (ORDER BY SUM(weights.value) DESC) AS ranking
+FROM terms
+INNER JOIN weights ON terms.section = weights.section
+WHERE
+    (
+        COALESCE(CARDINALITY($4::text []), 0) = 0
+        OR terms.term = ANY($4::text [])
+    )
+    AND (
+        COALESCE(CARDINALITY($9::text []), 0) = 0
+        OR terms.batch_id IN (SELECT batches.batch_id FROM batches)
+    )
+GROUP BY terms.term
+ORDER BY score DESC, value
+LIMIT $5 OFFSET $6
+`
+
+type ListWeightedRankingsParams struct {
+	Column1  string    `json:"column_1"`
+	Column2  []string  `json:"column_2"`
+	Column3  []float64 `json:"column_3"`
+	Column4  []string  `json:"column_4"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+	Column7  []string  `json:"column_7"`
+	Column8  []string  `json:"column_8"`
+	Column9  []string  `json:"column_9"`
+	Column10 []int32   `json:"column_10"`
+}
+
+type ListWeightedRankingsRow struct {
+	Value   string  `json:"value"`
+	Score   float64 `json:"score"`
+	Total   int64   `json:"total"`
+	Ranking int64   `json:"ranking"`
+}
+
+func (q *Queries) ListWeightedRankings(ctx context.Context, arg ListWeightedRankingsParams) ([]ListWeightedRankingsRow, error) {
+	rows, err := q.db.Query(ctx, listWeightedRankings,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Limit,
+		arg.Offset,
+		arg.Column7,
+		arg.Column8,
+		arg.Column9,
+		arg.Column10,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeightedRankingsRow
+	for rows.Next() {
+		var i ListWeightedRankingsRow
+		if err := rows.Scan(
+			&i.Value,
+			&i.Score,
+			&i.Total,
+			&i.Ranking,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
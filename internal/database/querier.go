@@ -9,14 +9,35 @@ import (
 )
 
 type Querier interface {
+	CreateBatchAttributes(ctx context.Context, arg CreateBatchAttributesParams) ([]CreateBatchAttributesRow, error)
+	CreateExperiences(ctx context.Context, arg CreateExperiencesParams) ([]CreateExperiencesRow, error)
+	CreateLanguageRequirements(ctx context.Context, arg CreateLanguageRequirementsParams) ([]CreateLanguageRequirementsRow, error)
+	CreateNgramsBatch(ctx context.Context, arg CreateNgramsBatchParams) (CreateNgramsBatchRow, error)
+	CreatePhraseTerms(ctx context.Context, arg CreatePhraseTermsParams) error
 	CreatePhrasesBatch(ctx context.Context, arg CreatePhrasesBatchParams) (CreatePhrasesBatchRow, error)
+	CreatePhrasesBatchWithSources(ctx context.Context, arg CreatePhrasesBatchWithSourcesParams) (CreatePhrasesBatchWithSourcesRow, error)
+	CreateSalaries(ctx context.Context, arg CreateSalariesParams) ([]CreateSalariesRow, error)
+	CreateTechnologyVersions(ctx context.Context, arg CreateTechnologyVersionsParams) ([]CreateTechnologyVersionsRow, error)
 	CreateWord(ctx context.Context, value string) (CreateWordRow, error)
 	CreateWordsBatch(ctx context.Context, arg CreateWordsBatchParams) (CreateWordsBatchRow, error)
+	CreateWordsBatchWithSources(ctx context.Context, arg CreateWordsBatchWithSourcesParams) (CreateWordsBatchWithSourcesRow, error)
+	DeleteSkillTerm(ctx context.Context, term string) (int64, error)
+	ListBatchAttributes(ctx context.Context, arg ListBatchAttributesParams) ([]ListBatchAttributesRow, error)
+	ListExperienceDistribution(ctx context.Context, arg ListExperienceDistributionParams) ([]ListExperienceDistributionRow, error)
+	ListLanguageRequirementSummary(ctx context.Context, arg ListLanguageRequirementSummaryParams) ([]ListLanguageRequirementSummaryRow, error)
+	ListNgramRankings(ctx context.Context, arg ListNgramRankingsParams) ([]ListNgramRankingsRow, error)
+	ListPhrases(ctx context.Context, arg ListPhrasesParams) ([]ListPhrasesRow, error)
+	ListSalaryStats(ctx context.Context, arg ListSalaryStatsParams) ([]ListSalaryStatsRow, error)
+	ListSkillTerms(ctx context.Context) ([]ListSkillTermsRow, error)
+	ListTfidfRankings(ctx context.Context, arg ListTfidfRankingsParams) ([]ListTfidfRankingsRow, error)
+	ListVersionDistribution(ctx context.Context, arg ListVersionDistributionParams) ([]ListVersionDistributionRow, error)
+	ListWeightedRankings(ctx context.Context, arg ListWeightedRankingsParams) ([]ListWeightedRankingsRow, error)
 	ListWordBatches(ctx context.Context, arg ListWordBatchesParams) ([]ListWordBatchesRow, error)
 	ListWordFrequencies(ctx context.Context, arg ListWordFrequenciesParams) ([]ListWordFrequenciesRow, error)
 	ListWordRankings(ctx context.Context, arg ListWordRankingsParams) ([]ListWordRankingsRow, error)
 	ListWords(ctx context.Context, arg ListWordsParams) ([]ListWordsRow, error)
 	ListWordsByBatchName(ctx context.Context, name string) ([]ListWordsByBatchNameRow, error)
+	UpsertSkillTerm(ctx context.Context, arg UpsertSkillTermParams) (UpsertSkillTermRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
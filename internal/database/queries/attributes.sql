@@ -0,0 +1,29 @@
+-- name: CreateBatchAttributes :many
+INSERT INTO phrase_batch_attributes (name, value, batch_id)
+SELECT
+    attribute.name,
+    attribute.value,
+    $1::bigint
+FROM UNNEST($2::text [], $3::text []) AS attribute (name, value)
+ON CONFLICT (batch_id, name, value) DO NOTHING
+RETURNING id, name, value, batch_id;
+
+-- name: ListBatchAttributes :many
+SELECT
+    phrase_batch_attributes.name,
+    phrase_batch_attributes.value,
+    COUNT(DISTINCT phrase_batch_attributes.batch_id) AS total
+FROM phrase_batch_attributes
+INNER JOIN
+    phrase_batches
+    ON phrase_batch_attributes.batch_id = phrase_batches.id
+WHERE
+    phrase_batch_attributes.deleted_at IS NULL
+    AND phrase_batches.deleted_at IS NULL
+    AND ($1::text = '' OR phrase_batches.name = $1)
+    AND ($2::text = '' OR phrase_batch_attributes.name = $2)
+GROUP BY phrase_batch_attributes.name, phrase_batch_attributes.value
+ORDER BY
+    phrase_batch_attributes.name ASC,
+    total DESC,
+    phrase_batch_attributes.value ASC;
@@ -0,0 +1,34 @@
+-- name: CreateExperiences :many
+INSERT INTO experiences (term, min_years, max_years, source, batch_id)
+SELECT
+    NULLIF(experience.term, ''),
+    experience.min_years,
+    NULLIF(experience.max_years, 0),
+    experience.source,
+    $1::bigint
+FROM UNNEST(
+    $2::text [],
+    $3::integer [],
+    $4::integer [],
+    $5::text []
+) AS experience (term, min_years, max_years, source)
+RETURNING id, term, min_years, max_years, source, batch_id;
+
+-- name: ListExperienceDistribution :many
+SELECT
+    experiences.term::text AS term,
+    experiences.min_years,
+    COUNT(*) AS total
+FROM experiences
+INNER JOIN phrase_batches ON experiences.batch_id = phrase_batches.id
+WHERE
+    experiences.deleted_at IS NULL
+    AND phrase_batches.deleted_at IS NULL
+    AND experiences.term IS NOT NULL
+    AND ($1::text = '' OR phrase_batches.name = $1)
+    AND (
+        COALESCE(CARDINALITY($2::text []), 0) = 0
+        OR experiences.term = ANY($2::text [])
+    )
+GROUP BY experiences.term, experiences.min_years
+ORDER BY experiences.term ASC, experiences.min_years ASC;
@@ -0,0 +1,39 @@
+-- name: CreateLanguageRequirements :many
+INSERT INTO language_requirements (language, proficiency, source, batch_id)
+SELECT
+    requirement.language,
+    requirement.proficiency,
+    requirement.source,
+    $1::bigint
+FROM UNNEST(
+    $2::text [], $3::text [], $4::text []
+) AS requirement (language, proficiency, source)
+RETURNING id, language, proficiency, source, batch_id;
+
+-- name: ListLanguageRequirementSummary :many
+-- Batches are counted once per language at their highest required level,
+-- levels are ordered from A1 to native.
+WITH required AS (
+    SELECT
+        language_requirements.language,
+        language_requirements.batch_id,
+        MAX(language_requirements.proficiency) AS proficiency
+    FROM language_requirements
+    INNER JOIN
+        phrase_batches
+        ON language_requirements.batch_id = phrase_batches.id
+    WHERE
+        language_requirements.deleted_at IS NULL
+        AND phrase_batches.deleted_at IS NULL
+        AND ($1::text = '' OR phrase_batches.name = $1)
+        AND ($2::text = '' OR language_requirements.language = $2)
+    GROUP BY language_requirements.language, language_requirements.batch_id
+)
+
+SELECT
+    required.language,
+    required.proficiency::text AS proficiency,
+    COUNT(*) AS total
+FROM required
+GROUP BY required.language, required.proficiency
+ORDER BY required.language ASC, required.proficiency ASC;
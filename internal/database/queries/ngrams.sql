@@ -0,0 +1,33 @@
+-- name: CreateNgramsBatch :one
+WITH new_batch AS (
+    INSERT INTO ngram_batches (name, measure)
+    VALUES ($1, $2)
+    RETURNING id
+)
+
+INSERT INTO ngrams (value, n, count, score, batch_id)
+SELECT
+    ngram.value,
+    ngram.n,
+    ngram.count,
+    ngram.score,
+    (SELECT id FROM new_batch)
+FROM UNNEST(
+    $3::text [], $4::integer [], $5::integer [], $6::float8 []
+) AS ngram (value, n, count, score)
+RETURNING id, value, n, count, score, batch_id;
+
+-- name: ListNgramRankings :many
+SELECT
+    ngrams.value,
+    ngrams.n,
+    SUM(ngrams.count)::bigint AS total,
+    ROW_NUMBER() OVER (ORDER BY SUM(ngrams.count) DESC, ngrams.value ASC) AS ranking
+FROM ngrams
+WHERE
+    ngrams.deleted_at IS NULL
+    AND ($1::integer = 0 OR ngrams.n = $1)
+    AND (COALESCE(CARDINALITY($4::text []), 0) = 0 OR ngrams.value = ANY($4::text []))
+GROUP BY ngrams.value, ngrams.n
+ORDER BY ranking ASC
+LIMIT $2 OFFSET $3;
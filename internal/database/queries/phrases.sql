@@ -5,9 +5,133 @@ WITH batch AS (
     RETURNING id
 )
 
-INSERT INTO phrases (value, batch_id)
+INSERT INTO phrases (value, language, section, batch_id)
 SELECT
-    phrase_value,
+    phrase.value,
+    NULLIF(phrase.language, ''),
+    NULLIF(phrase.section, ''),
     (SELECT id FROM batch)
-FROM UNNEST($2::text []) AS phrase_value
-RETURNING id, value, batch_id;
+FROM UNNEST($2::text [], $3::text [], $4::text []) AS phrase (value, language, section)
+RETURNING id, value, language, section, batch_id;
+
+-- name: CreatePhrasesBatchWithSources :one
+WITH batch AS (
+    INSERT INTO phrase_batches (name)
+    VALUES ($1)
+    RETURNING id
+)
+
+INSERT INTO phrases (value, source, language, section, batch_id)
+SELECT
+    phrase.value,
+    phrase.source,
+    NULLIF(phrase.language, ''),
+    NULLIF(phrase.section, ''),
+    (SELECT id FROM batch)
+FROM UNNEST(
+    $2::text [], $3::text [], $4::text [], $5::text []
+) AS phrase (value, source, language, section)
+RETURNING id, value, source, language, section, batch_id;
+
+-- name: CreatePhraseTerms :exec
+INSERT INTO phrase_terms (term, section, batch_id)
+SELECT
+    phrase_term.term,
+    NULLIF(phrase_term.section, ''),
+    $1::bigint
+FROM UNNEST($2::text [], $3::text []) AS phrase_term (term, section);
+
+-- name: ListPhrases :many
+SELECT
+    phrases.id,
+    phrases.value,
+    phrases.source,
+    phrases.language,
+    phrases.section,
+    phrase_batches.name AS batch_name
+FROM phrases
+INNER JOIN phrase_batches ON phrases.batch_id = phrase_batches.id
+WHERE
+    phrases.deleted_at IS NULL
+    AND phrase_batches.deleted_at IS NULL
+    AND ($1::text = '' OR phrase_batches.name = $1)
+    AND ($2::text = '' OR phrases.language = $2)
+    AND ($3::text = '' OR phrases.section = $3)
+ORDER BY phrases.id
+LIMIT $4 OFFSET $5;
+
+-- name: ListWeightedRankings :many
+WITH weights AS (
+    SELECT
+        weight.section,
+        weight.value
+    FROM UNNEST($2::text [], $3::float8 []) AS weight (section, value)
+),
+
+wanted AS (
+    SELECT
+        attribute.name,
+        attribute.value
+    FROM UNNEST($7::text [], $8::text []) AS attribute (name, value)
+),
+
+mentions AS (
+    SELECT
+        mention.form,
+        mention.idx
+    FROM UNNEST($9::text [], $10::integer []) AS mention (form, idx)
+),
+
+terms AS (
+    SELECT
+        phrase_terms.batch_id,
+        phrase_terms.term,
+        COALESCE(phrase_terms.section, '') AS section
+    FROM phrase_terms
+    INNER JOIN phrase_batches ON phrase_terms.batch_id = phrase_batches.id
+    WHERE
+        phrase_terms.deleted_at IS NULL
+        AND phrase_batches.deleted_at IS NULL
+        AND ($1::text = '' OR phrase_batches.name = $1)
+        AND NOT EXISTS (
+            SELECT 1 FROM wanted
+            WHERE NOT EXISTS (
+                SELECT 1 FROM phrase_batch_attributes
+                WHERE
+                    phrase_batch_attributes.batch_id = phrase_terms.batch_id
+                    AND phrase_batch_attributes.name = wanted.name
+                    AND phrase_batch_attributes.value = wanted.value
+                    AND phrase_batch_attributes.deleted_at IS NULL
+            )
+        )
+),
+
+batches AS (
+    SELECT terms.batch_id
+    FROM terms
+    INNER JOIN mentions ON terms.term = mentions.form
+    GROUP BY terms.batch_id
+    HAVING
+        COUNT(DISTINCT mentions.idx)
+        = (SELECT COUNT(DISTINCT mentions.idx) FROM mentions)
+)
+
+SELECT
+    terms.term AS value,
+    SUM(weights.value)::float8 AS score,
+    COUNT(*) AS total,
+    RANK() OVER (ORDER BY SUM(weights.value) DESC) AS ranking
+FROM terms
+INNER JOIN weights ON terms.section = weights.section
+WHERE
+    (
+        COALESCE(CARDINALITY($4::text []), 0) = 0
+        OR terms.term = ANY($4::text [])
+    )
+    AND (
+        COALESCE(CARDINALITY($9::text []), 0) = 0
+        OR terms.batch_id IN (SELECT batches.batch_id FROM batches)
+    )
+GROUP BY terms.term
+ORDER BY score DESC, value
+LIMIT $5 OFFSET $6;
@@ -0,0 +1,63 @@
+-- name: CreateSalaries :many
+INSERT INTO salaries (
+    min_amount, max_amount, currency, period, contract, source, batch_id
+)
+SELECT
+    salary.min_amount,
+    salary.max_amount,
+    salary.currency,
+    salary.period,
+    NULLIF(salary.contract, ''),
+    salary.source,
+    $1::bigint
+FROM UNNEST(
+    $2::float8 [],
+    $3::float8 [],
+    $4::text [],
+    $5::text [],
+    $6::text [],
+    $7::text []
+) AS salary (min_amount, max_amount, currency, period, contract, source)
+RETURNING
+    id, min_amount, max_amount, currency, period, contract, source, batch_id;
+
+-- name: ListSalaryStats :many
+-- Amounts are normalized to a month, a month has 168 working hours.
+WITH monthly AS (
+    SELECT
+        salaries.currency,
+        COALESCE(salaries.contract, '') AS contract,
+        salaries.min_amount * CASE salaries.period
+            WHEN 'hour' THEN 168
+            WHEN 'year' THEN 1.0 / 12
+            ELSE 1
+        END AS min_amount,
+        salaries.max_amount * CASE salaries.period
+            WHEN 'hour' THEN 168
+            WHEN 'year' THEN 1.0 / 12
+            ELSE 1
+        END AS max_amount
+    FROM salaries
+    INNER JOIN phrase_batches ON salaries.batch_id = phrase_batches.id
+    WHERE
+        salaries.deleted_at IS NULL
+        AND phrase_batches.deleted_at IS NULL
+        AND ($1::text = '' OR phrase_batches.name = $1)
+        AND ($2::text = '' OR salaries.currency = $2)
+        AND ($3::text = '' OR salaries.contract = $3)
+)
+
+SELECT
+    monthly.currency,
+    monthly.contract,
+    COUNT(*) AS total,
+    MIN(monthly.min_amount)::float8 AS min_amount,
+    MAX(monthly.max_amount)::float8 AS max_amount,
+    AVG(monthly.min_amount)::float8 AS avg_min_amount,
+    AVG(monthly.max_amount)::float8 AS avg_max_amount,
+    PERCENTILE_CONT(0.5) WITHIN GROUP (
+        ORDER BY (monthly.min_amount + monthly.max_amount) / 2
+    )::float8 AS median_amount
+FROM monthly
+GROUP BY monthly.currency, monthly.contract
+ORDER BY total DESC, monthly.currency ASC, monthly.contract ASC;
@@ -0,0 +1,24 @@
+-- name: ListSkillTerms :many
+SELECT
+    id,
+    term,
+    category,
+    created_at
+FROM skill_terms
+WHERE deleted_at IS NULL
+ORDER BY term ASC;
+
+-- name: UpsertSkillTerm :one
+INSERT INTO skill_terms (term, category)
+VALUES ($1, $2)
+ON CONFLICT (term) DO UPDATE
+    SET
+        category = excluded.category,
+        created_at = CURRENT_TIMESTAMP,
+        deleted_at = NULL
+RETURNING id, term, category, created_at;
+
+-- name: DeleteSkillTerm :execrows
+UPDATE skill_terms
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE term = $1 AND deleted_at IS NULL;
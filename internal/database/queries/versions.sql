@@ -0,0 +1,34 @@
+-- name: CreateTechnologyVersions :many
+INSERT INTO technology_versions (term, version, source, batch_id)
+SELECT
+    technology_version.term,
+    technology_version.version,
+    technology_version.source,
+    $1::bigint
+FROM UNNEST(
+    $2::text [], $3::text [], $4::text []
+) AS technology_version (term, version, source)
+RETURNING id, term, version, source, batch_id;
+
+-- name: ListVersionDistribution :many
+-- Versions are numbers separated by dots, so the latest of equally asked
+-- versions of a term is first.
+SELECT
+    technology_versions.term,
+    technology_versions.version,
+    COUNT(*) AS total
+FROM technology_versions
+INNER JOIN phrase_batches ON technology_versions.batch_id = phrase_batches.id
+WHERE
+    technology_versions.deleted_at IS NULL
+    AND phrase_batches.deleted_at IS NULL
+    AND ($1::text = '' OR phrase_batches.name = $1)
+    AND (
+        COALESCE(CARDINALITY($2::text []), 0) = 0
+        OR technology_versions.term = ANY($2::text [])
+    )
+GROUP BY technology_versions.term, technology_versions.version
+ORDER BY
+    technology_versions.term ASC,
+    total DESC,
+    STRING_TO_ARRAY(technology_versions.version, '.')::integer [] DESC;
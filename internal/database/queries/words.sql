@@ -28,7 +28,9 @@ SELECT
     words.value,
     ROW_NUMBER() OVER (ORDER BY COUNT(*) DESC) AS ranking
 FROM words
-WHERE words.deleted_at IS NULL
+WHERE
+    words.deleted_at IS NULL
+    AND (COALESCE(CARDINALITY($3::text []), 0) = 0 OR words.value = ANY($3::text []))
 GROUP BY words.value
 ORDER BY ranking ASC
 LIMIT $1 OFFSET $2;
@@ -57,6 +59,21 @@ SELECT
 FROM UNNEST($2::text []) AS word_value
 RETURNING id, value, batch_id;
 
+-- name: CreateWordsBatchWithSources :one
+WITH new_batch AS (
+    INSERT INTO word_batches (name)
+    VALUES ($1)
+    RETURNING id
+)
+
+INSERT INTO words (value, source, batch_id)
+SELECT
+    word.value,
+    word.source,
+    (SELECT id FROM new_batch)
+FROM UNNEST($2::text [], $3::text []) AS word (value, source)
+RETURNING id, value, source, batch_id;
+
 -- name: ListWordsByBatchName :many
 SELECT
     wb.name AS batch_name,
@@ -65,3 +82,77 @@ FROM word_batches AS wb
 INNER JOIN words AS w ON wb.id = w.id
 WHERE wb.name = $1 AND wb.deleted_at IS NULL
 ORDER BY wb.created_at DESC;
+
+-- name: ListTfidfRankings :many
+WITH documents AS (
+    SELECT
+        'words:' || word_batches.id AS id,
+        word_batches.name,
+        words.value AS term
+    FROM words
+    INNER JOIN word_batches ON words.batch_id = word_batches.id
+    WHERE words.deleted_at IS NULL AND word_batches.deleted_at IS NULL
+    UNION ALL
+    SELECT
+        'phrases:' || phrase_batches.id AS id,
+        phrase_batches.name,
+        phrase_terms.term
+    FROM phrase_terms
+    INNER JOIN phrase_batches ON phrase_terms.batch_id = phrase_batches.id
+    WHERE phrase_terms.deleted_at IS NULL AND phrase_batches.deleted_at IS NULL
+),
+
+term_counts AS (
+    SELECT
+        id,
+        name,
+        term,
+        COUNT(*) AS count
+    FROM documents
+    GROUP BY id, name, term
+),
+
+document_sizes AS (
+    SELECT
+        id,
+        SUM(count) AS size
+    FROM term_counts
+    GROUP BY id
+),
+
+document_frequencies AS (
+    SELECT
+        term,
+        COUNT(*) AS df
+    FROM term_counts
+    GROUP BY term
+),
+
+selected_documents AS (
+    SELECT DISTINCT id
+    FROM term_counts
+    WHERE $1::text = '' OR name = $1
+),
+
+scores AS (
+    SELECT
+        tc.term,
+        SUM(
+            tc.count::float8 / ds.size::float8
+            * LN((1 + (SELECT COUNT(*) FROM document_sizes))::float8 / (1 + df.df))
+        ) / (SELECT COUNT(*) FROM selected_documents) AS score
+    FROM term_counts AS tc
+    INNER JOIN selected_documents AS sd ON tc.id = sd.id
+    INNER JOIN document_sizes AS ds ON tc.id = ds.id
+    INNER JOIN document_frequencies AS df ON tc.term = df.term
+    GROUP BY tc.term
+)
+
+SELECT
+    term AS value,
+    score::float8 AS score,
+    ROW_NUMBER() OVER (ORDER BY score DESC, term ASC) AS ranking
+FROM scores
+WHERE COALESCE(CARDINALITY($4::text []), 0) = 0 OR term = ANY($4::text [])
+ORDER BY ranking ASC
+LIMIT $2 OFFSET $3;
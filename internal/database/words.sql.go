@@ -63,6 +63,160 @@ func (q *Queries) CreateWordsBatch(ctx context.Context, arg CreateWordsBatchPara
 	return i, err
 }
 
+const createWordsBatchWithSources = `-- name: CreateWordsBatchWithSources :one
+WITH new_batch AS (
+    INSERT INTO word_batches (name)
+    VALUES ($1)
+    RETURNING id
+)
+
+INSERT INTO words (value, source, batch_id)
+SELECT
+    word.value,
+    word.source,
+    (SELECT id FROM new_batch)
+FROM UNNEST($2::text [], $3::text []) AS word (value, source)
+RETURNING id, value, source, batch_id
+`
+
+type CreateWordsBatchWithSourcesParams struct {
+	Name    string   `json:"name"`
+	Column2 []string `json:"column_2"`
+	Column3 []string `json:"column_3"`
+}
+
+type CreateWordsBatchWithSourcesRow struct {
+	ID      int64       `json:"id"`
+	Value   string      `json:"value"`
+	Source  pgtype.Text `json:"source"`
+	BatchID pgtype.Int8 `json:"batch_id"`
+}
+
+func (q *Queries) CreateWordsBatchWithSources(ctx context.Context, arg CreateWordsBatchWithSourcesParams) (CreateWordsBatchWithSourcesRow, error) {
+	row := q.db.QueryRow(ctx, createWordsBatchWithSources, arg.Name, arg.Column2, arg.Column3)
+	var i CreateWordsBatchWithSourcesRow
+	err := row.Scan(
+		&i.ID,
+		&i.Value,
+		&i.Source,
+		&i.BatchID,
+	)
+	return i, err
+}
+
+const listTfidfRankings = `-- name: ListTfidfRankings :many
+WITH documents AS (
+    SELECT
+        'words:' || word_batches.id AS id,
+        word_batches.name,
+        words.value AS term
+    FROM words
+    INNER JOIN word_batches ON words.batch_id = word_batches.id
+    WHERE words.deleted_at IS NULL AND word_batches.deleted_at IS NULL
+    UNION ALL
+    SELECT
+        'phrases:' || phrase_batches.id AS id,
+        phrase_batches.name,
+        phrase_terms.term
+    FROM phrase_terms
+    INNER JOIN phrase_batches ON phrase_terms.batch_id = phrase_batches.id
+    WHERE phrase_terms.deleted_at IS NULL AND phrase_batches.deleted_at IS NULL
+),
+
+term_counts AS (
+    SELECT
+        id,
+        name,
+        term,
+        COUNT(*) AS count
+    FROM documents
+    GROUP BY id, name, term
+),
+
+document_sizes AS (
+    SELECT
+        id,
+        SUM(count) AS size
+    FROM term_counts
+    GROUP BY id
+),
+
+document_frequencies AS (
+    SELECT
+        term,
+        COUNT(*) AS df
+    FROM term_counts
+    GROUP BY term
+),
+
+selected_documents AS (
+    SELECT DISTINCT id
+    FROM term_counts
+    WHERE $1::text = '' OR name = $1
+),
+
+scores AS (
+    SELECT
+        tc.term,
+        SUM(
+            tc.count::float8 / ds.size::float8
+            * LN((1 + (SELECT COUNT(*) FROM document_sizes))::float8 / (1 + df.df))
+        ) / (SELECT COUNT(*) FROM selected_documents) AS score
+    FROM term_counts AS tc
+    INNER JOIN selected_documents AS sd ON tc.id = sd.id
+    INNER JOIN document_sizes AS ds ON tc.id = ds.id
+    INNER JOIN document_frequencies AS df ON tc.term = df.term
+    GROUP BY tc.term
+)
+
+SELECT
+    term AS value,
+    score::float8 AS score,
+    ROW_NUMBER() OVER (ORDER BY score DESC, term ASC) AS ranking
+FROM scores
+WHERE COALESCE(CARDINALITY($4::text []), 0) = 0 OR term = ANY($4::text [])
+ORDER BY ranking ASC
+LIMIT $2 OFFSET $3
+`
+
+type ListTfidfRankingsParams struct {
+	Column1 string   `json:"column_1"`
+	Limit   int32    `json:"limit"`
+	Offset  int32    `json:"offset"`
+	Column4 []string `json:"column_4"`
+}
+
+type ListTfidfRankingsRow struct {
+	Value   string  `json:"value"`
+	Score   float64 `json:"score"`
+	Ranking int64   `json:"ranking"`
+}
+
+func (q *Queries) ListTfidfRankings(ctx context.Context, arg ListTfidfRankingsParams) ([]ListTfidfRankingsRow, error) {
+	rows, err := q.db.Query(ctx, listTfidfRankings,
+		arg.Column1,
+		arg.Limit,
+		arg.Offset,
+		arg.Column4,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTfidfRankingsRow
+	for rows.Next() {
+		var i ListTfidfRankingsRow
+		if err := rows.Scan(&i.Value, &i.Score, &i.Ranking); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listWordBatches = `-- name: ListWordBatches :many
 SELECT
     id,
@@ -151,15 +305,18 @@ SELECT
     words.value,
     ROW_NUMBER() OVER (ORDER BY COUNT(*) DESC) AS ranking
 FROM words
-WHERE words.deleted_at IS NULL
+WHERE
+    words.deleted_at IS NULL
+    AND (COALESCE(CARDINALITY($3::text []), 0) = 0 OR words.value = ANY($3::text []))
 GROUP BY words.value
 ORDER BY ranking ASC
 LIMIT $1 OFFSET $2
 `
 
 type ListWordRankingsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Limit   int32    `json:"limit"`
+	Offset  int32    `json:"offset"`
+	Column3 []string `json:"column_3"`
 }
 
 type ListWordRankingsRow struct {
@@ -168,7 +325,7 @@ type ListWordRankingsRow struct {
 }
 
 func (q *Queries) ListWordRankings(ctx context.Context, arg ListWordRankingsParams) ([]ListWordRankingsRow, error) {
-	rows, err := q.db.Query(ctx, listWordRankings, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listWordRankings, arg.Limit, arg.Offset, arg.Column3)
 	if err != nil {
 		return nil, err
 	}
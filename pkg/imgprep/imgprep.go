@@ -0,0 +1,190 @@
+// Package imgprep prepares screenshots for text recognition.
+//
+// Images are decoded with the standard image packages, processed by the
+// selected steps and encoded back as PNG.
+package imgprep
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"  // Register decoder
+	_ "image/jpeg" // Register decoder
+	"image/png"
+	"strings"
+
+	_ "golang.org/x/image/bmp"  // Register decoder
+	_ "golang.org/x/image/tiff" // Register decoder
+	_ "golang.org/x/image/webp" // Register decoder
+)
+
+// Steps is a set of processing steps applied to an image.
+type Steps uint8
+
+const (
+	// Grayscale converts image to shades of gray. Every other step
+	// works on a grayscale image so it's implied by them.
+	Grayscale Steps = 1 << iota
+	// Invert makes light text on dark background (dark mode) dark on light.
+	// Images which already have light background are left untouched.
+	Invert
+	// Upscale enlarges small images so characters are big enough for ocr.
+	Upscale
+	// Binarize converts image to black and white with Otsu's threshold.
+	Binarize
+	// Deskew rotates image so lines of text are horizontal.
+	Deskew
+)
+
+// None is an empty set of steps.
+const None Steps = 0
+
+// All contains every processing step.
+const All = Grayscale | Invert | Upscale | Binarize | Deskew
+
+var stepNames = []struct {
+	step Steps
+	name string
+}{
+	{Grayscale, "grayscale"},
+	{Invert, "invert"},
+	{Upscale, "upscale"},
+	{Binarize, "binarize"},
+	{Deskew, "deskew"},
+}
+
+var ErrUnknownStep = errors.New("unknown step")
+
+// ParseSteps parses comma separated step names, e.g. "grayscale,binarize".
+// "all" selects every step, empty string selects none.
+func ParseSteps(s string) (Steps, error) {
+	var steps Steps
+
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if name == "all" {
+			steps |= All
+
+			continue
+		}
+
+		found := false
+		for _, sn := range stepNames {
+			if sn.name == name {
+				steps |= sn.step
+				found = true
+
+				break
+			}
+		}
+		if !found {
+			return None, fmt.Errorf("%w: %s", ErrUnknownStep, name)
+		}
+	}
+
+	return steps, nil
+}
+
+// Has reports whether every step of other is in s.
+func (s Steps) Has(other Steps) bool {
+	return s&other == other
+}
+
+func (s Steps) String() string {
+	names := make([]string, 0, len(stepNames))
+	for _, sn := range stepNames {
+		if s.Has(sn.step) {
+			names = append(names, sn.name)
+		}
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Process decodes content (PNG, JPEG, GIF, BMP, TIFF or WebP), applies steps in a fixed order
+// (grayscale, invert, upscale, binarize, deskew) and returns the image
+// encoded as PNG. Content is returned unchanged when no step is selected.
+func Process(content []byte, steps Steps) ([]byte, error) {
+	if steps == None {
+		return content, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, Apply(img, steps)); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var ErrEmptyRegion = errors.New("region is outside of image")
+
+// Crop decodes content once and returns every region of the image encoded as
+// PNG. Regions are relative to the top left corner of the image and are clipped
+// to its bounds, ErrEmptyRegion is returned if nothing is left of a region.
+func Crop(content []byte, regions ...image.Rectangle) ([][]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	bounds := img.Bounds()
+
+	crops := make([][]byte, 0, len(regions))
+	for _, r := range regions {
+		r = r.Add(bounds.Min).Intersect(bounds)
+		if r.Empty() {
+			return nil, fmt.Errorf("%w: %v", ErrEmptyRegion, r)
+		}
+
+		buf := new(bytes.Buffer)
+		if err := png.Encode(buf, subImage(img, r)); err != nil {
+			return nil, fmt.Errorf("encode: %w", err)
+		}
+		crops = append(crops, buf.Bytes())
+	}
+
+	return crops, nil
+}
+
+func subImage(img image.Image, r image.Rectangle) image.Image {
+	if img, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return img.SubImage(r)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, r.Min, draw.Src)
+
+	return dst
+}
+
+// Apply runs steps on a decoded image and returns a grayscale result.
+func Apply(img image.Image, steps Steps) *image.Gray {
+	g := ToGray(img)
+
+	if steps.Has(Invert) && IsDark(g) {
+		InvertGray(g)
+	}
+	if steps.Has(Upscale) {
+		g = Scale(g, upscaleFactor(g.Bounds()))
+	}
+	if steps.Has(Binarize) {
+		Threshold(g, Otsu(g))
+	}
+	if steps.Has(Deskew) {
+		g = Rotate(g, -SkewAngle(g))
+	}
+
+	return g
+}
@@ -0,0 +1,309 @@
+package imgprep_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/imgprep"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/tiff"
+)
+
+func TestParseSteps(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		in      string
+		want    imgprep.Steps
+		wantErr bool
+	}{
+		{
+			desc: "empty_is_none",
+
+			in:   "",
+			want: imgprep.None,
+		},
+		{
+			desc: "many_steps",
+
+			in:   "grayscale, Binarize,deskew",
+			want: imgprep.Grayscale | imgprep.Binarize | imgprep.Deskew,
+		},
+		{
+			desc: "all_steps",
+
+			in:   "all",
+			want: imgprep.All,
+		},
+		{
+			desc: "unknown_step_fails",
+
+			in:      "grayscale,sharpen",
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			steps, err := imgprep.ParseSteps(tC.in)
+			if tC.wantErr {
+				require.ErrorIs(t, err, imgprep.ErrUnknownStep)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tC.want, steps)
+		})
+	}
+}
+
+func TestStepsString(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "grayscale,invert,upscale,binarize,deskew", imgprep.All.String())
+	require.Equal(t, "invert,deskew", (imgprep.Invert | imgprep.Deskew).String())
+	require.Empty(t, imgprep.None.String())
+}
+
+func TestProcessWithoutStepsReturnsContent(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("not even an image")
+
+	out, err := imgprep.Process(content, imgprep.None)
+	require.NoError(t, err)
+	require.Equal(t, content, out)
+}
+
+func TestProcess(t *testing.T) {
+	t.Parallel()
+
+	pngContent, err := os.ReadFile(filepath.Join("testdata", "0.png"))
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(pngContent))
+	require.NoError(t, err)
+	jpgBuf := new(bytes.Buffer)
+	require.NoError(t, jpeg.Encode(jpgBuf, img, nil))
+	gifBuf := new(bytes.Buffer)
+	require.NoError(t, gif.Encode(gifBuf, img, nil))
+	bmpBuf := new(bytes.Buffer)
+	require.NoError(t, bmp.Encode(bmpBuf, img))
+	tiffBuf := new(bytes.Buffer)
+	require.NoError(t, tiff.Encode(tiffBuf, img, nil))
+
+	testCases := []struct {
+		desc string
+
+		content []byte
+	}{
+		{
+			desc:    "png",
+			content: pngContent,
+		},
+		{
+			desc:    "jpeg",
+			content: jpgBuf.Bytes(),
+		},
+		{
+			desc:    "gif",
+			content: gifBuf.Bytes(),
+		},
+		{
+			desc:    "bmp",
+			content: bmpBuf.Bytes(),
+		},
+		{
+			desc:    "tiff",
+			content: tiffBuf.Bytes(),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := imgprep.Process(tC.content, imgprep.Grayscale|imgprep.Binarize)
+			require.NoError(t, err)
+
+			res, err := png.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			require.Equal(t, img.Bounds().Size(), res.Bounds().Size())
+
+			// Only black and white pixels are left
+			g, ok := res.(*image.Gray)
+			require.True(t, ok)
+			for _, p := range g.Pix {
+				require.True(t, p == 0 || p == 255)
+			}
+		})
+	}
+}
+
+func TestProcessInvalidImage(t *testing.T) {
+	t.Parallel()
+
+	_, err := imgprep.Process([]byte("not an image"), imgprep.Grayscale)
+	require.Error(t, err)
+}
+
+func TestCrop(t *testing.T) {
+	t.Parallel()
+
+	src := image.NewGray(image.Rect(0, 0, 100, 50))
+	src.SetGray(60, 30, color.Gray{Y: 200})
+	buf := new(bytes.Buffer)
+	require.NoError(t, png.Encode(buf, src))
+
+	testCases := []struct {
+		desc string
+
+		regions  []image.Rectangle
+		wantSize []image.Point
+		wantErr  error
+	}{
+		{
+			desc: "many_regions",
+
+			regions:  []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(50, 20, 70, 40)},
+			wantSize: []image.Point{{10, 10}, {20, 20}},
+		},
+		{
+			desc: "clipped_to_bounds",
+
+			regions:  []image.Rectangle{image.Rect(90, 40, 200, 200)},
+			wantSize: []image.Point{{10, 10}},
+		},
+		{
+			desc: "outside_of_image",
+
+			regions: []image.Rectangle{image.Rect(200, 200, 300, 300)},
+			wantErr: imgprep.ErrEmptyRegion,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			crops, err := imgprep.Crop(buf.Bytes(), tC.regions...)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, crops, len(tC.wantSize))
+
+			for i, crop := range crops {
+				img, err := png.Decode(bytes.NewReader(crop))
+				require.NoError(t, err)
+				require.Equal(t, tC.wantSize[i], img.Bounds().Size())
+			}
+		})
+	}
+
+	// Cropped pixels keep their values
+	crops, err := imgprep.Crop(buf.Bytes(), image.Rect(50, 20, 70, 40))
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(crops[0]))
+	require.NoError(t, err)
+	r, _, _, _ := img.At(img.Bounds().Min.X+10, img.Bounds().Min.Y+10).RGBA()
+	require.Equal(t, uint32(200)<<8|200, r)
+}
+
+// newTextImage draws horizontal dark lines imitating text on background,
+// lines are skewed by angle in degrees.
+func newTextImage(w, h int, bg, fg uint8, angle float64) *image.Gray {
+	g := image.NewGray(image.Rect(0, 0, w, h))
+	for i := range g.Pix {
+		g.Pix[i] = bg
+	}
+
+	slope := math.Tan(angle * math.Pi / 180)
+	for y0 := 20; y0 < h-20; y0 += 20 {
+		for x := 10; x < w-10; x++ {
+			y := y0 + int(math.Round(float64(x)*slope))
+			for dy := range 3 {
+				if image.Pt(x, y+dy).In(g.Bounds()) {
+					g.SetGray(x, y+dy, color.Gray{Y: fg})
+				}
+			}
+		}
+	}
+
+	return g
+}
+
+func TestInvertDarkMode(t *testing.T) {
+	t.Parallel()
+
+	dark := newTextImage(100, 100, 20, 230, 0)
+	require.True(t, imgprep.IsDark(dark))
+
+	g := imgprep.Apply(dark, imgprep.Invert)
+	require.False(t, imgprep.IsDark(g))
+	require.Equal(t, uint8(235), g.GrayAt(0, 0).Y)
+
+	// Light images stay the same
+	light := newTextImage(100, 100, 230, 20, 0)
+	g = imgprep.Apply(light, imgprep.Invert)
+	require.Equal(t, light.Pix, g.Pix)
+}
+
+func TestUpscale(t *testing.T) {
+	t.Parallel()
+
+	small := newTextImage(300, 100, 255, 0, 0)
+	g := imgprep.Apply(small, imgprep.Upscale)
+	require.Equal(t, image.Pt(1200, 400), g.Bounds().Size())
+
+	big := newTextImage(1300, 100, 255, 0, 0)
+	g = imgprep.Apply(big, imgprep.Upscale)
+	require.Equal(t, big.Bounds().Size(), g.Bounds().Size())
+}
+
+func TestOtsu(t *testing.T) {
+	t.Parallel()
+
+	g := newTextImage(100, 100, 200, 60, 0)
+
+	th := imgprep.Otsu(g)
+	require.GreaterOrEqual(t, th, uint8(60))
+	require.Less(t, th, uint8(200))
+}
+
+func TestDeskew(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		angle float64
+	}{
+		{desc: "skewed_down", angle: 4},
+		{desc: "skewed_up", angle: -3},
+		{desc: "straight", angle: 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			g := newTextImage(300, 200, 255, 0, tC.angle)
+			require.InDelta(t, tC.angle, imgprep.SkewAngle(g), 0.5)
+
+			deskewed := imgprep.Apply(g, imgprep.Deskew)
+			require.InDelta(t, 0, imgprep.SkewAngle(deskewed), 0.5)
+		})
+	}
+}
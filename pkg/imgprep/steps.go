@@ -0,0 +1,219 @@
+package imgprep
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// ToGray converts img to grayscale with bounds starting at (0, 0).
+func ToGray(img image.Image) *image.Gray {
+	b := img.Bounds()
+	g := image.NewGray(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(g, g.Bounds(), img, b.Min, draw.Src)
+
+	return g
+}
+
+// IsDark reports whether g has a dark background, which is assumed when
+// the majority of pixels are darker than middle gray.
+func IsDark(g *image.Gray) bool {
+	dark := 0
+	for _, p := range g.Pix {
+		if p < 128 {
+			dark++
+		}
+	}
+
+	return dark*2 > len(g.Pix)
+}
+
+// InvertGray inverts every pixel of g in place.
+func InvertGray(g *image.Gray) {
+	for i, p := range g.Pix {
+		g.Pix[i] = 255 - p
+	}
+}
+
+// Minimum width below which images are upscaled and maximum scale factor.
+const (
+	minWidth  = 1200
+	maxFactor = 4
+)
+
+func upscaleFactor(b image.Rectangle) float64 {
+	if b.Dx() == 0 || b.Dx() >= minWidth {
+		return 1
+	}
+
+	return math.Min(math.Ceil(float64(minWidth)/float64(b.Dx())), maxFactor)
+}
+
+// Scale resizes g by factor with bilinear interpolation.
+// Factor lower or equal to 1 returns g unchanged.
+func Scale(g *image.Gray, factor float64) *image.Gray {
+	if factor <= 1 {
+		return g
+	}
+
+	sw, sh := g.Bounds().Dx(), g.Bounds().Dy()
+	dw, dh := int(float64(sw)*factor), int(float64(sh)*factor)
+	dst := image.NewGray(image.Rect(0, 0, dw, dh))
+
+	for y := range dh {
+		sy := math.Min((float64(y)+0.5)/factor-0.5, float64(sh-1))
+		sy = math.Max(sy, 0)
+		y0 := int(sy)
+		y1 := min(y0+1, sh-1)
+		fy := sy - float64(y0)
+
+		for x := range dw {
+			sx := math.Min((float64(x)+0.5)/factor-0.5, float64(sw-1))
+			sx = math.Max(sx, 0)
+			x0 := int(sx)
+			x1 := min(x0+1, sw-1)
+			fx := sx - float64(x0)
+
+			top := float64(g.GrayAt(x0, y0).Y)*(1-fx) + float64(g.GrayAt(x1, y0).Y)*fx
+			bottom := float64(g.GrayAt(x0, y1).Y)*(1-fx) + float64(g.GrayAt(x1, y1).Y)*fx
+			dst.SetGray(x, y, color.Gray{Y: uint8(math.Round(top*(1-fy) + bottom*fy))})
+		}
+	}
+
+	return dst
+}
+
+// Otsu returns a threshold which best separates dark and light pixels of g.
+func Otsu(g *image.Gray) uint8 {
+	var hist [256]int
+	for _, p := range g.Pix {
+		hist[p]++
+	}
+
+	total := len(g.Pix)
+	var sum float64
+	for i, n := range hist {
+		sum += float64(i * n)
+	}
+
+	var (
+		sumB, best float64
+		weightB    int
+		threshold  uint8
+	)
+	for i, n := range hist {
+		weightB += n
+		if weightB == 0 {
+			continue
+		}
+		weightF := total - weightB
+		if weightF == 0 {
+			break
+		}
+		sumB += float64(i * n)
+
+		meanB := sumB / float64(weightB)
+		meanF := (sum - sumB) / float64(weightF)
+		between := float64(weightB) * float64(weightF) * (meanB - meanF) * (meanB - meanF)
+		if between > best {
+			best = between
+			threshold = uint8(i)
+		}
+	}
+
+	return threshold
+}
+
+// Threshold turns pixels of g lighter than t white and the rest black.
+func Threshold(g *image.Gray, t uint8) {
+	for i, p := range g.Pix {
+		if p > t {
+			g.Pix[i] = 255
+		} else {
+			g.Pix[i] = 0
+		}
+	}
+}
+
+// Range and precision of skew angle detection in degrees.
+const (
+	maxSkew  = 10.0
+	skewStep = 0.5
+)
+
+// SkewAngle estimates rotation of text lines in g in degrees, positive
+// angle means lines go down to the right. It picks the angle for which
+// projection of dark pixels on the vertical axis has the sharpest profile.
+func SkewAngle(g *image.Gray) float64 {
+	b := g.Bounds()
+
+	type point struct{ x, y float64 }
+	dark := make([]point, 0)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if g.GrayAt(x, y).Y < 128 {
+				dark = append(dark, point{float64(x), float64(y)})
+			}
+		}
+	}
+	if len(dark) == 0 {
+		return 0
+	}
+
+	diag := int(math.Hypot(float64(b.Dx()), float64(b.Dy()))) + 1
+	rows := make([]int, 2*diag+1)
+
+	var bestAngle, bestScore float64
+	for angle := -maxSkew; angle <= maxSkew; angle += skewStep {
+		clear(rows)
+
+		sin, cos := math.Sincos(angle * math.Pi / 180)
+		for _, p := range dark {
+			r := int(math.Round(p.y*cos-p.x*sin)) + diag
+			rows[r]++
+		}
+
+		var score float64
+		for _, n := range rows {
+			score += float64(n * n)
+		}
+		if score > bestScore {
+			bestScore = score
+			bestAngle = angle
+		}
+	}
+
+	return bestAngle
+}
+
+// Rotate returns g rotated around its center by angle in degrees,
+// uncovered area is filled with white.
+func Rotate(g *image.Gray, angle float64) *image.Gray {
+	if angle == 0 {
+		return g
+	}
+
+	b := g.Bounds()
+	dst := image.NewGray(b)
+	for i := range dst.Pix {
+		dst.Pix[i] = 255
+	}
+
+	cx, cy := float64(b.Dx())/2, float64(b.Dy())/2
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			// Inverse mapping from destination to source pixel
+			dx, dy := float64(x)-cx, float64(y)-cy
+			sx := int(math.Round(dx*cos + dy*sin + cx))
+			sy := int(math.Round(-dx*sin + dy*cos + cy))
+			if image.Pt(sx, sy).In(b) {
+				dst.SetGray(x, y, g.GrayAt(sx, sy))
+			}
+		}
+	}
+
+	return dst
+}
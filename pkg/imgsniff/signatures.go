@@ -6,71 +6,166 @@ import (
 	"unicode"
 )
 
-type signature []byte
+// Format is an image format recognized by its content.
+type Format string
+
+const (
+	Unknown Format = ""
+	JPEG    Format = "jpeg"
+	PNG     Format = "png"
+	GIF     Format = "gif"
+	BMP     Format = "bmp"
+	TIFF    Format = "tiff"
+	WebP    Format = "webp"
+)
+
+// MIMEType returns media type of the format, empty for Unknown.
+func (f Format) MIMEType() string {
+	if f == Unknown {
+		return ""
+	}
 
-type signatures struct {
-	m  map[string]signature
-	mu sync.Mutex
+	return "image/" + string(f)
 }
 
-func Signatures() *signatures {
-	return &signatures{
-		m: map[string]signature{
-			"png": {137, 80, 78, 71, 13, 10, 26, 10},
-			"jpg": {0xFF, 0x4F, 0xFF, 0x51},
-		},
+// Matcher reports whether data begins with a format signature.
+// Matchers must not modify data and must handle data of any length.
+type Matcher func(data []byte) bool
+
+// Signature returns a matcher of data beginning with sig. Zero bytes of
+// mask (if not nil) mark positions of sig which match any byte.
+func Signature(sig, mask []byte) Matcher {
+	return func(data []byte) bool {
+		if len(data) < len(sig) {
+			return false
+		}
+		if mask == nil {
+			return bytes.Equal(data[:len(sig)], sig)
+		}
+		for i, b := range sig {
+			if i < len(mask) && mask[i] == 0 {
+				continue
+			}
+			if data[i] != b {
+				return false
+			}
+		}
+
+		return true
 	}
 }
 
-func (s *signatures) png() signature {
-	if s == nil {
-		s = Signatures()
-	}
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// Registry holds matchers of image formats checked in registration order.
+// It's goroutine safe.
+type Registry struct {
+	mu       sync.RWMutex
+	formats  []Format
+	matchers []Matcher
+}
 
-	return s.m["png"]
+// NewRegistry returns a registry without any formats.
+func NewRegistry() *Registry {
+	return &Registry{}
 }
 
-func IsPNG(data []byte) bool {
-	if data == nil {
-		return false
+// Register adds a matcher for format f. Registering the same format again
+// adds another matcher for it.
+func (r *Registry) Register(f Format, m Matcher) {
+	if f == Unknown {
+		panic("format cannot be unknown")
+	}
+	if m == nil {
+		panic("matcher cannot be nil")
 	}
 
-	idx := firstNonWSIndex(data)
-	sigs := Signatures()
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	return bytes.Equal(data[idx:idx+8], sigs.png())
+	r.formats = append(r.formats, f)
+	r.matchers = append(r.matchers, m)
 }
 
-func firstNonWSIndex(data []byte) int {
-	if data == nil {
-		return 0
-	}
-	idx := 0 // Index of first non-whitespace byte in data
-	for ; idx < len(data) && unicode.IsSpace(rune(data[idx])); idx++ {
+// Sniff returns format of data, leading whitespace is skipped.
+// Unknown is returned if no registered format matches.
+func (r *Registry) Sniff(data []byte) Format {
+	data = data[firstNonWSIndex(data):]
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i, match := range r.matchers {
+		if match(data) {
+			return r.formats[i]
+		}
 	}
 
-	return idx
+	return Unknown
 }
 
-func (s *signatures) jpg() signature {
-	if s == nil {
-		s = Signatures()
+// Formats returns registered formats without duplicates.
+func (r *Registry) Formats() []Format {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	formats := make([]Format, 0, len(r.formats))
+	seen := make(map[Format]bool)
+	for _, f := range r.formats {
+		if !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
 	}
-	s.mu.Lock()
-	defer s.mu.Unlock()
 
-	return s.m["jpg"]
+	return formats
+}
+
+var defaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	r.Register(JPEG, Signature([]byte{0xFF, 0xD8, 0xFF}, nil))
+	r.Register(PNG, Signature([]byte{137, 80, 78, 71, 13, 10, 26, 10}, nil))
+	r.Register(GIF, Signature([]byte("GIF87a"), nil))
+	r.Register(GIF, Signature([]byte("GIF89a"), nil))
+	r.Register(BMP, Signature([]byte("BM"), nil))
+	r.Register(TIFF, Signature([]byte{'I', 'I', 42, 0}, nil)) // Little endian
+	r.Register(TIFF, Signature([]byte{'M', 'M', 0, 42}, nil)) // Big endian
+	r.Register(WebP, Signature(
+		[]byte("RIFF\x00\x00\x00\x00WEBP"),
+		[]byte{1, 1, 1, 1, 0, 0, 0, 0, 1, 1, 1, 1}, // Skip file size
+	))
+
+	return r
+}
+
+// Register adds a matcher for format f to the default registry.
+func Register(f Format, m Matcher) {
+	defaultRegistry.Register(f, m)
+}
+
+// Sniff returns format of data using the default registry.
+func Sniff(data []byte) Format {
+	return defaultRegistry.Sniff(data)
+}
+
+// IsImage reports whether data is in any format of the default registry.
+func IsImage(data []byte) bool {
+	return Sniff(data) != Unknown
+}
+
+func IsPNG(data []byte) bool {
+	return Sniff(data) == PNG
 }
 
 func IsJPG(data []byte) bool {
-	if data == nil {
-		return false
-	}
+	return Sniff(data) == JPEG
+}
 
-	idx := firstNonWSIndex(data)
-	sigs := Signatures()
+func firstNonWSIndex(data []byte) int {
+	idx := 0 // Index of first non-whitespace byte in data
+	for ; idx < len(data) && unicode.IsSpace(rune(data[idx])); idx++ {
+	}
 
-	return bytes.Equal(data[idx:idx+4], sigs.jpg())
+	return idx
 }
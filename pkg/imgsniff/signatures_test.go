@@ -1,6 +1,11 @@
 package imgsniff
 
 import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
 	"slices"
 	"testing"
 
@@ -42,6 +47,24 @@ func TestPNG(t *testing.T) {
 			data: []byte{138, 10, 20, 30, 30, 20, 32, 15}, // Some random numbers
 			want: false,
 		},
+		{
+			desc: "truncated_signature",
+
+			data: []byte{137, 80, 78},
+			want: false,
+		},
+		{
+			desc: "only_whitespace",
+
+			data: []byte("   "),
+			want: false,
+		},
+		{
+			desc: "nil",
+
+			data: nil,
+			want: false,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -89,7 +112,7 @@ func TestFirstNonWSIdx(t *testing.T) {
 func TestIsJPG(t *testing.T) {
 	t.Parallel()
 
-	signature := []byte{0xFF, 0x4F, 0xFF, 0x51}
+	signature := []byte{0xFF, 0xD8, 0xFF, 0xE0}
 
 	testCases := []struct {
 		desc string
@@ -100,9 +123,21 @@ func TestIsJPG(t *testing.T) {
 		{
 			desc: "normal_jpg",
 
-			data: []byte{0xFF, 0x4F, 0xFF, 0x51},
+			data: []byte{0xFF, 0xD8, 0xFF, 0xE0},
 			want: true,
 		},
+		{
+			desc: "jpeg_2000_codestream_is_not_jpg",
+
+			data: []byte{0xFF, 0x4F, 0xFF, 0x51},
+			want: false,
+		},
+		{
+			desc: "too_short",
+
+			data: []byte{0xFF, 0xD8},
+			want: false,
+		},
 		{
 			desc: "first_is_whitespace_still_jpg",
 
@@ -131,3 +166,142 @@ func TestIsJPG(t *testing.T) {
 		})
 	}
 }
+
+func encodeTestImage(t *testing.T, encode func(buf *bytes.Buffer, img image.Image) error) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, encode(buf, image.NewGray(image.Rect(0, 0, 4, 4))))
+
+	return buf.Bytes()
+}
+
+func TestSniff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		data []byte
+		want Format
+	}{
+		{
+			desc: "encoded_jpeg",
+			data: encodeTestImage(t, func(buf *bytes.Buffer, img image.Image) error {
+				return jpeg.Encode(buf, img, nil)
+			}),
+			want: JPEG,
+		},
+		{
+			desc: "encoded_png",
+			data: encodeTestImage(t, func(buf *bytes.Buffer, img image.Image) error {
+				return png.Encode(buf, img)
+			}),
+			want: PNG,
+		},
+		{
+			desc: "encoded_gif",
+			data: encodeTestImage(t, func(buf *bytes.Buffer, img image.Image) error {
+				return gif.Encode(buf, img, nil)
+			}),
+			want: GIF,
+		},
+		{
+			desc: "bmp",
+			data: []byte("BM\x36\x00\x00\x00"),
+			want: BMP,
+		},
+		{
+			desc: "tiff_little_endian",
+			data: []byte{'I', 'I', 42, 0, 8, 0, 0, 0},
+			want: TIFF,
+		},
+		{
+			desc: "tiff_big_endian",
+			data: []byte{'M', 'M', 0, 42, 0, 0, 0, 8},
+			want: TIFF,
+		},
+		{
+			desc: "webp",
+			data: []byte("RIFF\x24\x10\x00\x00WEBPVP8 "),
+			want: WebP,
+		},
+		{
+			desc: "riff_but_not_webp",
+			data: []byte("RIFF\x24\x10\x00\x00WAVEfmt "),
+			want: Unknown,
+		},
+		{
+			desc: "text",
+			data: []byte("some text"),
+			want: Unknown,
+		},
+		{
+			desc: "empty",
+			data: []byte{},
+			want: Unknown,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tC.want, Sniff(tC.data))
+			require.Equal(t, tC.want != Unknown, IsImage(tC.data))
+		})
+	}
+}
+
+func TestFormatMIMEType(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "image/jpeg", JPEG.MIMEType())
+	require.Equal(t, "image/webp", WebP.MIMEType())
+	require.Empty(t, Unknown.MIMEType())
+}
+
+func TestRegistryRegister(t *testing.T) {
+	t.Parallel()
+
+	const JXL Format = "jxl"
+
+	r := NewRegistry()
+	require.Equal(t, Unknown, r.Sniff([]byte{0xFF, 0x0A}))
+
+	r.Register(JXL, Signature([]byte{0xFF, 0x0A}, nil))
+	r.Register(JXL, Signature([]byte{0, 0, 0, 0x0C, 'J', 'X', 'L', ' '}, nil))
+
+	require.Equal(t, JXL, r.Sniff([]byte{0xFF, 0x0A, 0x01}))
+	require.Equal(t, JXL, r.Sniff([]byte{0, 0, 0, 0x0C, 'J', 'X', 'L', ' ', 0x0D}))
+	require.Equal(t, []Format{JXL}, r.Formats())
+
+	// Default registry is not affected
+	require.Equal(t, Unknown, Sniff([]byte{0xFF, 0x0A, 0x01}))
+}
+
+func FuzzSniff(f *testing.F) {
+	f.Add([]byte{0xFF, 0xD8, 0xFF})
+	f.Add([]byte{137, 80, 78, 71, 13, 10, 26, 10})
+	f.Add([]byte("GIF89a"))
+	f.Add([]byte("BM"))
+	f.Add([]byte{'I', 'I', 42, 0})
+	f.Add([]byte("RIFF\x00\x00\x00\x00WEBP"))
+	f.Add([]byte("  \n"))
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		format := Sniff(data)
+
+		if format == Unknown {
+			require.False(t, IsImage(data))
+
+			return
+		}
+		require.True(t, IsImage(data))
+		require.Contains(t, defaultRegistry.Formats(), format)
+
+		// Sniffing must not depend on bytes after the signature
+		padded := slices.Concat(data, []byte{0, 1, 2, 3})
+		require.Equal(t, format, Sniff(padded))
+	})
+}
@@ -0,0 +1,127 @@
+package ocr
+
+import (
+	"image"
+	"strings"
+)
+
+// WordBox is a word recognized in an image with its bounding box.
+type WordBox struct {
+	Text string          `json:"text"`
+	Box  image.Rectangle `json:"box"`
+	// Confidence of recognition in range 0-100.
+	Confidence float64 `json:"confidence"`
+	// Line is an index of the line the word belongs to.
+	Line int `json:"line"`
+}
+
+// LineBox is a line of words recognized in an image.
+type LineBox struct {
+	Text string          `json:"text"`
+	Box  image.Rectangle `json:"box"`
+	// Confidence is a mean confidence of words in the line.
+	Confidence float64 `json:"confidence"`
+}
+
+// WordBoxes returns recognized words in reading order.
+func (res *Result) WordBoxes() []WordBox {
+	if res == nil {
+		return nil
+	}
+
+	return res.words
+}
+
+// LineBoxes returns recognized lines in reading order.
+func (res *Result) LineBoxes() []LineBox {
+	if res == nil {
+		return nil
+	}
+
+	return res.lines
+}
+
+// groupLines joins words by their line index. Line indexes of words
+// must be non-decreasing, lines are numbered again from zero.
+func groupLines(words []WordBox) []LineBox {
+	lines := make([]LineBox, 0)
+
+	var (
+		texts []string
+		sum   float64
+		prev  int
+	)
+	flush := func() {
+		if len(texts) == 0 {
+			return
+		}
+		last := &lines[len(lines)-1]
+		last.Text = strings.Join(texts, " ")
+		last.Confidence = sum / float64(len(texts))
+		texts, sum = texts[:0], 0
+	}
+
+	for i := range words {
+		w := &words[i]
+		if len(lines) == 0 || w.Line != prev {
+			flush()
+			lines = append(lines, LineBox{Box: w.Box})
+			prev = w.Line
+		}
+		last := &lines[len(lines)-1]
+		last.Box = last.Box.Union(w.Box)
+		texts = append(texts, w.Text)
+		sum += w.Confidence
+		w.Line = len(lines) - 1
+	}
+	flush()
+
+	return lines
+}
+
+// dropBelow removes words recognized with confidence lower than threshold and
+// rebuilds text of the result from remaining lines. Results without words
+// are left untouched.
+func (res *Result) dropBelow(threshold float64) {
+	if threshold <= 0 || len(res.words) == 0 {
+		return
+	}
+
+	kept := make([]WordBox, 0, len(res.words))
+	for _, w := range res.words {
+		if w.Confidence >= threshold {
+			kept = append(kept, w)
+		}
+	}
+	res.words = kept
+	res.lines = groupLines(kept)
+
+	texts := make([]string, 0, len(res.lines))
+	for _, l := range res.lines {
+		texts = append(texts, l.Text)
+	}
+	res.text = strings.Join(texts, "\n")
+}
+
+// joinRegions joins results recognized in regions of an image. Boxes are
+// moved by the top left corner of their region, texts are separate lines.
+func joinRegions(results []*Result, regions []image.Rectangle) *Result {
+	texts := make([]string, 0, len(results))
+	words := make([]WordBox, 0)
+
+	lineOffset := 0
+	for i, res := range results {
+		texts = append(texts, res.text)
+
+		next := lineOffset
+		for _, w := range res.words {
+			w.Box = w.Box.Add(regions[i].Min)
+			w.Line += lineOffset - res.words[0].Line
+			next = max(next, w.Line+1)
+			words = append(words, w)
+		}
+		lineOffset = next
+	}
+
+	return NewResult(strings.Join(texts, "\n"), words...)
+}
@@ -0,0 +1,119 @@
+package ocr
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupLines(t *testing.T) {
+	t.Parallel()
+
+	words := []WordBox{
+		{Text: "senior", Box: image.Rect(0, 0, 10, 10), Confidence: 90, Line: 3},
+		{Text: "golang", Box: image.Rect(12, 0, 22, 12), Confidence: 70, Line: 3},
+		{Text: "developer", Box: image.Rect(0, 20, 30, 30), Confidence: 80, Line: 7},
+	}
+
+	lines := groupLines(words)
+	require.Len(t, lines, 2)
+
+	require.Equal(t, "senior golang", lines[0].Text)
+	require.Equal(t, image.Rect(0, 0, 22, 12), lines[0].Box)
+	require.InDelta(t, 80.0, lines[0].Confidence, 0.001)
+
+	require.Equal(t, "developer", lines[1].Text)
+	require.Equal(t, 1, words[2].Line)
+}
+
+func TestResultDropBelow(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		threshold float64
+		wantText  string
+		wantWords int
+	}{
+		{
+			desc: "zero_keeps_everything",
+
+			threshold: 0,
+			wantText:  "senior golang ~~\ndeveloper",
+			wantWords: 4,
+		},
+		{
+			desc: "drops_low_confidence_words",
+
+			threshold: 60,
+			wantText:  "senior golang\ndeveloper",
+			wantWords: 3,
+		},
+		{
+			desc: "drops_lines_without_words",
+
+			threshold: 85,
+			wantText:  "senior",
+			wantWords: 1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			res := NewResult("senior golang ~~\ndeveloper",
+				WordBox{Text: "senior", Confidence: 90, Line: 0},
+				WordBox{Text: "golang", Confidence: 70, Line: 0},
+				WordBox{Text: "~~", Confidence: 12, Line: 0},
+				WordBox{Text: "developer", Confidence: 80, Line: 1},
+			)
+			res.dropBelow(tC.threshold)
+
+			require.Equal(t, tC.wantText, res.Text())
+			require.Len(t, res.WordBoxes(), tC.wantWords)
+		})
+	}
+}
+
+func TestResultDropBelowWithoutWords(t *testing.T) {
+	t.Parallel()
+
+	res := NewResult("golang developer")
+	res.dropBelow(50)
+
+	require.Equal(t, "golang developer", res.Text())
+}
+
+func TestJoinRegions(t *testing.T) {
+	t.Parallel()
+
+	results := []*Result{
+		NewResult("senior golang\ndeveloper",
+			WordBox{Text: "senior", Box: image.Rect(0, 0, 10, 10), Confidence: 90, Line: 0},
+			WordBox{Text: "golang", Box: image.Rect(12, 0, 22, 10), Confidence: 90, Line: 0},
+			WordBox{Text: "developer", Box: image.Rect(0, 20, 30, 30), Confidence: 90, Line: 1},
+		),
+		NewResult(""), // Nothing recognized
+		NewResult("docker",
+			WordBox{Text: "docker", Box: image.Rect(0, 0, 10, 10), Confidence: 50, Line: 4},
+		),
+	}
+	regions := []image.Rectangle{
+		image.Rect(100, 200, 150, 250),
+		image.Rect(0, 0, 10, 10),
+		image.Rect(5, 5, 50, 50),
+	}
+
+	res := joinRegions(results, regions)
+	require.Equal(t, "senior golang\ndeveloper\n\ndocker", res.Text())
+
+	words := res.WordBoxes()
+	require.Len(t, words, 4)
+	require.Equal(t, image.Rect(100, 200, 110, 210), words[0].Box)
+	require.Equal(t, image.Rect(5, 5, 15, 15), words[3].Box)
+	require.Equal(t, 2, words[3].Line)
+
+	lines := res.LineBoxes()
+	require.Len(t, lines, 3)
+	require.Equal(t, "docker", lines[2].Text)
+}
@@ -0,0 +1,305 @@
+package ocr
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Cache stores recognition results by keys returned from CacheKey.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns a result stored under key, false if there is none.
+	Get(key string) (*Result, bool)
+	// Put stores a result under key.
+	Put(key string, res *Result) error
+}
+
+// cacheKeyVersion changes whenever cached results are not compatible
+// with the previous ones.
+const cacheKeyVersion = "v1"
+
+// CacheKey returns a hex encoded SHA-256 of image content and options
+// used to recognize it.
+func CacheKey(content []byte, opts Options) string {
+	h := sha256.New()
+	h.Write([]byte(cacheKeyVersion))
+	h.Write(content)
+	// Options only hold plain values, encoding can't fail
+	_ = json.NewEncoder(h).Encode(opts)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CacheStats counts lookups of a cache.
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Errors counts results which could not be stored.
+	Errors uint64
+}
+
+// CachedEngine is an engine whose results are looked up in a cache before
+// recognition. ScanFile, ScanFrom and ScanDir look up whole images, keyed
+// by their content and options, so cached results also skip preprocessing
+// and cropping, see ImageScanner.
+type CachedEngine struct {
+	Engine
+
+	cache Cache
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+}
+
+// NewCachedEngine returns e consulting c before recognition.
+func NewCachedEngine(e Engine, c Cache) *CachedEngine {
+	if e == nil {
+		panic("engine cannot be nil")
+	}
+	if c == nil {
+		panic("cache cannot be nil")
+	}
+
+	return &CachedEngine{
+		Engine: e,
+		cache:  c,
+	}
+}
+
+// Stats returns lookups counted since the engine was created.
+func (ce *CachedEngine) Stats() CacheStats {
+	return CacheStats{
+		Hits:   ce.hits.Load(),
+		Misses: ce.misses.Load(),
+		Errors: ce.errors.Load(),
+	}
+}
+
+var _ ImageScanner = (*CachedEngine)(nil)
+
+// ScanImage returns a cached result of a whole image, preprocessing and
+// recognizing it with the wrapped engine if there is none.
+func (ce *CachedEngine) ScanImage(ctx context.Context, content []byte, opts Options) (*Result, error) {
+	return ce.lookup(ctx, content, opts, func(ctx context.Context, content []byte, opts Options) (*Result, error) {
+		return recognize(ctx, ce.Engine, content, opts)
+	})
+}
+
+// Scan returns a cached result of content as passed, recognizing it with
+// the wrapped engine if there is none.
+func (ce *CachedEngine) Scan(ctx context.Context, content []byte, opts Options) (*Result, error) {
+	return ce.lookup(ctx, content, opts, ce.Engine.Scan)
+}
+
+// lookup returns a result of content stored in the cache, or recognizes it
+// with scan and stores it. Errors are not cached.
+func (ce *CachedEngine) lookup(ctx context.Context, content []byte, opts Options, scan func(context.Context, []byte, Options) (*Result, error)) (*Result, error) {
+	key := CacheKey(content, opts)
+
+	if res, ok := ce.cache.Get(key); ok {
+		ce.hits.Add(1)
+
+		return res, nil
+	}
+	ce.misses.Add(1)
+
+	res, err := scan(ctx, content, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := ce.cache.Put(key, res); err != nil {
+		ce.errors.Add(1)
+	}
+
+	return res, nil
+}
+
+// cachedResult is a result without data of a single scan.
+type cachedResult struct {
+	Text  string    `json:"text"`
+	Words []WordBox `json:"words"`
+}
+
+func newCachedResult(res *Result) cachedResult {
+	return cachedResult{
+		Text:  res.text,
+		Words: slices.Clone(res.words),
+	}
+}
+
+func (cr cachedResult) result() *Result {
+	return NewResult(cr.Text, slices.Clone(cr.Words)...)
+}
+
+// MemoryCache is a cache holding a limited number of the most recently
+// used results in memory.
+type MemoryCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List // Front is the most recently used
+	items map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key string
+	res cachedResult
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+// NewMemoryCache returns a cache holding up to size results, at least one.
+func NewMemoryCache(size int) *MemoryCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &MemoryCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *MemoryCache) Get(key string) (*Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+
+	return el.Value.(*memoryCacheItem).res.result(), true
+}
+
+func (c *MemoryCache) Put(key string, res *Result) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*memoryCacheItem).res = newCachedResult(res)
+		c.ll.MoveToFront(el)
+
+		return nil
+	}
+
+	c.items[key] = c.ll.PushFront(&memoryCacheItem{key, newCachedResult(res)})
+	for c.ll.Len() > c.size {
+		last := c.ll.Back()
+		c.ll.Remove(last)
+		delete(c.items, last.Value.(*memoryCacheItem).key)
+	}
+
+	return nil
+}
+
+// Len returns number of cached results.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// DiskCache is a cache storing results as JSON files in a directory.
+type DiskCache struct {
+	dir string
+}
+
+var _ Cache = (*DiskCache)(nil)
+
+// NewDiskCache returns a cache storing results in dir, dir is created
+// if it doesn't exist.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	dir = filepath.Clean(dir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("mkdir: %w", err)
+	}
+
+	return &DiskCache{dir: dir}, nil
+}
+
+// Dir returns directory of the cache.
+func (c *DiskCache) Dir() string {
+	return c.dir
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key[:min(2, len(key))], key+".json")
+}
+
+func (c *DiskCache) Get(key string) (*Result, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var cr cachedResult
+	if err := json.Unmarshal(data, &cr); err != nil {
+		return nil, false // Treat corrupted entries as missing
+	}
+
+	return cr.result(), true
+}
+
+func (c *DiskCache) Put(key string, res *Result) error {
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+
+	data, err := json.Marshal(newCachedResult(res))
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	// Write to a temporary file first so concurrent readers never see
+	// partially written entries
+	tmp, err := os.CreateTemp(filepath.Dir(path), "*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("write: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
+
+// Clear removes every cached result.
+func (c *DiskCache) Clear() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("remove all: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	return nil
+}
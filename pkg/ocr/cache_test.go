@@ -0,0 +1,229 @@
+package ocr_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kndrad/piccrack/pkg/imgprep"
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKey(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("image")
+	opts := ocr.DefaultOptions()
+
+	key := ocr.CacheKey(content, opts)
+	require.Len(t, key, 64)
+	require.Equal(t, key, ocr.CacheKey([]byte("image"), ocr.DefaultOptions()))
+
+	require.NotEqual(t, key, ocr.CacheKey([]byte("other image"), opts))
+
+	opts.Languages = []string{"pol"}
+	require.NotEqual(t, key, ocr.CacheKey(content, opts))
+
+	opts = ocr.DefaultOptions()
+	opts.Regions = []image.Rectangle{image.Rect(0, 0, 10, 10)}
+	require.NotEqual(t, key, ocr.CacheKey(content, opts))
+
+	// Timeout doesn't change results
+	opts = ocr.DefaultOptions()
+	opts.Timeout = time.Second
+	require.Equal(t, key, ocr.CacheKey(content, opts))
+}
+
+func TestCachedEngineScanFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		cache ocr.Cache
+	}{
+		{
+			desc:  "memory",
+			cache: ocr.NewMemoryCache(10),
+		},
+		{
+			desc: "disk",
+			cache: func() ocr.Cache {
+				c, err := ocr.NewDiskCache(t.TempDir())
+				require.NoError(t, err)
+
+				return c
+			}(),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			inner := ocrtest.NewEngineWithWords(ocrtest.Words("golang developer", 90)...)
+			e := ocr.NewCachedEngine(inner, tC.cache)
+
+			path := filepath.Join("testdata", "job0.png")
+			opts := ocr.DefaultOptions()
+
+			first, err := ocr.ScanFile(context.Background(), e, path, opts)
+			require.NoError(t, err)
+			second, err := ocr.ScanFile(context.Background(), e, path, opts)
+			require.NoError(t, err)
+
+			require.Equal(t, 1, inner.Scans())
+			require.Equal(t, first.Text(), second.Text())
+			require.Equal(t, first.WordBoxes(), second.WordBoxes())
+			require.Equal(t, first.LineBoxes(), second.LineBoxes())
+			require.Equal(t, path, second.Path())
+
+			// Other options are not cached yet
+			opts.Languages = []string{"pol"}
+			_, err = ocr.ScanFile(context.Background(), e, path, opts)
+			require.NoError(t, err)
+			require.Equal(t, 2, inner.Scans())
+
+			require.Equal(t, ocr.CacheStats{Hits: 1, Misses: 2}, e.Stats())
+		})
+	}
+}
+
+func TestCachedEngineScanDir(t *testing.T) {
+	t.Parallel()
+
+	inner := ocrtest.NewEngine("golang developer")
+	pool := ocr.NewPool(2, func() ocr.Engine { return inner })
+	e := ocr.NewCachedEngine(pool, ocr.NewMemoryCache(100))
+
+	for range 2 {
+		outcomes, errc := ocr.ScanDir(context.Background(), e, "testdata", ocr.DefaultOptions())
+
+		n := 0
+		for out := range outcomes {
+			require.NoError(t, out.Err)
+			require.NotEmpty(t, out.Result.Path())
+			n++
+		}
+		require.NoError(t, <-errc)
+		require.Equal(t, 5, n)
+	}
+
+	require.Equal(t, 5, inner.Scans())
+	require.Equal(t, ocr.CacheStats{Hits: 5, Misses: 5}, e.Stats())
+}
+
+func TestCachedEngineDoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	c := ocr.NewMemoryCache(10)
+	e := ocr.NewCachedEngine(ocrtest.NewFailingEngine(os.ErrInvalid), c)
+
+	_, err := ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "job0.png"), ocr.DefaultOptions())
+	require.ErrorIs(t, err, os.ErrInvalid)
+	require.Zero(t, c.Len())
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	c := ocr.NewMemoryCache(2)
+
+	require.NoError(t, c.Put("a", ocr.NewResult("a")))
+	require.NoError(t, c.Put("b", ocr.NewResult("b")))
+
+	_, ok := c.Get("a") // Now b is the least recently used
+	require.True(t, ok)
+
+	require.NoError(t, c.Put("c", ocr.NewResult("c")))
+	require.Equal(t, 2, c.Len())
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+
+	res, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, "a", res.Text())
+}
+
+func TestDiskCache(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	c, err := ocr.NewDiskCache(dir)
+	require.NoError(t, err)
+
+	key := ocr.CacheKey([]byte("image"), ocr.DefaultOptions())
+	_, ok := c.Get(key)
+	require.False(t, ok)
+
+	require.NoError(t, c.Put(key, ocr.NewResult("golang", ocrtest.Words("golang", 80)...)))
+
+	// Results survive reopening the cache
+	c, err = ocr.NewDiskCache(dir)
+	require.NoError(t, err)
+
+	res, ok := c.Get(key)
+	require.True(t, ok)
+	require.Equal(t, "golang", res.Text())
+	require.Len(t, res.WordBoxes(), 1)
+
+	require.NoError(t, c.Clear())
+	_, ok = c.Get(key)
+	require.False(t, ok)
+
+	// Corrupted entries are missing
+	require.NoError(t, c.Put(key, ocr.NewResult("golang")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, key[:2], key+".json"), []byte("{"), 0o600))
+	_, ok = c.Get(key)
+	require.False(t, ok)
+}
+
+func TestCachedEngineScansWholeImages(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "job0.png"))
+	require.NoError(t, err)
+
+	t.Run("regions", func(t *testing.T) {
+		t.Parallel()
+
+		c := ocr.NewMemoryCache(10)
+		inner := ocrtest.NewEngine("golang developer")
+		e := ocr.NewCachedEngine(inner, c)
+
+		opts := ocr.DefaultOptions()
+		opts.Regions = []image.Rectangle{image.Rect(0, 0, 20, 20), image.Rect(20, 20, 40, 40)}
+		for range 2 {
+			_, err := ocr.ScanFrom(context.Background(), e, bytes.NewReader(content), opts)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, inner.Scans())
+		require.Equal(t, 1, c.Len(), "an image is cached once")
+		require.Equal(t, ocr.CacheStats{Hits: 1, Misses: 1}, e.Stats())
+	})
+
+	t.Run("before_preprocessing", func(t *testing.T) {
+		t.Parallel()
+
+		// Sniffed as an image, but fails to be decoded by preprocessing
+		truncated := content[:64]
+		opts := ocr.DefaultOptions()
+		opts.Preprocess = imgprep.Grayscale
+
+		c := ocr.NewMemoryCache(10)
+		require.NoError(t, c.Put(ocr.CacheKey(truncated, opts), ocr.NewResult("cached")))
+		e := ocr.NewCachedEngine(ocrtest.NewEngine("golang developer"), c)
+
+		res, err := ocr.ScanFrom(context.Background(), e, bytes.NewReader(truncated), opts)
+		require.NoError(t, err)
+		require.Equal(t, "cached", res.Text())
+	})
+}
@@ -0,0 +1,144 @@
+package ocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kndrad/piccrack/pkg/imgprep"
+)
+
+// Engine performs text recognition on image content.
+//
+// Implementations are not required to validate content, it is done by
+// the package level scan functions before calling an engine.
+//
+// Scan must return once ctx is done, even if recognition itself can't be
+// interrupted. An engine whose scan was abandoned this way may be busy
+// until recognition finishes.
+type Engine interface {
+	Scan(ctx context.Context, content []byte, opts Options) (*Result, error)
+	Close() error
+}
+
+// DefaultLanguages are recognized when none were configured.
+var DefaultLanguages = []string{"eng", "pol"}
+
+// PageSegMode tells an engine how to detect layout of text in an image.
+// Values follow tesseract page segmentation modes.
+type PageSegMode int
+
+const (
+	PSMAutoOSD      PageSegMode = 1  // Automatic segmentation with orientation detection.
+	PSMAuto         PageSegMode = 3  // Fully automatic segmentation.
+	PSMSingleColumn PageSegMode = 4  // Single column of text of variable sizes.
+	PSMSingleBlock  PageSegMode = 6  // Single uniform block of text.
+	PSMSingleLine   PageSegMode = 7  // Single text line.
+	PSMSparseText   PageSegMode = 11 // As much text as possible in no particular order.
+)
+
+// Valid reports whether mode is a known tesseract page segmentation mode.
+func (mode PageSegMode) Valid() bool {
+	return mode >= 0 && mode <= 13
+}
+
+// Options configures a single recognition performed by an engine.
+type Options struct {
+	// Languages to recognize, e.g. "eng" and "pol".
+	Languages []string
+
+	// Whitelist limits recognized characters. Empty means no limit.
+	Whitelist string
+
+	// PageSegMode sets layout detection, PSMAuto if zero.
+	PageSegMode PageSegMode
+
+	// MinConfidence drops recognized words with lower confidence (0-100)
+	// from the result. Zero keeps every word.
+	MinConfidence float64
+
+	// Preprocess selects steps applied to an image before it's passed
+	// to an engine.
+	Preprocess imgprep.Steps
+
+	// Regions of an image to recognize, the whole image if empty.
+	// Every region is cropped and recognized separately, boxes of the
+	// result are relative to the whole image.
+	Regions []image.Rectangle
+
+	// Timeout limits recognition of a single image, including all of its
+	// regions. Zero means no limit. Scans exceeding it fail with ErrTimeout.
+	// It doesn't change results, so it's not a part of cache keys.
+	Timeout time.Duration `json:"-"`
+}
+
+// DefaultOptions returns options used when none were configured.
+func DefaultOptions() Options {
+	return Options{
+		Languages:   DefaultLanguages,
+		PageSegMode: PSMAuto,
+	}
+}
+
+var ErrInvalidLanguage = errors.New("invalid language")
+
+// ParseLanguages parses languages joined by "+" or "," as in "eng+pol".
+// Empty string returns DefaultLanguages.
+func ParseLanguages(s string) ([]string, error) {
+	langs := make([]string, 0)
+
+	for _, lang := range strings.FieldsFunc(s, func(r rune) bool { return r == '+' || r == ',' }) {
+		lang = strings.TrimSpace(lang)
+		if lang == "" {
+			continue
+		}
+		for _, r := range lang {
+			if (r < 'a' || r > 'z') && r != '_' {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidLanguage, lang)
+			}
+		}
+		langs = append(langs, lang)
+	}
+	if len(langs) == 0 {
+		return DefaultLanguages, nil
+	}
+
+	return langs, nil
+}
+
+var ErrInvalidRegion = errors.New("invalid region")
+
+// ParseRegions parses regions given as "x,y,width,height" in pixels,
+// x and y being the top left corner of a region.
+func ParseRegions(ss ...string) ([]image.Rectangle, error) {
+	regions := make([]image.Rectangle, 0, len(ss))
+
+	for _, s := range ss {
+		fields := strings.Split(s, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("%w: %q must be x,y,width,height", ErrInvalidRegion, s)
+		}
+
+		var v [4]int
+		for i, f := range fields {
+			n, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %w", ErrInvalidRegion, s, err)
+			}
+			if n < 0 {
+				return nil, fmt.Errorf("%w: %q has negative values", ErrInvalidRegion, s)
+			}
+			v[i] = n
+		}
+		if v[2] == 0 || v[3] == 0 {
+			return nil, fmt.Errorf("%w: %q is empty", ErrInvalidRegion, s)
+		}
+		regions = append(regions, image.Rect(v[0], v[1], v[0]+v[2], v[1]+v[3]))
+	}
+
+	return regions, nil
+}
@@ -0,0 +1,462 @@
+package ocr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kndrad/piccrack/pkg/imgprep"
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/kndrad/piccrack/pkg/pproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		path    string
+		wantErr error
+	}{
+		{
+			desc: "returns_text",
+
+			path: filepath.Join("testdata", "jpg_offer.jpg"),
+		},
+		{
+			desc: "not_an_image_is_not_scanned",
+
+			path:    filepath.Join("testdata", "file.txt"),
+			wantErr: ocr.ErrNotAnImage,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			e := ocrtest.NewEngine("golang developer")
+			defer e.Close()
+
+			result, err := ocr.ScanFile(context.Background(), e, tC.path, ocr.DefaultOptions())
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+				require.Zero(t, e.Scans())
+
+				return
+			}
+			require.NoError(t, err)
+
+			require.NotNil(t, result)
+			require.Equal(t, "golang developer", result.String())
+			require.Equal(t, tC.path, result.Path())
+		})
+	}
+}
+
+func TestScanFileMinConfidence(t *testing.T) {
+	t.Parallel()
+
+	words := append(ocrtest.Words("golang developer", 95), ocrtest.Words("|~ ,", 20)...)
+	e := ocrtest.NewEngineWithWords(words...)
+
+	opts := ocr.DefaultOptions()
+	opts.MinConfidence = 50
+
+	res, err := ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "job0.png"), opts)
+	require.NoError(t, err)
+
+	require.Equal(t, "golang developer", res.Text())
+	require.Len(t, res.WordBoxes(), 2)
+	require.Len(t, res.LineBoxes(), 1)
+}
+
+func TestScanFilePreprocess(t *testing.T) {
+	t.Parallel()
+
+	e := ocrtest.NewEngine("golang developer")
+
+	opts := ocr.DefaultOptions()
+	opts.Preprocess = imgprep.Grayscale | imgprep.Binarize
+
+	res, err := ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "job0.png"), opts)
+	require.NoError(t, err)
+	require.Equal(t, "golang developer", res.Text())
+
+	// Engine receives processed image
+	img, err := png.Decode(bytes.NewReader(e.LastContent()))
+	require.NoError(t, err)
+	require.IsType(t, &image.Gray{}, img)
+}
+
+func TestScanFileRegions(t *testing.T) {
+	t.Parallel()
+
+	e := ocrtest.NewEngineWithWords(ocrtest.Words("golang", 90)...)
+
+	opts := ocr.DefaultOptions()
+	opts.Regions = []image.Rectangle{image.Rect(0, 0, 40, 20), image.Rect(50, 60, 100, 100)}
+
+	res, err := ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "job0.png"), opts)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, e.Scans())
+	require.Equal(t, "golang\ngolang", res.Text())
+
+	words := res.WordBoxes()
+	require.Len(t, words, 2)
+	require.Equal(t, image.Pt(50, 60), words[1].Box.Min)
+	require.Len(t, res.LineBoxes(), 2)
+
+	// Engine receives the cropped region
+	img, err := png.Decode(bytes.NewReader(e.LastContent()))
+	require.NoError(t, err)
+	require.Equal(t, image.Pt(50, 40), img.Bounds().Size())
+
+	opts.Regions = []image.Rectangle{image.Rect(1e5, 1e5, 1e5+10, 1e5+10)}
+	_, err = ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "job0.png"), opts)
+	require.ErrorIs(t, err, imgprep.ErrEmptyRegion)
+}
+
+func TestScanFileEngineErr(t *testing.T) {
+	t.Parallel()
+
+	errEngine := errors.New("engine failed")
+	e := ocrtest.NewFailingEngine(errEngine)
+
+	_, err := ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "job0.png"), ocr.DefaultOptions())
+	require.ErrorIs(t, err, errEngine)
+}
+
+func TestScanFileTimeout(t *testing.T) {
+	t.Parallel()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		desc string
+
+		ctx     context.Context
+		timeout time.Duration
+		wantErr error
+	}{
+		{
+			desc: "image_exceeding_timeout",
+
+			ctx:     context.Background(),
+			timeout: 10 * time.Millisecond,
+			wantErr: ocr.ErrTimeout,
+		},
+		{
+			desc: "canceled_scan_is_not_a_timeout",
+
+			ctx:     canceled,
+			timeout: time.Hour,
+			wantErr: context.Canceled,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			e := ocrtest.NewSlowEngine("golang developer", time.Hour)
+
+			opts := ocr.DefaultOptions()
+			opts.Timeout = tC.timeout
+			opts.Regions = []image.Rectangle{image.Rect(0, 0, 10, 10), image.Rect(10, 10, 20, 20)}
+
+			_, err := ocr.ScanFile(tC.ctx, e, filepath.Join("testdata", "job0.png"), opts)
+			require.ErrorIs(t, err, tC.wantErr)
+			if tC.wantErr != ocr.ErrTimeout {
+				require.NotErrorIs(t, err, ocr.ErrTimeout)
+			}
+			require.Equal(t, 1, e.Scans(), "regions share the deadline of an image")
+		})
+	}
+}
+
+func TestScanDir(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		workers int
+	}{
+		{
+			desc:    "single_engine",
+			workers: 1,
+		},
+		{
+			desc:    "pool_of_engines",
+			workers: 3,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			e := ocrtest.NewEngine("golang developer")
+			p := ocr.NewPool(tC.workers, func() ocr.Engine { return e })
+			defer p.Close()
+
+			outcomes, errc := ocr.ScanDir(context.Background(), p, "testdata", ocr.DefaultOptions())
+
+			paths := make([]string, 0)
+			for out := range outcomes {
+				require.NoError(t, out.Err)
+				require.Equal(t, "golang developer", out.Result.Text())
+				require.Equal(t, out.Path, out.Result.Path())
+				paths = append(paths, out.Path)
+			}
+			require.NoError(t, <-errc)
+
+			// Every image except file.txt
+			require.Len(t, paths, 5)
+			require.Equal(t, 5, e.Scans())
+		})
+	}
+}
+
+func TestScanDirReportsEveryFailure(t *testing.T) {
+	t.Parallel()
+
+	errEngine := errors.New("engine failed")
+	e := ocrtest.NewFailingEngine(errEngine)
+
+	outcomes, errc := ocr.ScanDir(context.Background(), ocr.NewPool(2, func() ocr.Engine { return e }), "testdata", ocr.DefaultOptions())
+
+	failed := 0
+	for out := range outcomes {
+		require.Nil(t, out.Result)
+		require.ErrorIs(t, out.Err, errEngine)
+		require.NotEmpty(t, out.Path)
+		failed++
+	}
+	require.NoError(t, <-errc)
+	require.Equal(t, 5, failed)
+}
+
+func TestScanDirContinuesAfterCorruptImage(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	img, err := os.ReadFile(filepath.Join("testdata", "job0.png"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ok.png"), img, 0o600))
+	// Valid signature but truncated data
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.png"), img[:64], 0o600))
+
+	e := ocrtest.NewEngine("golang developer")
+	opts := ocr.DefaultOptions()
+	opts.Preprocess = imgprep.Grayscale // Requires decoding
+
+	outcomes, errc := ocr.ScanDir(context.Background(), e, dir, opts)
+
+	byPath := make(map[string]ocr.Outcome)
+	for out := range outcomes {
+		byPath[filepath.Base(out.Path)] = out
+	}
+	require.NoError(t, <-errc)
+	require.Len(t, byPath, 2)
+
+	require.NoError(t, byPath["ok.png"].Err)
+	require.Equal(t, "golang developer", byPath["ok.png"].Result.Text())
+
+	require.Error(t, byPath["corrupt.png"].Err)
+	require.Nil(t, byPath["corrupt.png"].Result)
+}
+
+func TestScanEntries(t *testing.T) {
+	t.Parallel()
+
+	entries, err := pproc.Walk(context.Background(), "testdata", pproc.NoFilter)
+	require.NoError(t, err)
+
+	e := ocrtest.NewEngine("golang developer")
+	outcomes := ocr.ScanEntries(context.Background(), ocr.NewPool(2, func() ocr.Engine { return e }), entries, ocr.DefaultOptions())
+
+	byPath := make(map[string]ocr.Outcome)
+	for out := range outcomes {
+		byPath[filepath.Base(out.Path)] = out
+	}
+	require.Len(t, byPath, 6)
+	require.Equal(t, 5, e.Scans())
+
+	require.ErrorIs(t, byPath["file.txt"].Err, ocr.ErrNotAnImage)
+	require.Equal(t, "golang developer", byPath["job0.png"].Result.Text())
+	require.Equal(t, filepath.Join("testdata", "job0.png"), byPath["job0.png"].Result.Path())
+}
+
+func TestScanDirMissingRoot(t *testing.T) {
+	t.Parallel()
+
+	outcomes, errc := ocr.ScanDir(context.Background(), ocrtest.NewEngine(""), filepath.Join("testdata", "missing"), ocr.DefaultOptions())
+	for range outcomes {
+		t.Fatal("no outcome expected")
+	}
+	require.ErrorIs(t, <-errc, os.ErrNotExist)
+}
+
+func TestScanDirCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	e := ocrtest.NewEngine("golang developer")
+	outcomes, errc := ocr.ScanDir(ctx, ocr.NewPool(2, func() ocr.Engine { return e }), "testdata", ocr.DefaultOptions())
+
+	// Receive one outcome and stop listening
+	<-outcomes
+	cancel()
+	for range outcomes {
+	}
+
+	require.ErrorIs(t, <-errc, context.Canceled)
+}
+
+func TestScanFrom(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		path string
+	}{
+		{
+			desc: "returns_result_by_scanning_from_file",
+			path: filepath.Join("testdata", "job0.png"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			f, err := os.Open(tC.path)
+			require.NoError(t, err)
+			defer f.Close()
+
+			e := ocrtest.NewEngine("golang developer")
+			defer e.Close()
+
+			res, err := ocr.ScanFrom(context.Background(), e, f, ocr.DefaultOptions())
+			require.NoError(t, err)
+			require.Equal(t, "golang developer", res.Text())
+			require.Empty(t, res.Path())
+		})
+	}
+}
+
+func TestParseLanguages(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "tesseract_format",
+			in:   "eng+pol",
+			want: []string{"eng", "pol"},
+		},
+		{
+			desc: "comma_separated",
+			in:   "pol, deu",
+			want: []string{"pol", "deu"},
+		},
+		{
+			desc: "script_with_underscore",
+			in:   "chi_sim",
+			want: []string{"chi_sim"},
+		},
+		{
+			desc: "empty_returns_default",
+			in:   "",
+			want: ocr.DefaultLanguages,
+		},
+		{
+			desc:    "invalid_characters_fail",
+			in:      "eng+../pol",
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			langs, err := ocr.ParseLanguages(tC.in)
+			if tC.wantErr {
+				require.ErrorIs(t, err, ocr.ErrInvalidLanguage)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tC.want, langs)
+		})
+	}
+}
+
+func TestParseRegions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		in      []string
+		want    []image.Rectangle
+		wantErr bool
+	}{
+		{
+			desc: "single_region",
+			in:   []string{"10,20,100,50"},
+			want: []image.Rectangle{image.Rect(10, 20, 110, 70)},
+		},
+		{
+			desc: "many_regions_with_spaces",
+			in:   []string{"0, 0, 5, 5", "5,5,1,1"},
+			want: []image.Rectangle{image.Rect(0, 0, 5, 5), image.Rect(5, 5, 6, 6)},
+		},
+		{
+			desc: "none",
+			in:   nil,
+			want: []image.Rectangle{},
+		},
+		{
+			desc:    "missing_values",
+			in:      []string{"10,20,100"},
+			wantErr: true,
+		},
+		{
+			desc:    "negative_values",
+			in:      []string{"-10,20,100,100"},
+			wantErr: true,
+		},
+		{
+			desc:    "empty_region",
+			in:      []string{"10,20,0,100"},
+			wantErr: true,
+		},
+		{
+			desc:    "not_a_number",
+			in:      []string{"a,b,c,d"},
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			regions, err := ocr.ParseRegions(tC.in...)
+			if tC.wantErr {
+				require.ErrorIs(t, err, ocr.ErrInvalidRegion)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tC.want, regions)
+		})
+	}
+}
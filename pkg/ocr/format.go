@@ -0,0 +1,347 @@
+package ocr
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"image"
+	"io"
+	"strings"
+)
+
+// Format is a serialization format of a result.
+type Format string
+
+const (
+	FormatText Format = "text" // Plain recognized text.
+	FormatJSON Format = "json" // Text, words and lines with boxes.
+	FormatHOCR Format = "hocr" // hOCR 1.2, XHTML with layout in title attributes.
+	FormatALTO Format = "alto" // ALTO XML version 4.
+)
+
+// Formats lists every format a result can be encoded in.
+var Formats = []Format{FormatText, FormatJSON, FormatHOCR, FormatALTO}
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// ParseFormat parses name of a format, case insensitive.
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range Formats {
+		if f == known {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+}
+
+// MediaType returns media type of content encoded in the format.
+func (f Format) MediaType() string {
+	switch f {
+	case FormatText:
+		return "text/plain"
+	case FormatJSON:
+		return "application/json"
+	case FormatHOCR:
+		return "text/vnd.hocr+html"
+	case FormatALTO:
+		return "application/alto+xml"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Ext returns file name extension of the format.
+func (f Format) Ext() string {
+	switch f {
+	case FormatText:
+		return ".txt"
+	case FormatJSON:
+		return ".json"
+	case FormatHOCR:
+		return ".hocr"
+	case FormatALTO:
+		return ".xml"
+	default:
+		return ""
+	}
+}
+
+// Encode writes the result to w in format f.
+func (res *Result) Encode(w io.Writer, f Format) error {
+	switch f {
+	case FormatText:
+		if _, err := io.WriteString(w, res.Text()+"\n"); err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+
+		return nil
+	case FormatJSON:
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+
+		return nil
+	case FormatHOCR:
+		return res.WriteHOCR(w)
+	case FormatALTO:
+		return res.WriteALTO(w)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownFormat, string(f))
+	}
+}
+
+func (res *Result) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Path  string    `json:"path,omitempty"`
+		Text  string    `json:"text"`
+		Words []WordBox `json:"words"`
+		Lines []LineBox `json:"lines"`
+	}{
+		Path:  res.Path(),
+		Text:  res.Text(),
+		Words: res.WordBoxes(),
+		Lines: res.LineBoxes(),
+	}
+	if v.Words == nil {
+		v.Words = []WordBox{}
+	}
+	if v.Lines == nil {
+		v.Lines = []LineBox{}
+	}
+
+	return json.Marshal(v)
+}
+
+// bounds returns bounds of the scanned image. If image content is not
+// known, it's the smallest rectangle holding every recognized line.
+func (res *Result) bounds() image.Rectangle {
+	if res == nil {
+		return image.Rectangle{}
+	}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(res.content)); err == nil {
+		return image.Rect(0, 0, cfg.Width, cfg.Height)
+	}
+
+	var r image.Rectangle
+	for _, line := range res.lines {
+		r = r.Union(line.Box)
+	}
+
+	return image.Rect(0, 0, r.Max.X, r.Max.Y)
+}
+
+// layoutLine is a line of a result with its words.
+type layoutLine struct {
+	LineBox
+
+	Words []WordBox
+}
+
+// linesWithWords returns lines of the result holding their words.
+func (res *Result) linesWithWords() []layoutLine {
+	lines := make([]layoutLine, len(res.LineBoxes()))
+	for i, line := range res.LineBoxes() {
+		lines[i].LineBox = line
+	}
+	for _, w := range res.WordBoxes() {
+		if w.Line >= 0 && w.Line < len(lines) {
+			lines[w.Line].Words = append(lines[w.Line].Words, w)
+		}
+	}
+
+	return lines
+}
+
+var hocrTemplate = template.Must(template.New("hocr").Funcs(template.FuncMap{
+	"bbox": func(r image.Rectangle) string {
+		return fmt.Sprintf("bbox %d %d %d %d", r.Min.X, r.Min.Y, r.Max.X, r.Max.Y)
+	},
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="en" lang="en">
+ <head>
+  <title>{{.Path}}</title>
+  <meta http-equiv="Content-Type" content="text/html;charset=utf-8"/>
+  <meta name="ocr-system" content="piccrack"/>
+  <meta name="ocr-capabilities" content="ocr_page ocr_line ocrx_word ocrp_wconf"/>
+ </head>
+ <body>
+  <div class="ocr_page" id="page_1" title="{{with .Path}}image &quot;{{.}}&quot;; {{end}}{{bbox .Bounds}}">
+{{- range $i, $line := .Lines}}
+   <span class="ocr_line" id="line_1_{{inc $i}}" title="{{bbox $line.Box}}">
+{{- range $j, $w := $line.Words}}
+    <span class="ocrx_word" id="word_1_{{inc $i}}_{{inc $j}}" title="{{bbox $w.Box}}; x_wconf {{printf "%.0f" $w.Confidence}}">{{$w.Text}}</span>
+{{- end}}
+   </span>
+{{- end}}
+  </div>
+ </body>
+</html>
+`))
+
+// WriteHOCR writes the result as an hOCR document of a single page.
+func (res *Result) WriteHOCR(w io.Writer) error {
+	data := struct {
+		Path   string
+		Bounds image.Rectangle
+		Lines  []layoutLine
+	}{
+		Path:   res.Path(),
+		Bounds: res.bounds(),
+		Lines:  res.linesWithWords(),
+	}
+	// Template would escape the declaration
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	if err := hocrTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
+
+// ALTO elements, only those needed to describe lines of words are used.
+type (
+	altoDocument struct {
+		XMLName     xml.Name        `xml:"alto"`
+		Xmlns       string          `xml:"xmlns,attr"`
+		Description altoDescription `xml:"Description"`
+		Page        altoPage        `xml:"Layout>Page"`
+	}
+
+	altoDescription struct {
+		MeasurementUnit string `xml:"MeasurementUnit"`
+		FileName        string `xml:"sourceImageInformation>fileName,omitempty"`
+		Software        string `xml:"OCRProcessing>ocrProcessingStep>processingSoftware>softwareName"`
+	}
+
+	altoBox struct {
+		HPos   int `xml:"HPOS,attr"`
+		VPos   int `xml:"VPOS,attr"`
+		Width  int `xml:"WIDTH,attr"`
+		Height int `xml:"HEIGHT,attr"`
+	}
+
+	altoPage struct {
+		ID         string         `xml:"ID,attr"`
+		Number     int            `xml:"PHYSICAL_IMG_NR,attr"`
+		Width      int            `xml:"WIDTH,attr"`
+		Height     int            `xml:"HEIGHT,attr"`
+		PrintSpace altoPrintSpace `xml:"PrintSpace"`
+	}
+
+	altoPrintSpace struct {
+		altoBox
+
+		Block altoTextBlock `xml:"TextBlock"`
+	}
+
+	altoTextBlock struct {
+		ID string `xml:"ID,attr"`
+		altoBox
+
+		Lines []altoTextLine `xml:"TextLine"`
+	}
+
+	altoTextLine struct {
+		ID string `xml:"ID,attr"`
+		altoBox
+
+		Items []any
+	}
+
+	altoString struct {
+		XMLName xml.Name `xml:"String"`
+		ID      string   `xml:"ID,attr"`
+		Content string   `xml:"CONTENT,attr"`
+		altoBox
+		// WC is a word confidence in range 0-1.
+		WC string `xml:"WC,attr"`
+	}
+
+	altoSpace struct {
+		XMLName xml.Name `xml:"SP"`
+	}
+)
+
+func newAltoBox(r image.Rectangle) altoBox {
+	return altoBox{
+		HPos:   r.Min.X,
+		VPos:   r.Min.Y,
+		Width:  r.Dx(),
+		Height: r.Dy(),
+	}
+}
+
+// WriteALTO writes the result as an ALTO XML document of a single page
+// with a single text block.
+func (res *Result) WriteALTO(w io.Writer) error {
+	bounds := res.bounds()
+
+	var blockBox image.Rectangle
+	lines := make([]altoTextLine, 0, len(res.LineBoxes()))
+	for i, line := range res.linesWithWords() {
+		blockBox = blockBox.Union(line.Box)
+
+		items := make([]any, 0, 2*len(line.Words))
+		for j, word := range line.Words {
+			if j > 0 {
+				items = append(items, altoSpace{})
+			}
+			items = append(items, altoString{
+				ID:      fmt.Sprintf("string_%d_%d", i+1, j+1),
+				Content: word.Text,
+				altoBox: newAltoBox(word.Box),
+				WC:      fmt.Sprintf("%.2f", word.Confidence/100),
+			})
+		}
+		lines = append(lines, altoTextLine{
+			ID:      fmt.Sprintf("line_%d", i+1),
+			altoBox: newAltoBox(line.Box),
+			Items:   items,
+		})
+	}
+
+	doc := altoDocument{
+		Xmlns: "http://www.loc.gov/standards/alto/ns-v4#",
+		Description: altoDescription{
+			MeasurementUnit: "pixel",
+			FileName:        res.Path(),
+			Software:        "piccrack",
+		},
+		Page: altoPage{
+			ID:     "page_1",
+			Number: 1,
+			Width:  bounds.Dx(),
+			Height: bounds.Dy(),
+			PrintSpace: altoPrintSpace{
+				altoBox: newAltoBox(bounds),
+				Block: altoTextBlock{
+					ID:      "block_1",
+					altoBox: newAltoBox(blockBox),
+					Lines:   lines,
+				},
+			},
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", " ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode xml: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return nil
+}
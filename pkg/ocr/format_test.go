@@ -0,0 +1,198 @@
+package ocr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		s       string
+		want    ocr.Format
+		wantErr error
+	}{
+		{
+			desc: "hocr",
+
+			s:    "hocr",
+			want: ocr.FormatHOCR,
+		},
+		{
+			desc: "case_insensitive",
+
+			s:    " ALTO ",
+			want: ocr.FormatALTO,
+		},
+		{
+			desc: "unknown",
+
+			s:       "pdf",
+			wantErr: ocr.ErrUnknownFormat,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := ocr.ParseFormat(tC.s)
+			require.ErrorIs(t, err, tC.wantErr)
+			require.Equal(t, tC.want, f)
+		})
+	}
+}
+
+// scanJob0 returns a result of two lines recognized in testdata/job0.png.
+func scanJob0(t *testing.T) *ocr.Result {
+	t.Helper()
+
+	words := append(ocrtest.Words("senior golang", 91), ocrtest.Words("\ndeveloper <&>", 70)...)
+	e := ocrtest.NewEngineWithWords(words...)
+
+	res, err := ocr.ScanFile(context.Background(), e, filepath.Join("testdata", "job0.png"), ocr.DefaultOptions())
+	require.NoError(t, err)
+
+	return res
+}
+
+// wellFormed reads every token of an xml document.
+func wellFormed(t *testing.T, data []byte) {
+	t.Helper()
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = true
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestResultEncode(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "job0.png"))
+	require.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+
+		format ocr.Format
+		check  func(t *testing.T, data []byte)
+	}{
+		{
+			desc: "text",
+
+			format: ocr.FormatText,
+			check: func(t *testing.T, data []byte) {
+				require.Equal(t, "senior golang\ndeveloper <&>\n", string(data))
+			},
+		},
+		{
+			desc: "json",
+
+			format: ocr.FormatJSON,
+			check: func(t *testing.T, data []byte) {
+				var v struct {
+					Path  string        `json:"path"`
+					Text  string        `json:"text"`
+					Words []ocr.WordBox `json:"words"`
+					Lines []ocr.LineBox `json:"lines"`
+				}
+				require.NoError(t, json.Unmarshal(data, &v))
+				require.Equal(t, filepath.Join("testdata", "job0.png"), v.Path)
+				require.Len(t, v.Words, 4)
+				require.Len(t, v.Lines, 2)
+			},
+		},
+		{
+			desc: "hocr",
+
+			format: ocr.FormatHOCR,
+			check: func(t *testing.T, data []byte) {
+				wellFormed(t, data)
+
+				s := string(data)
+				require.Equal(t, 2, strings.Count(s, `class="ocr_line"`))
+				require.Equal(t, 4, strings.Count(s, `class="ocrx_word"`))
+				require.Contains(t, s, `title="bbox 10 0 20 10; x_wconf 91">golang</span>`)
+				require.Contains(t, s, `>&lt;&amp;&gt;</span>`)
+			},
+		},
+		{
+			desc: "alto",
+
+			format: ocr.FormatALTO,
+			check: func(t *testing.T, data []byte) {
+				wellFormed(t, data)
+
+				var doc struct {
+					Page struct {
+						Width  int `xml:"WIDTH,attr"`
+						Height int `xml:"HEIGHT,attr"`
+						Lines  []struct {
+							Strings []struct {
+								Content string  `xml:"CONTENT,attr"`
+								HPos    int     `xml:"HPOS,attr"`
+								WC      float64 `xml:"WC,attr"`
+							} `xml:"String"`
+						} `xml:"PrintSpace>TextBlock>TextLine"`
+					} `xml:"Layout>Page"`
+				}
+				require.NoError(t, xml.Unmarshal(data, &doc))
+				require.Equal(t, cfg.Width, doc.Page.Width)
+				require.Equal(t, cfg.Height, doc.Page.Height)
+				require.Len(t, doc.Page.Lines, 2)
+
+				golang := doc.Page.Lines[0].Strings[1]
+				require.Equal(t, "golang", golang.Content)
+				require.Equal(t, 10, golang.HPos)
+				require.InDelta(t, 0.91, golang.WC, 0.001)
+				require.Equal(t, "<&>", doc.Page.Lines[1].Strings[1].Content)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			require.NoError(t, scanJob0(t).Encode(buf, tC.format))
+			tC.check(t, buf.Bytes())
+		})
+	}
+}
+
+func TestResultEncodeEmpty(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range ocr.Formats {
+		buf := new(bytes.Buffer)
+		require.NoError(t, ocr.NewResult("").Encode(buf, f), f)
+		if f == ocr.FormatHOCR || f == ocr.FormatALTO {
+			wellFormed(t, buf.Bytes())
+		}
+	}
+
+	require.ErrorIs(t, ocr.NewResult("").Encode(io.Discard, "pdf"), ocr.ErrUnknownFormat)
+}
@@ -7,58 +7,123 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 
+	"github.com/kndrad/piccrack/pkg/imgprep"
 	"github.com/kndrad/piccrack/pkg/imgsniff"
 	"github.com/kndrad/piccrack/pkg/pproc"
-	"github.com/otiai10/gosseract/v2"
+	"github.com/kndrad/piccrack/pkg/textproc"
 )
 
 var MaxImageSize int = 10 * 1024 * 1024 // 10MB
 
-func NewClient() *gosseract.Client {
-	client := gosseract.NewClient()
-	client.Trim = true
-	client.SetWhitelist(
-		"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789 \n",
-	)
-
-	return client
-}
-
 var ErrNotAnImage = errors.New("not an image")
 
-// scan is a wrapper around tesseract client with additional content validation
-// performed before returning text.
-func scan(tc *gosseract.Client, content []byte) (string, error) {
-	if tc == nil {
-		panic("tesseract client cannot be nil")
+// ErrTimeout is returned when recognition of an image takes longer than
+// Options.Timeout.
+var ErrTimeout = errors.New("ocr timeout")
+
+// scan is a wrapper around an engine with additional content validation
+// performed before returning result.
+func scan(ctx context.Context, e Engine, content []byte, opts Options) (*Result, error) {
+	if e == nil {
+		panic("engine cannot be nil")
 	}
 
 	if content == nil {
 		panic("content cannot be nil")
 	}
 	if !IsImage(content) {
-		return "", ErrNotAnImage
+		return nil, ErrNotAnImage
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.Timeout, ErrTimeout)
+		defer cancel()
+	}
+
+	var (
+		res *Result
+		err error
+	)
+	if is, ok := e.(ImageScanner); ok {
+		res, err = is.ScanImage(ctx, content, opts)
+	} else {
+		res, err = recognize(ctx, e, content, opts)
+	}
+	if err != nil {
+		// Tell a deadline of the image apart from the one of the caller
+		if errors.Is(context.Cause(ctx), ErrTimeout) {
+			return nil, fmt.Errorf("%w after %s: %w", ErrTimeout, opts.Timeout, err)
+		}
+
+		return nil, err
+	}
+	res.content = content
+	res.dropBelow(opts.MinConfidence)
+
+	return res, nil
+}
+
+// ImageScanner is an engine recognizing whole images itself, before they
+// are preprocessed and cropped to regions of Options, e.g. to look them up
+// in a cache. ScanFile, ScanFrom and ScanDir call ScanImage of such engines
+// instead of preprocessing images for Scan.
+type ImageScanner interface {
+	Engine
+	ScanImage(ctx context.Context, content []byte, opts Options) (*Result, error)
+}
+
+// recognize preprocesses content, or every region of it, and recognizes
+// it with e.
+func recognize(ctx context.Context, e Engine, content []byte, opts Options) (*Result, error) {
+	if len(opts.Regions) == 0 {
+		return scanImage(ctx, e, content, opts)
 	}
 
-	if err := tc.SetImageFromBytes(content); err != nil {
-		return "", fmt.Errorf("set image: %w", err)
+	return scanRegions(ctx, e, content, opts)
+}
+
+func scanImage(ctx context.Context, e Engine, content []byte, opts Options) (*Result, error) {
+	img, err := imgprep.Process(content, opts.Preprocess)
+	if err != nil {
+		return nil, fmt.Errorf("preprocess: %w", err)
 	}
-	text, err := tc.Text()
+
+	res, err := e.Scan(ctx, img, opts)
 	if err != nil {
-		return "", fmt.Errorf("text: %w", err)
+		return nil, fmt.Errorf("engine scan: %w", err)
+	}
+
+	return res, nil
+}
+
+// scanRegions recognizes every region of content separately and joins
+// results in order of regions.
+func scanRegions(ctx context.Context, e Engine, content []byte, opts Options) (*Result, error) {
+	crops, err := imgprep.Crop(content, opts.Regions...)
+	if err != nil {
+		return nil, fmt.Errorf("crop: %w", err)
+	}
+
+	results := make([]*Result, 0, len(crops))
+	for _, crop := range crops {
+		res, err := scanImage(ctx, e, crop, opts)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
 	}
 
-	return text, nil
+	return joinRegions(results, opts.Regions), nil
 }
 
 // ScanFile performs OCR on an image file.
 // Image content validation is performed before ocr.
-func ScanFile(tc *gosseract.Client, path string) (*Result, error) {
-	if tc == nil {
-		panic("tesseract client can't be nil")
+func ScanFile(ctx context.Context, e Engine, path string, opts Options) (*Result, error) {
+	if e == nil {
+		panic("engine can't be nil")
 	}
 	if path == "" {
 		panic("path can't be empty")
@@ -70,18 +135,41 @@ func ScanFile(tc *gosseract.Client, path string) (*Result, error) {
 		return nil, fmt.Errorf("read file: %w", err)
 	}
 
-	text, err := scan(tc, content)
+	res, err := scan(ctx, e, content, opts)
 	if err != nil {
 		return nil, fmt.Errorf("scan: %w", err)
 	}
+	res.path = path
 
-	return &Result{path: path, content: content, text: text}, nil
+	return res, nil
 }
 
+// Result holds text recognized in an image.
 type Result struct {
 	path    string
 	content []byte
 	text    string
+	words   []WordBox
+	lines   []LineBox
+}
+
+// NewResult returns a result holding text recognized by an engine.
+// Words are optional, lines of the result are grouped from them.
+func NewResult(text string, words ...WordBox) *Result {
+	return &Result{
+		text:  text,
+		words: words,
+		lines: groupLines(words),
+	}
+}
+
+// Path returns path of the scanned image, empty if it was not read from a file.
+func (res *Result) Path() string {
+	if res == nil {
+		return ""
+	}
+
+	return res.path
 }
 
 func (res *Result) String() string {
@@ -96,6 +184,7 @@ func (res *Result) Text() string {
 	return res.text
 }
 
+// Words returns tokens of recognized text, see textproc.Tokenizer.
 func (res *Result) Words() <-chan string {
 	var wg sync.WaitGroup
 
@@ -104,13 +193,13 @@ func (res *Result) Words() <-chan string {
 	go func() {
 		defer wg.Done()
 
-		for _, v := range strings.Fields(res.text) {
+		for _, v := range textproc.Tokens(res.Text()) {
 			wg.Add(1)
 
 			go func() {
 				defer wg.Done()
 
-				out <- strings.ToLower(v)
+				out <- v
 			}()
 		}
 	}()
@@ -122,39 +211,103 @@ func (res *Result) Words() <-chan string {
 	return out
 }
 
-// IsImage checks content (sniffs) if it's jpg or png.
+// IsImage checks content (sniffs) if it's in any format known to imgsniff.
 func IsImage(content []byte) bool {
-	return imgsniff.IsJPG(content) || imgsniff.IsPNG(content)
+	return imgsniff.IsImage(content)
+}
+
+// Outcome is an outcome of scanning a single file of a directory,
+// either a result or an error.
+type Outcome struct {
+	Path   string
+	Result *Result
+	Err    error
 }
 
 // ScanDir performs ocr on every image found in a directory.
-func ScanDir(ctx context.Context, tc *gosseract.Client, root string) ([]*Result, error) {
-	images := make([]*pproc.Entry, 0)
+//
+// Images are scanned concurrently by as many workers as the engine allows,
+// see Pool. Outcomes are streamed as soon as each image is scanned, a file
+// which fails to be read or scanned doesn't stop scanning of others.
+// Error channel receives an error which stopped the whole scan (walk of
+// root failed or ctx is done) and is closed once scanning is done.
+// Caller must consume outcomes to prevent leaks.
+func ScanDir(ctx context.Context, e Engine, root string, opts Options) (<-chan Outcome, <-chan error) {
+	outcomes := make(chan Outcome)
+	errc := make(chan error, 1)
 
-	entries, err := pproc.Walk(ctx, root, IsImage)
-	if err != nil {
-		return nil, fmt.Errorf("error during walk: %w", err)
-	}
-	for entry := range entries {
-		images = append(images, entry)
-	}
+	go func() {
+		defer close(errc)
+		defer close(outcomes)
 
-	// Drain entries and run ocr
-	results := make([]*Result, 0)
-	for _, img := range images {
-		res, err := ScanFile(tc, img.Path())
+		entries, err := pproc.Walk(ctx, root, IsImage)
 		if err != nil {
-			return nil, fmt.Errorf("do: %w", err)
+			errc <- fmt.Errorf("error during walk: %w", err)
+
+			return
 		}
-		results = append(results, res)
+
+		for out := range ScanEntries(ctx, e, entries, opts) {
+			select {
+			case outcomes <- out:
+			case <-ctx.Done():
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			errc <- err
+		}
+	}()
+
+	return outcomes, errc
+}
+
+// ScanEntries performs ocr on content of every entry, e.g. of images walked
+// by pproc.Walk, concurrently like ScanDir. An entry which failed to be read
+// is an outcome with its error. Outcomes are closed once entries are.
+func ScanEntries(ctx context.Context, e Engine, entries <-chan *pproc.Entry, opts Options) <-chan Outcome {
+	outcomes := make(chan Outcome)
+
+	var wg sync.WaitGroup
+	for range workers(e) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Keep draining entries after cancellation so the walk can finish
+			for entry := range entries {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				out := Outcome{Path: entry.Path()}
+				if err := entry.Err(); err != nil {
+					out.Err = err
+				} else if res, err := scan(ctx, e, entry.Content(), opts); err != nil {
+					out.Err = fmt.Errorf("scan: %w", err)
+				} else {
+					res.path = entry.Path()
+					out.Result = res
+				}
+
+				select {
+				case outcomes <- out:
+				case <-ctx.Done():
+				}
+			}
+		}()
 	}
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
 
-	return results, nil
+	return outcomes
 }
 
-func ScanFrom(tc *gosseract.Client, r io.Reader) (*Result, error) {
-	if tc == nil {
-		return nil, errors.New("tesseract client cannot be nil")
+func ScanFrom(ctx context.Context, e Engine, r io.Reader, opts Options) (*Result, error) {
+	if e == nil {
+		return nil, errors.New("engine cannot be nil")
 	}
 	if r == nil {
 		return nil, errors.New("reader is nil")
@@ -165,16 +318,12 @@ func ScanFrom(tc *gosseract.Client, r io.Reader) (*Result, error) {
 		return nil, fmt.Errorf("read full: %w", err)
 	}
 
-	text, err := scan(tc, content)
+	res, err := scan(ctx, e, content, opts)
 	if err != nil {
 		return nil, fmt.Errorf("scan: %w", err)
 	}
 
-	return &Result{
-		path:    "",
-		text:    text,
-		content: content,
-	}, nil
+	return res, nil
 }
 
 func readFull(r io.Reader) ([]byte, error) {
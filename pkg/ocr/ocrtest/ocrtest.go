@@ -0,0 +1,142 @@
+// Package ocrtest provides a deterministic ocr.Engine for tests
+// which do not have tesseract installed.
+package ocrtest
+
+import (
+	"context"
+	"image"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+)
+
+// Engine returns preset text for every scanned image instead of running
+// recognition.
+type Engine struct {
+	text  string
+	words []ocr.WordBox
+	err   error
+	delay time.Duration
+
+	mu     sync.Mutex
+	scans  int
+	last   []byte
+	closed bool
+}
+
+var _ ocr.Engine = (*Engine)(nil)
+
+// NewEngine returns an engine which recognizes text in every image.
+// Every word of text is recognized with full confidence.
+func NewEngine(text string) *Engine {
+	return &Engine{text: text, words: Words(text, 100)}
+}
+
+// NewEngineWithWords returns an engine which recognizes words in every image.
+// Text is built from words joined by lines.
+func NewEngineWithWords(words ...ocr.WordBox) *Engine {
+	lines := make([]string, 0)
+	for i, w := range words {
+		if i == 0 || w.Line != words[i-1].Line {
+			lines = append(lines, w.Text)
+
+			continue
+		}
+		lines[len(lines)-1] += " " + w.Text
+	}
+
+	return &Engine{text: strings.Join(lines, "\n"), words: words}
+}
+
+// Words splits text into words with given confidence, each word is
+// placed in a box of fixed size next to the previous one.
+func Words(text string, confidence float64) []ocr.WordBox {
+	const w, h = 10, 10
+
+	words := make([]ocr.WordBox, 0)
+	for y, line := range strings.Split(text, "\n") {
+		for x, v := range strings.Fields(line) {
+			words = append(words, ocr.WordBox{
+				Text:       v,
+				Box:        image.Rect(x*w, y*h, (x+1)*w, (y+1)*h),
+				Confidence: confidence,
+				Line:       y,
+			})
+		}
+	}
+
+	return words
+}
+
+// NewFailingEngine returns an engine which fails every scan with err.
+func NewFailingEngine(err error) *Engine {
+	return &Engine{err: err}
+}
+
+// NewSlowEngine returns an engine which recognizes text in every image
+// after delay, unless ctx of a scan is done earlier.
+func NewSlowEngine(text string, delay time.Duration) *Engine {
+	e := NewEngine(text)
+	e.delay = delay
+
+	return e
+}
+
+func (e *Engine) Scan(ctx context.Context, content []byte, _ ocr.Options) (*ocr.Result, error) {
+	e.mu.Lock()
+	e.scans++
+	e.last = content
+	e.mu.Unlock()
+
+	if e.delay > 0 {
+		t := time.NewTimer(e.delay)
+		defer t.Stop()
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return ocr.NewResult(e.text, slices.Clone(e.words)...), nil
+}
+
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.closed = true
+
+	return nil
+}
+
+// Closed reports whether the engine was closed.
+func (e *Engine) Closed() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.closed
+}
+
+// Scans returns how many times the engine was asked to scan an image.
+func (e *Engine) Scans() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.scans
+}
+
+// LastContent returns content of the most recently scanned image.
+func (e *Engine) LastContent() []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.last
+}
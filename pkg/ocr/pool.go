@@ -0,0 +1,178 @@
+package ocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Pool is an Engine which dispatches scans to a bounded set of engines.
+// Each engine is used by one scan at a time, so at most Size scans run
+// concurrently. Engines are created lazily when all existing ones are busy.
+//
+// An engine whose scan was abandoned because ctx was done is never reused,
+// it's closed and replaced by a new one.
+type Pool struct {
+	newEngine func() Engine
+	size      int
+
+	engines chan Engine // Idle engines
+
+	mu   sync.Mutex
+	all  []Engine
+	errs []error // Of closing discarded engines
+
+	closing sync.WaitGroup // Discarded engines being closed
+}
+
+var _ Engine = (*Pool)(nil)
+
+// NewPool returns a pool of at most size engines created by newEngine.
+// Size lower than 1 is treated as 1.
+func NewPool(size int, newEngine func() Engine) *Pool {
+	if newEngine == nil {
+		panic("new engine func cannot be nil")
+	}
+	if size < 1 {
+		size = 1
+	}
+
+	return &Pool{
+		newEngine: newEngine,
+		size:      size,
+		engines:   make(chan Engine, size),
+		all:       make([]Engine, 0, size),
+	}
+}
+
+// Size returns maximum number of concurrent scans.
+func (p *Pool) Size() int {
+	if p == nil {
+		return 0
+	}
+
+	return p.size
+}
+
+// Scan waits for an idle engine and performs the scan with it.
+func (p *Pool) Scan(ctx context.Context, content []byte, opts Options) (*Result, error) {
+	e, err := p.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire: %w", err)
+	}
+
+	res, err := e.Scan(ctx, content, opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Engine may still be busy with the abandoned scan
+			p.replace(e)
+		} else {
+			p.release(e)
+		}
+
+		return nil, fmt.Errorf("pooled scan: %w", err)
+	}
+	p.release(e)
+
+	return res, nil
+}
+
+func (p *Pool) acquire(ctx context.Context) (Engine, error) {
+	select {
+	case e := <-p.engines:
+		return e, nil
+	default:
+	}
+
+	p.mu.Lock()
+	if len(p.all) < p.size {
+		e := p.newEngine()
+		p.all = append(p.all, e)
+		p.mu.Unlock()
+
+		return e, nil
+	}
+	p.mu.Unlock()
+
+	select {
+	case e := <-p.engines:
+		return e, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *Pool) release(e Engine) {
+	p.engines <- e
+}
+
+// replace closes e in the background and releases a new engine in its place.
+func (p *Pool) replace(e Engine) {
+	p.mu.Lock()
+	if i := slices.Index(p.all, e); i >= 0 {
+		p.all = slices.Delete(p.all, i, i+1)
+	}
+	next := p.newEngine()
+	p.all = append(p.all, next)
+	p.mu.Unlock()
+
+	p.closing.Add(1)
+	go func() {
+		defer p.closing.Done()
+
+		// Blocks until e finishes the abandoned scan
+		if err := e.Close(); err != nil {
+			p.mu.Lock()
+			p.errs = append(p.errs, fmt.Errorf("close discarded engine: %w", err))
+			p.mu.Unlock()
+		}
+	}()
+
+	p.release(next)
+}
+
+// Close closes every engine created by the pool, waiting for discarded
+// engines to finish their abandoned scans.
+// It must not be called while scans are in progress.
+func (p *Pool) Close() error {
+	if p == nil {
+		return nil
+	}
+
+	p.closing.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	errs := p.errs
+	p.errs = nil
+	for _, e := range p.all {
+		if err := e.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	p.all = p.all[:0]
+
+	// Drain idle engines, they are all closed now
+	for {
+		select {
+		case <-p.engines:
+		default:
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// workers returns how many images can be scanned concurrently by e.
+func workers(e Engine) int {
+	switch e := e.(type) {
+	case *Pool:
+		return e.Size()
+	case *CachedEngine:
+		return workers(e.Engine)
+	default:
+		return 1
+	}
+}
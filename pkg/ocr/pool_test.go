@@ -0,0 +1,167 @@
+package ocr_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/stretchr/testify/require"
+)
+
+// countingEngine records how many scans are running at once.
+type countingEngine struct {
+	running *atomic.Int32
+	max     *atomic.Int32
+	closed  atomic.Bool
+}
+
+func (e *countingEngine) Scan(_ context.Context, _ []byte, _ ocr.Options) (*ocr.Result, error) {
+	n := e.running.Add(1)
+	defer e.running.Add(-1)
+
+	for {
+		m := e.max.Load()
+		if n <= m || e.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	return ocr.NewResult("text"), nil
+}
+
+func (e *countingEngine) Close() error {
+	e.closed.Store(true)
+
+	return nil
+}
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning atomic.Int32
+
+	engines := make([]*countingEngine, 0)
+	var mu sync.Mutex
+
+	p := ocr.NewPool(3, func() ocr.Engine {
+		mu.Lock()
+		defer mu.Unlock()
+
+		e := &countingEngine{running: &running, max: &maxRunning}
+		engines = append(engines, e)
+
+		return e
+	})
+	require.Equal(t, 3, p.Size())
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := p.Scan(context.Background(), []byte{}, ocr.DefaultOptions())
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, maxRunning.Load(), int32(3))
+	require.LessOrEqual(t, len(engines), 3)
+
+	require.NoError(t, p.Close())
+	for _, e := range engines {
+		require.True(t, e.closed.Load())
+	}
+}
+
+func TestPoolSizeAtLeastOne(t *testing.T) {
+	t.Parallel()
+
+	p := ocr.NewPool(0, func() ocr.Engine { return &countingEngine{} })
+	require.Equal(t, 1, p.Size())
+}
+
+func TestPoolReplacesAbandonedEngine(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		engines []*ocrtest.Engine
+	)
+	p := ocr.NewPool(1, func() ocr.Engine {
+		mu.Lock()
+		defer mu.Unlock()
+
+		e := ocrtest.NewEngine("text")
+		if len(engines) == 0 {
+			e = ocrtest.NewSlowEngine("text", time.Hour)
+		}
+		engines = append(engines, e)
+
+		return e
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := p.Scan(ctx, []byte{}, ocr.DefaultOptions())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	res, err := p.Scan(context.Background(), []byte{}, ocr.DefaultOptions())
+	require.NoError(t, err)
+	require.Equal(t, "text", res.Text())
+
+	mu.Lock()
+	require.Len(t, engines, 2)
+	require.Equal(t, 1, engines[1].Scans())
+	mu.Unlock()
+	require.Eventually(t, engines[0].Closed, time.Second, time.Millisecond)
+
+	require.NoError(t, p.Close())
+	require.True(t, engines[1].Closed())
+}
+
+func TestPoolReusesEngineAfterScanError(t *testing.T) {
+	t.Parallel()
+
+	created := 0
+	p := ocr.NewPool(1, func() ocr.Engine {
+		created++
+
+		return ocrtest.NewFailingEngine(ocr.ErrNotAnImage)
+	})
+
+	for range 3 {
+		_, err := p.Scan(context.Background(), []byte{}, ocr.DefaultOptions())
+		require.ErrorIs(t, err, ocr.ErrNotAnImage)
+	}
+	require.Equal(t, 1, created)
+}
+
+func TestPoolAcquireCanceled(t *testing.T) {
+	t.Parallel()
+
+	p := ocr.NewPool(1, func() ocr.Engine { return ocrtest.NewSlowEngine("text", 50*time.Millisecond) })
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		_, err := p.Scan(context.Background(), []byte{}, ocr.DefaultOptions())
+		require.NoError(t, err)
+	}()
+	time.Sleep(5 * time.Millisecond) // Let the engine be acquired
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := p.Scan(ctx, []byte{}, ocr.DefaultOptions())
+	require.ErrorIs(t, err, context.Canceled)
+	<-done
+}
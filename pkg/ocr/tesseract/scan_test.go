@@ -0,0 +1,38 @@
+package tesseract
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngineScanTimesOutWhileBusy(t *testing.T) {
+	t.Parallel()
+
+	e := NewEngine()
+
+	release := make(chan struct{})
+	e.recognize = func([]byte, ocr.Options) (*ocr.Result, error) {
+		<-release
+
+		return ocr.NewResult(""), nil
+	}
+
+	path := filepath.Join("..", "testdata", "job0.png")
+	opts := ocr.DefaultOptions()
+	opts.Timeout = 50 * time.Millisecond
+
+	// The first scan is abandoned and keeps the client busy
+	_, err := ocr.ScanFile(context.Background(), e, path, opts)
+	require.ErrorIs(t, err, ocr.ErrTimeout)
+
+	_, err = ocr.ScanFile(context.Background(), e, path, opts)
+	require.ErrorIs(t, err, ocr.ErrTimeout)
+
+	close(release)
+	require.NoError(t, e.Close())
+}
@@ -0,0 +1,166 @@
+// Package tesseract implements ocr.Engine with the tesseract library.
+package tesseract
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/otiai10/gosseract/v2"
+)
+
+// Engine is an ocr.Engine backed by a single tesseract client.
+// Scans are serialized, client can't be used concurrently.
+//
+// Tesseract can't be interrupted, a scan abandoned because its ctx is done
+// keeps the client busy until recognition finishes. Close waits for it.
+type Engine struct {
+	// sem holds a token while the client is busy.
+	sem chan struct{}
+	tc  *gosseract.Client
+
+	// recognize is scan, replaced in tests.
+	recognize func(content []byte, opts ocr.Options) (*ocr.Result, error)
+}
+
+var _ ocr.Engine = (*Engine)(nil)
+
+// NewEngine returns an engine with a new tesseract client.
+// It's due to the caller to Close the engine.
+func NewEngine() *Engine {
+	tc := gosseract.NewClient()
+	tc.Trim = true
+
+	e := &Engine{
+		sem: make(chan struct{}, 1),
+		tc:  tc,
+	}
+	e.recognize = e.scan
+
+	return e
+}
+
+func (e *Engine) Scan(ctx context.Context, content []byte, opts ocr.Options) (*ocr.Result, error) {
+	if e == nil {
+		panic("engine cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type scanned struct {
+		res *ocr.Result
+		err error
+	}
+	done := make(chan scanned, 1)
+
+	// Wait for the client no longer than ctx allows, it may be busy with
+	// a scan abandoned before
+	select {
+	case e.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	go func() {
+		defer func() { <-e.sem }()
+
+		res, err := e.recognize(content, opts)
+		done <- scanned{res, err}
+	}()
+
+	select {
+	case s := <-done:
+		return s.res, s.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (e *Engine) scan(content []byte, opts ocr.Options) (*ocr.Result, error) {
+	langs := opts.Languages
+	if len(langs) == 0 {
+		langs = ocr.DefaultLanguages
+	}
+	// Changing languages initializes tesseract again, avoid it if possible
+	if !slices.Equal(e.tc.Languages, langs) {
+		if err := e.tc.SetLanguage(langs...); err != nil {
+			return nil, fmt.Errorf("set language: %w", err)
+		}
+	}
+	mode := opts.PageSegMode
+	if mode == 0 {
+		mode = ocr.PSMAuto
+	}
+	if err := e.tc.SetPageSegMode(gosseract.PageSegMode(mode)); err != nil {
+		return nil, fmt.Errorf("set page seg mode: %w", err)
+	}
+	if err := e.tc.SetWhitelist(opts.Whitelist); err != nil {
+		return nil, fmt.Errorf("set whitelist: %w", err)
+	}
+	if err := e.tc.SetImageFromBytes(content); err != nil {
+		return nil, fmt.Errorf("set image: %w", err)
+	}
+	text, err := e.tc.Text()
+	if err != nil {
+		return nil, fmt.Errorf("text: %w", err)
+	}
+	boxes, err := e.tc.GetBoundingBoxesVerbose()
+	if err != nil {
+		return nil, fmt.Errorf("bounding boxes: %w", err)
+	}
+
+	return ocr.NewResult(text, wordBoxes(boxes)...), nil
+}
+
+// wordBoxes converts word level boxes to words numbered by the line
+// they appear in.
+func wordBoxes(boxes []gosseract.BoundingBox) []ocr.WordBox {
+	type lineKey struct{ block, par, line int }
+
+	words := make([]ocr.WordBox, 0, len(boxes))
+	lines := make(map[lineKey]int)
+
+	for _, b := range boxes {
+		if strings.TrimSpace(b.Word) == "" {
+			continue
+		}
+		key := lineKey{b.BlockNum, b.ParNum, b.LineNum}
+		idx, ok := lines[key]
+		if !ok {
+			idx = len(lines)
+			lines[key] = idx
+		}
+		words = append(words, ocr.WordBox{
+			Text:       b.Word,
+			Box:        b.Box,
+			Confidence: b.Confidence,
+			Line:       idx,
+		})
+	}
+
+	return words
+}
+
+func (e *Engine) Close() error {
+	if e == nil {
+		return nil
+	}
+
+	e.sem <- struct{}{}
+	defer func() { <-e.sem }()
+
+	if err := e.tc.Close(); err != nil {
+		return fmt.Errorf("close client: %w", err)
+	}
+
+	return nil
+}
+
+// NewPool returns a pool of at most size engines, one tesseract client each.
+func NewPool(size int) *ocr.Pool {
+	return ocr.NewPool(size, func() ocr.Engine {
+		return NewEngine()
+	})
+}
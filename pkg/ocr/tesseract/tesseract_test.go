@@ -0,0 +1,58 @@
+package tesseract_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/tesseract"
+	"github.com/stretchr/testify/require"
+)
+
+const testdata = "../testdata"
+
+func TestEngineScanFile(t *testing.T) {
+	t.Parallel()
+
+	e := tesseract.NewEngine()
+	defer e.Close()
+
+	result, err := ocr.ScanFile(context.Background(), e, filepath.Join(testdata, "jpg_offer.jpg"), ocr.DefaultOptions())
+	require.NoError(t, err)
+
+	require.NotNil(t, result)
+	require.NotEmpty(t, result.String())
+	require.NotEmpty(t, result.WordBoxes())
+	require.NotEmpty(t, result.LineBoxes())
+}
+
+func TestEngineScanDir(t *testing.T) {
+	t.Parallel()
+
+	p := tesseract.NewPool(2)
+	defer p.Close()
+
+	outcomes, errc := ocr.ScanDir(context.Background(), p, testdata, ocr.DefaultOptions())
+	for out := range outcomes {
+		require.NoError(t, out.Err)
+		require.NotEmpty(t, out.Result.Text())
+	}
+	require.NoError(t, <-errc)
+}
+
+func TestEngineScanFrom(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join(testdata, "job0.png"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	e := tesseract.NewEngine()
+	defer e.Close()
+
+	res, err := ocr.ScanFrom(context.Background(), e, f, ocr.DefaultOptions())
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Text())
+}
@@ -0,0 +1,137 @@
+// Package pdftext extracts text embedded in PDF documents.
+//
+// Only the text layer is read, pages without one (e.g. scanned pages)
+// have empty text.
+package pdftext
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+var (
+	ErrNotPDF      = errors.New("not a pdf document")
+	ErrNoTextLayer = errors.New("pdf document has no text layer")
+)
+
+var signature = []byte("%PDF-")
+
+// IsPDF checks content (sniffs) if it's a pdf document.
+func IsPDF(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), signature)
+}
+
+// Page holds text of a single page of a document.
+type Page struct {
+	// Number of the page starting from 1.
+	Number int
+	Text   string
+}
+
+// Source returns name of the page within document at path.
+func (p Page) Source(path string) string {
+	return fmt.Sprintf("%s#page=%d", path, p.Number)
+}
+
+// Extract returns text of every page of a pdf document.
+//
+// ErrNoTextLayer is returned if none of the pages has any text.
+func Extract(content []byte) (pages []Page, err error) {
+	if !IsPDF(content) {
+		return nil, ErrNotPDF
+	}
+
+	// Reader panics on some malformed documents
+	defer func() {
+		if r := recover(); r != nil {
+			pages = nil
+			err = fmt.Errorf("malformed pdf: %v", r)
+		}
+	}()
+
+	r, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("new reader: %w", err)
+	}
+
+	n := r.NumPage()
+	pages = make([]Page, 0, n)
+
+	hasText := false
+	for i := 1; i <= n; i++ {
+		p := r.Page(i)
+		if p.V.IsNull() {
+			continue
+		}
+		text := pageText(p.Content().Text)
+		if text != "" {
+			hasText = true
+		}
+		pages = append(pages, Page{
+			Number: i,
+			Text:   text,
+		})
+	}
+	if !hasText {
+		return nil, ErrNoTextLayer
+	}
+
+	return pages, nil
+}
+
+// ExtractFile returns text of every page of a pdf document located at path.
+func ExtractFile(path string) ([]Page, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+	pages, err := Extract(content)
+	if err != nil {
+		return nil, fmt.Errorf("extract: %w", err)
+	}
+
+	return pages, nil
+}
+
+// pageText joins glyphs into lines of text. Glyphs are expected in order of
+// the content stream. A new line begins when vertical position changes by
+// more than half of the font size, a space is inserted when horizontal gap
+// between glyphs is wider than a fraction of the font size.
+func pageText(glyphs []pdf.Text) string {
+	const spaceGap = 0.15 // Of font size
+
+	var (
+		b    strings.Builder
+		prev pdf.Text
+	)
+	for i, g := range glyphs {
+		if i > 0 {
+			size := math.Max(g.FontSize, 1)
+
+			switch {
+			case math.Abs(g.Y-prev.Y) > size/2:
+				b.WriteByte('\n')
+			case prev.W > 0 && g.X-(prev.X+prev.W) > size*spaceGap:
+				if g.S != " " && prev.S != " " {
+					b.WriteByte(' ')
+				}
+			}
+		}
+		b.WriteString(g.S)
+		prev = g
+	}
+
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
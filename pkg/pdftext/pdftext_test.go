@@ -0,0 +1,130 @@
+package pdftext
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPDF(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		data []byte
+		want bool
+	}{
+		{
+			desc: "pdf",
+			data: []byte("%PDF-1.4\n"),
+			want: true,
+		},
+		{
+			desc: "leading_whitespace",
+			data: []byte("\n %PDF-1.7"),
+			want: true,
+		},
+		{
+			desc: "text",
+			data: []byte("PDF-1.4"),
+			want: false,
+		},
+		{
+			desc: "empty",
+			data: nil,
+			want: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tC.want, IsPDF(tC.data))
+		})
+	}
+}
+
+func TestExtractFile(t *testing.T) {
+	t.Parallel()
+
+	pages, err := ExtractFile(filepath.Join("testdata", "job_offer.pdf"))
+	require.NoError(t, err)
+
+	require.Equal(t, []Page{
+		{Number: 1, Text: "Senior Golang Developer\nRequirements: Docker, Kubernetes"},
+		{Number: 2, Text: "Nice to have: PostgreSQL\nRemote work"},
+		{Number: 3, Text: ""}, // Without text layer
+	}, pages)
+	require.Equal(t, "offer.pdf#page=2", pages[1].Source("offer.pdf"))
+}
+
+func TestExtractErrors(t *testing.T) {
+	t.Parallel()
+
+	blank, err := os.ReadFile(filepath.Join("testdata", "blank.pdf"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc string
+
+		content []byte
+		wantErr error
+	}{
+		{
+			desc:    "not_pdf",
+			content: []byte("some text"),
+			wantErr: ErrNotPDF,
+		},
+		{
+			desc:    "no_text_layer",
+			content: blank,
+			wantErr: ErrNoTextLayer,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Extract(tC.content)
+			require.ErrorIs(t, err, tC.wantErr)
+		})
+	}
+}
+
+func TestExtractMalformed(t *testing.T) {
+	t.Parallel()
+
+	_, err := Extract([]byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog"))
+	require.Error(t, err)
+}
+
+func TestPageText(t *testing.T) {
+	t.Parallel()
+
+	glyphs := func(s string, x, y, w float64) []pdf.Text {
+		out := make([]pdf.Text, 0, len(s))
+		for i, r := range s {
+			out = append(out, pdf.Text{
+				FontSize: 10,
+				X:        x + float64(i)*w,
+				Y:        y,
+				W:        w,
+				S:        string(r),
+			})
+		}
+
+		return out
+	}
+
+	var text []pdf.Text
+	text = append(text, glyphs("Go", 0, 100, 5)...)
+	text = append(text, glyphs("Docker", 20, 100, 5)...) // Gap without space glyph
+	text = append(text, glyphs("SQL", 0, 86, 5)...)      // Next line
+	text = append(text, glyphs("C++", 15, 86, 5)...)     // Adjacent
+
+	require.Equal(t, "Go Docker\nSQLC++", pageText(text))
+}
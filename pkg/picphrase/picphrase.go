@@ -7,14 +7,31 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sync"
 
 	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/pdftext"
+	"github.com/kndrad/piccrack/pkg/pproc"
 	"github.com/kndrad/piccrack/pkg/textproc"
 )
 
 type Phrase struct {
-	value string
+	value    string
+	source   string
+	language string
+	section  textproc.Section
+}
+
+// newPhrase returns a phrase of a line with its language detected and
+// section classified by c.
+func newPhrase(line, source string, c *textproc.SectionClassifier) *Phrase {
+	return &Phrase{
+		value:    line,
+		source:   source,
+		language: textproc.DefaultLanguageDetector().Language(line),
+		section:  c.Classify(line),
+	}
 }
 
 func (ph *Phrase) String() string {
@@ -25,107 +42,293 @@ func (ph *Phrase) String() string {
 	return ph.value
 }
 
-// ScanAt uses ocr client to scan for phrases found in image located at path.
-func ScanAt(ctx context.Context, path string) (<-chan *Phrase, error) {
-	tc := ocr.NewClient()
-	defer tc.Close()
+// Source returns where the phrase was found: path of an image or a page of
+// pdf document (see pdftext.Page.Source). Empty if unknown.
+func (ph *Phrase) Source() string {
+	if ph == nil {
+		return ""
+	}
 
-	sentences := make(chan *Phrase)
+	return ph.source
+}
 
-	res, err := ocr.ScanFile(tc, filepath.Clean(path))
-	if err != nil {
-		return nil, fmt.Errorf("single ocr: %w", err)
+// Language returns ISO 639-1 code of language of the phrase, e.g. "pl".
+// Empty if it was not detected.
+func (ph *Phrase) Language() string {
+	if ph == nil {
+		return ""
 	}
 
-	var wg sync.WaitGroup
-	for line := range textproc.ScanLines(res.Text()) {
-		wg.Add(1)
-		go func() {
-			select {
-			case sentences <- &Phrase{line}:
-			case <-ctx.Done():
-			}
-			wg.Done()
-		}()
+	return ph.language
+}
+
+// Section returns a section of a job offer the phrase belongs to, e.g.
+// requirements. Empty if it is under no known heading.
+func (ph *Phrase) Section() textproc.Section {
+	if ph == nil {
+		return textproc.SectionNone
 	}
-	go func() {
-		wg.Wait()
-		close(sentences)
-	}()
 
-	return sentences, nil
+	return ph.section
 }
 
-// ScanDir performs OCR on all images found in dir.
-func ScanDir(ctx context.Context, dir string) (<-chan *Phrase, error) {
-	dir = filepath.Clean(dir)
+// ScanFile scans for phrases found in image or pdf document located at path.
+// Images are scanned by ocr engine, text layer of pdf documents is read
+// directly. A failure is reported by the outcome.
+func ScanFile(ctx context.Context, e ocr.Engine, path string, opts ocr.Options) Outcome {
+	path = filepath.Clean(path)
+	o := Outcome{Path: path}
 
-	info, err := os.Stat(dir)
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("stat: %w", err)
+		o.Err = fmt.Errorf("read file: %w", err)
+
+		return o
 	}
-	if !info.IsDir() {
-		return nil, errors.New("path must be dir")
+	if pdftext.IsPDF(content) {
+		return scanPDF(path, content)
 	}
 
-	tc := ocr.NewClient()
-	defer tc.Close()
+	res, err := ocr.ScanFile(ctx, e, path, opts)
+	if err != nil {
+		o.Err = fmt.Errorf("single ocr: %w", err)
 
-	texts := make([]string, 0)
+		return o
+	}
+	o.Result = res
+	o.Phrases = collect(path, res.Text(), textproc.NewSectionClassifier())
+
+	return o
+}
 
-	results, err := ocr.ScanDir(ctx, tc, dir)
+// ScanPDF reads phrases from text layer of a pdf document. Each page is
+// a separate source of phrases named after the document.
+func ScanPDF(ctx context.Context, r io.Reader, name string) (<-chan *Phrase, error) {
+	content, err := io.ReadAll(r)
 	if err != nil {
-		return nil, fmt.Errorf("ocr dir: %w", err)
+		return nil, fmt.Errorf("read all: %w", err)
 	}
-	for _, res := range results {
-		texts = append(texts, res.Text())
+	pages, err := pdftext.Extract(content)
+	if err != nil {
+		return nil, fmt.Errorf("extract pdf: %w", err)
 	}
 
+	return sendPages(ctx, name, pages), nil
+}
+
+func sendPages(ctx context.Context, name string, pages []pdftext.Page) <-chan *Phrase {
 	out := make(chan *Phrase)
 
-	var wg sync.WaitGroup
-	for _, text := range texts {
+	go func() {
+		defer close(out)
+
+		c := textproc.NewSectionClassifier()
+		for _, page := range pages {
+			send(ctx, out, page.Source(name), page.Text, c)
+		}
+	}()
+
+	return out
+}
+
+// send sends every line of text as a phrase until ctx is done. Sections
+// of lines are classified by c, shared by pages of a document.
+func send(ctx context.Context, out chan<- *Phrase, source, text string, c *textproc.SectionClassifier) {
+	for line := range textproc.ScanLines(text) {
+		select {
+		case out <- newPhrase(line, source, c):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// Outcome is an outcome of scanning a single file of a directory,
+// either phrases found in it or an error.
+type Outcome struct {
+	Path    string
+	Phrases []*Phrase
+	// Result of recognition of an image, nil for pdf documents.
+	Result *ocr.Result
+	Err    error
+}
+
+// collect returns every line of text as a phrase. Sections of lines are
+// classified by c, shared by pages of a document.
+func collect(source, text string, c *textproc.SectionClassifier) []*Phrase {
+	phrases := make([]*Phrase, 0)
+	for line := range textproc.ScanLines(text) {
+		phrases = append(phrases, newPhrase(line, source, c))
+	}
+
+	return phrases
+}
+
+// ScanDir performs OCR on all images found in dir and reads text layer of
+// all pdf documents.
+// Outcomes are streamed as soon as each file is scanned, a file which fails
+// doesn't stop scanning of others. Error channel receives errors which stopped
+// the whole scan and is closed once scanning is done.
+func ScanDir(ctx context.Context, e ocr.Engine, dir string, opts ocr.Options) (<-chan Outcome, <-chan error) {
+	out := make(chan Outcome)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(out)
+
+		dir = filepath.Clean(dir)
+
+		info, err := os.Stat(dir)
+		if err != nil {
+			errc <- fmt.Errorf("stat: %w", err)
+
+			return
+		}
+		if !info.IsDir() {
+			errc <- errors.New("path must be dir")
+
+			return
+		}
+
+		send := func(o Outcome) {
+			select {
+			case out <- o:
+			case <-ctx.Done():
+			}
+		}
+
+		// Walk once, so that a file failing to be read is a single outcome
+		entries, err := pproc.Walk(ctx, dir, pproc.Any(ocr.IsImage, pdftext.IsPDF))
+		if err != nil {
+			errc <- fmt.Errorf("walk: %w", err)
+
+			return
+		}
+
+		images := make(chan *pproc.Entry)
+		var wg sync.WaitGroup
 		wg.Add(1)
 		go func() {
-			for line := range textproc.ScanLines(text) {
-				out <- &Phrase{line}
+			defer wg.Done()
+			defer close(images)
+
+			// Keep draining entries after cancellation so the walk can finish
+			for entry := range entries {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := entry.Err(); err != nil {
+					send(Outcome{Path: entry.Path(), Err: err})
+
+					continue
+				}
+				if ocr.IsImage(entry.Content()) {
+					select {
+					case images <- entry:
+					case <-ctx.Done():
+					}
+
+					continue
+				}
+				send(scanPDF(entry.Path(), entry.Content()))
 			}
-			wg.Done()
 		}()
-	}
-	go func() {
+
+		for res := range ocr.ScanEntries(ctx, e, images, opts) {
+			o := Outcome{Path: res.Path, Result: res.Result, Err: res.Err}
+			if res.Err == nil {
+				o.Phrases = collect(res.Path, res.Result.Text(), textproc.NewSectionClassifier())
+			}
+			send(o)
+		}
 		wg.Wait()
-		close(out)
+
+		if err := ctx.Err(); err != nil {
+			errc <- err
+		}
 	}()
 
-	return out, nil
+	return out, errc
 }
 
-func ScanReader(ctx context.Context, r io.Reader) (<-chan *Phrase, error) {
-	tc := ocr.NewClient()
-	defer tc.Close()
+// scanPDF reads phrases from text layer of content of a pdf document
+// located at path.
+func scanPDF(path string, content []byte) Outcome {
+	o := Outcome{Path: path}
+	pages, err := pdftext.Extract(content)
+	if err != nil {
+		o.Err = fmt.Errorf("extract pdf: %w", err)
+
+		return o
+	}
+	c := textproc.NewSectionClassifier()
+	for _, page := range pages {
+		o.Phrases = append(o.Phrases, collect(page.Source(path), page.Text, c)...)
+	}
+
+	return o
+}
 
-	res, err := ocr.ScanFrom(tc, r)
+// ScanReader performs OCR on an image read from r and sends its lines as
+// phrases, in order of the text.
+func ScanReader(ctx context.Context, e ocr.Engine, r io.Reader, opts ocr.Options) (<-chan *Phrase, error) {
+	res, err := ocr.ScanFrom(ctx, e, r, opts)
 	if err != nil {
 		return nil, fmt.Errorf("scan from: %w", err)
 	}
 
+	lines := make([]string, 0)
+	for line := range textproc.ScanLines(res.Text()) {
+		lines = append(lines, line)
+	}
+	phrases := newPhrases(lines, "", textproc.NewSectionClassifier())
+
 	out := make(chan *Phrase)
+	go func() {
+		defer close(out)
+
+		for _, ph := range phrases {
+			select {
+			case out <- ph:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
 
+// newPhrases returns phrases of lines in their order. Sections follow
+// headings, so lines are classified by c in order, and their languages are
+// detected by a bounded number of workers.
+func newPhrases(lines []string, source string, c *textproc.SectionClassifier) []*Phrase {
+	phrases := make([]*Phrase, len(lines))
+	for i, line := range lines {
+		phrases[i] = &Phrase{
+			value:   line,
+			source:  source,
+			section: c.Classify(line),
+		}
+	}
+
+	indexes := make(chan int)
 	var wg sync.WaitGroup
-	for line := range textproc.ScanLines(res.Text()) {
+	for range min(runtime.GOMAXPROCS(0), len(lines)) {
 		wg.Add(1)
-
 		go func() {
 			defer wg.Done()
-			out <- &Phrase{line}
+
+			for i := range indexes {
+				phrases[i].language = textproc.DefaultLanguageDetector().Language(lines[i])
+			}
 		}()
 	}
+	for i := range lines {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
-	go func() {
-		wg.Wait()
-		close(out)
-	}()
-
-	return out, nil
+	return phrases
 }
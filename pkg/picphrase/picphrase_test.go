@@ -1,40 +1,230 @@
 package picphrase
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"image"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/kndrad/piccrack/pkg/imgprep"
+	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/ocr/ocrtest"
+	"github.com/kndrad/piccrack/pkg/pdftext"
+	"github.com/kndrad/piccrack/pkg/textproc"
 	"github.com/stretchr/testify/require"
 )
 
-func TestScanAtPath(t *testing.T) {
+const testText = `Your main tasks will be:
+Designing and developing scalable backend solutions using Go and Python.
+Building and maintaining high-load real-time systems.`
+
+func TestScanFilePath(t *testing.T) {
 	path := filepath.Join("testdata", "0.png")
 
-	phrases, err := ScanAt(context.Background(), path)
-	require.NoError(t, err)
+	e := ocrtest.NewEngine(testText)
 
-	i := 0
-	for range phrases {
-		i++
+	o := ScanFile(context.Background(), e, path, ocr.DefaultOptions())
+	require.NoError(t, o.Err)
+
+	for _, ph := range o.Phrases {
+		require.Equal(t, path, ph.Source())
+		require.Equal(t, "en", ph.Language())
 	}
 
-	require.Equal(t, 60, i)
+	require.Len(t, o.Phrases, 3)
+}
+
+func TestCollectDetectsLanguagePerLine(t *testing.T) {
+	text := "Oferujemy pracę zdalną w zespole\n" +
+		"Strong experience with distributed systems"
+
+	phrases := collect("offer.png", text, textproc.NewSectionClassifier())
+	require.Len(t, phrases, 2)
+	require.Equal(t, "pl", phrases[0].Language())
+	require.Equal(t, "en", phrases[1].Language())
+}
+
+func TestScanFilePDFSections(t *testing.T) {
+	path := filepath.Join("testdata", "offer.pdf")
+
+	o := ScanFile(context.Background(), ocrtest.NewEngine(testText), path, ocr.DefaultOptions())
+	require.NoError(t, o.Err)
+
+	sections := make(map[string]textproc.Section)
+	for _, ph := range o.Phrases {
+		sections[ph.String()] = ph.Section()
+	}
+
+	// Labeled lines don't open sections
+	require.Equal(t, map[string]textproc.Section{
+		"senior golang developer":          textproc.SectionNone,
+		"requirements: docker, kubernetes": textproc.SectionRequirements,
+		"nice to have: postgresql":         textproc.SectionNiceToHave,
+		"remote work":                      textproc.SectionNone,
+	}, sections)
+}
+
+func TestScanFileRegions(t *testing.T) {
+	path := filepath.Join("testdata", "0.png")
+
+	e := ocrtest.NewEngine(testText)
+
+	opts := ocr.DefaultOptions()
+	opts.Regions = []image.Rectangle{image.Rect(0, 0, 20, 20), image.Rect(20, 20, 40, 40)}
+
+	o := ScanFile(context.Background(), e, path, opts)
+	require.NoError(t, o.Err)
+
+	require.Equal(t, 2, e.Scans())
+	require.Len(t, o.Phrases, 6)
 }
 
 func TestScanInDir(t *testing.T) {
 	path := "testdata"
 
-	phrases, err := ScanDir(context.Background(), path)
+	e := ocrtest.NewEngine(testText)
+	p := ocr.NewPool(2, func() ocr.Engine { return e })
+
+	outcomes, errc := ScanDir(context.Background(), p, path, ocr.DefaultOptions())
+
+	files := 0
+	sources := make(map[string]int)
+	for out := range outcomes {
+		require.NoError(t, out.Err)
+		require.Equal(t, filepath.Ext(out.Path) == ".pdf", out.Result == nil)
+		for _, ph := range out.Phrases {
+			sources[ph.Source()]++
+		}
+		files++
+	}
+	require.NoError(t, <-errc)
+	require.Equal(t, 5, files)
+
+	require.Equal(t, 4, e.Scans())
+	require.Equal(t, map[string]int{
+		filepath.Join("testdata", "0.jpg"):            3,
+		filepath.Join("testdata", "0.png"):            3,
+		filepath.Join("testdata", "1.png"):            3,
+		filepath.Join("testdata", "2.png"):            3,
+		filepath.Join("testdata", "offer.pdf#page=1"): 2,
+		filepath.Join("testdata", "offer.pdf#page=2"): 2,
+	}, sources)
+}
+
+func TestScanInDirPartialFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	img, err := os.ReadFile(filepath.Join("testdata", "0.png"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0.png"), img, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.png"), img[:64], 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.pdf"), []byte("%PDF-1.4 garbage"), 0o600))
+
+	opts := ocr.DefaultOptions()
+	opts.Preprocess = imgprep.Grayscale // Requires decoding
+
+	outcomes, errc := ScanDir(context.Background(), ocrtest.NewEngine(testText), dir, opts)
+
+	failed := make(map[string]bool)
+	phrases := 0
+	for out := range outcomes {
+		if out.Err != nil {
+			failed[filepath.Base(out.Path)] = true
+
+			continue
+		}
+		phrases += len(out.Phrases)
+	}
+	require.NoError(t, <-errc)
+
+	require.Equal(t, map[string]bool{"corrupt.png": true, "corrupt.pdf": true}, failed)
+	require.Equal(t, 3, phrases)
+}
+
+func TestScanFile(t *testing.T) {
+	testCases := []struct {
+		desc string
+
+		path        string
+		wantPhrases int
+		wantResult  bool
+		wantErr     error
+	}{
+		{
+			desc: "image",
+
+			path:        filepath.Join("testdata", "0.png"),
+			wantPhrases: 3,
+			wantResult:  true,
+		},
+		{
+			desc: "pdf",
+
+			path:        filepath.Join("testdata", "offer.pdf"),
+			wantPhrases: 4,
+		},
+		{
+			desc: "missing_file",
+
+			path:    filepath.Join("testdata", "missing.png"),
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			o := ScanFile(context.Background(), ocrtest.NewEngine(testText), tC.path, ocr.DefaultOptions())
+
+			require.Equal(t, tC.path, o.Path)
+			require.ErrorIs(t, o.Err, tC.wantErr)
+			require.Len(t, o.Phrases, tC.wantPhrases)
+			require.Equal(t, tC.wantResult, o.Result != nil)
+		})
+	}
+}
+
+func TestScanFilePDF(t *testing.T) {
+	path := filepath.Join("testdata", "offer.pdf")
+
+	e := ocrtest.NewEngine(testText)
+
+	o := ScanFile(context.Background(), e, path, ocr.DefaultOptions())
+	require.NoError(t, o.Err)
+
+	values := make(map[string]string)
+	for _, ph := range o.Phrases {
+		values[ph.String()] = ph.Source()
+	}
+
+	require.Zero(t, e.Scans())
+	require.Equal(t, map[string]string{
+		"senior golang developer":          path + "#page=1",
+		"requirements: docker, kubernetes": path + "#page=1",
+		"nice to have: postgresql":         path + "#page=2",
+		"remote work":                      path + "#page=2",
+	}, values)
+}
+
+func TestScanPDF(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "offer.pdf"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	phrases, err := ScanPDF(context.Background(), f, "offer.pdf")
 	require.NoError(t, err)
 
 	i := 0
-	for range phrases {
+	for ph := range phrases {
+		require.Contains(t, []string{"offer.pdf#page=1", "offer.pdf#page=2"}, ph.Source())
 		i++
 	}
+	require.Equal(t, 4, i)
 
-	require.Equal(t, 191, i)
+	_, err = ScanPDF(context.Background(), bytes.NewReader([]byte("text")), "text.pdf")
+	require.ErrorIs(t, err, pdftext.ErrNotPDF)
 }
 
 func TestScanReader(t *testing.T) {
@@ -45,8 +235,9 @@ func TestScanReader(t *testing.T) {
 	defer f.Close()
 
 	ctx := context.Background()
+	e := ocrtest.NewEngine(testText)
 
-	phrases, err := ScanReader(ctx, f)
+	phrases, err := ScanReader(ctx, e, f, ocr.DefaultOptions())
 	require.NoError(t, err)
 
 	i := 0
@@ -54,5 +245,26 @@ func TestScanReader(t *testing.T) {
 		i++
 	}
 
-	require.Equal(t, 60, i)
+	require.Equal(t, 3, i)
+}
+
+func TestScanReaderKeepsOrderOfLines(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "0.png"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	want := make([]string, 0, 100)
+	for i := range 100 {
+		want = append(want, fmt.Sprintf("line %d of the offer", i))
+	}
+	e := ocrtest.NewEngine(strings.Join(want, "\n"))
+
+	phrases, err := ScanReader(context.Background(), e, f, ocr.DefaultOptions())
+	require.NoError(t, err)
+
+	got := make([]string, 0, len(want))
+	for ph := range phrases {
+		got = append(got, ph.String())
+	}
+	require.Equal(t, want, got)
 }
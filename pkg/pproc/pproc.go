@@ -12,6 +12,7 @@ import (
 type Entry struct {
 	path    string
 	content []byte
+	err     error
 }
 
 // Path returns the file path of the entry.
@@ -32,6 +33,16 @@ func (e *Entry) Content() []byte {
 	return e.content
 }
 
+// Err returns an error which occurred while reading the entry.
+// Entries with an error have no content.
+func (e *Entry) Err() error {
+	if e == nil {
+		return nil
+	}
+
+	return e.err
+}
+
 // FilterFunc defines a predicate for filtering file contents.
 //
 // Implementations should return true for contents that should be included
@@ -43,60 +54,84 @@ func NoFilter(data []byte) bool {
 	return data != nil
 }
 
+// Any returns a filter accepting data accepted by any of filters.
+func Any(filters ...FilterFunc) FilterFunc {
+	return func(data []byte) bool {
+		for _, f := range filters {
+			if f(data) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
 // Walk concurrently traverses root directory and streams filtered file entries.
 // Processes regular files only.
 // Caller must consume the channel to prevent leaks.
 //
-// Context cancellation stops the walk.
+// Files and directories which can't be read don't stop the walk, they are
+// streamed as entries with an error instead (see Entry.Err). Only failure
+// to walk the root itself or context cancellation stops the walk.
 func Walk(ctx context.Context, root string, f FilterFunc) (<-chan *Entry, error) {
 	c := make(chan *Entry)
-	errc := make(chan error)
 
-	go func() {
-		var wg sync.WaitGroup
+	var wg sync.WaitGroup
+	send := func(e *Entry) {
+		defer wg.Done()
 
-		errc <- filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return fmt.Errorf("walk: %w", err)
-			}
+		select {
+		case c <- e:
+		case <-ctx.Done():
+		}
+	}
 
-			if !info.Mode().IsRegular() {
-				return nil
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err != nil {
+			if path == root {
+				return fmt.Errorf("walk: %w", err)
 			}
-
 			wg.Add(1)
-			go func() {
-				defer wg.Done()
-
-				data, err := os.ReadFile(path)
-				if err != nil {
-					errc <- fmt.Errorf("read %s: %w", path, err)
-
-					return
-				}
-				if f(data) {
-					select {
-					case c <- &Entry{path, data}:
-					case <-ctx.Done():
-						errc <- ctx.Err()
-
-						return
-					}
-				}
-			}()
+			go send(&Entry{path: path, err: err})
+
+			return nil
+		}
 
+		if !info.Mode().IsRegular() {
 			return nil
-		})
+		}
 
+		wg.Add(1)
 		go func() {
-			wg.Wait()
-			close(c)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				send(&Entry{path: path, err: fmt.Errorf("read: %w", err)})
+
+				return
+			}
+			if !f(data) {
+				wg.Done()
+
+				return
+			}
+			send(&Entry{path: path, content: data})
 		}()
+
+		return nil
+	})
+
+	go func() {
+		wg.Wait()
+		close(c)
 	}()
 
-	if err := <-errc; err == nil {
-		return c, nil
-	} else {
+	if err != nil {
 		return nil, fmt.Errorf("err during walk: %w", err)
 	}
+
+	return c, nil
 }
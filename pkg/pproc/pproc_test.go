@@ -2,9 +2,12 @@ package pproc_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/kndrad/piccrack/pkg/ocr"
+	"github.com/kndrad/piccrack/pkg/pdftext"
 	"github.com/kndrad/piccrack/pkg/pproc"
 	"github.com/stretchr/testify/require"
 )
@@ -21,7 +24,7 @@ func TestWalkWithNoFilter(t *testing.T) {
 	for range entries {
 		total++
 	}
-	require.Equal(t, 5, total)
+	require.Equal(t, 6, total)
 }
 
 func TestWalkWithImageFilter(t *testing.T) {
@@ -36,3 +39,56 @@ func TestWalkWithImageFilter(t *testing.T) {
 		require.True(t, ocr.IsImage(e.Content()))
 	}
 }
+
+func TestWalkWithAnyFilter(t *testing.T) {
+	t.Parallel()
+
+	root := "testdata"
+
+	entries, err := pproc.Walk(context.Background(), root, pproc.Any(ocr.IsImage, pdftext.IsPDF))
+	require.NoError(t, err)
+
+	images, pdfs := 0, 0
+	for e := range entries {
+		switch {
+		case ocr.IsImage(e.Content()):
+			images++
+		case pdftext.IsPDF(e.Content()):
+			pdfs++
+		default:
+			t.Fatalf("unexpected entry: %s", e.Path())
+		}
+	}
+	require.Equal(t, 4, images)
+	require.Equal(t, 1, pdfs)
+}
+
+func TestWalkMissingRoot(t *testing.T) {
+	t.Parallel()
+
+	_, err := pproc.Walk(context.Background(), filepath.Join("testdata", "missing"), pproc.NoFilter)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestWalkStreamsUnreadableFiles(t *testing.T) {
+	t.Parallel()
+
+	if os.Geteuid() == 0 {
+		t.Skip("file permissions are not enforced for root")
+	}
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "readable.txt"), []byte("text"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "unreadable.txt"), []byte("text"), 0o000))
+
+	entries, err := pproc.Walk(context.Background(), root, pproc.NoFilter)
+	require.NoError(t, err)
+
+	errs := make(map[string]error)
+	for e := range entries {
+		errs[filepath.Base(e.Path())] = e.Err()
+	}
+	require.Len(t, errs, 2)
+	require.NoError(t, errs["readable.txt"])
+	require.ErrorIs(t, errs["unreadable.txt"], os.ErrPermission)
+}
@@ -0,0 +1,171 @@
+package textproc
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+//go:embed aliases.txt
+var defaultAliases []byte
+
+var ErrInvalidAliases = errors.New("invalid aliases")
+
+// Aliases maps alternative spellings of terms to their canonical form,
+// e.g. "k8s" to "kubernetes". Keys and values are lower case.
+type Aliases map[string]string
+
+// DefaultAliases returns aliases of common technologies shipped with
+// the package.
+func DefaultAliases() Aliases {
+	a, err := ParseAliases(bytes.NewReader(defaultAliases))
+	if err != nil {
+		panic(fmt.Sprintf("default aliases: %v", err))
+	}
+
+	return a
+}
+
+// LoadAliases returns DefaultAliases overridden by aliases read from file at
+// path, see Aliases.Override. Empty path returns DefaultAliases.
+func LoadAliases(path string) (Aliases, error) {
+	a := DefaultAliases()
+	if path == "" {
+		return a, nil
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer f.Close()
+
+	override, err := ParseAliases(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	a.Override(override)
+
+	return a, nil
+}
+
+// ParseAliases parses lines of format "canonical: alias, alias".
+// Blank lines and lines starting with # are skipped. A canonical term
+// without aliases maps to itself.
+func ParseAliases(r io.Reader) (Aliases, error) {
+	a := make(Aliases)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		canonical, list, ok := strings.Cut(line, ":")
+		canonical = strings.ToLower(strings.TrimSpace(canonical))
+		if !ok || canonical == "" {
+			return nil, fmt.Errorf("%w: line %d: want canonical: alias, alias", ErrInvalidAliases, n)
+		}
+		if v, ok := a[canonical]; ok && v != canonical {
+			return nil, fmt.Errorf("%w: line %d: %q is an alias of %q", ErrInvalidAliases, n, canonical, v)
+		}
+		a[canonical] = canonical
+
+		for _, alias := range strings.Split(list, ",") {
+			alias = strings.ToLower(strings.TrimSpace(alias))
+			if alias == "" || alias == canonical {
+				continue
+			}
+			if v, ok := a[alias]; ok {
+				return nil, fmt.Errorf("%w: line %d: %q is already mapped to %q", ErrInvalidAliases, n, alias, v)
+			}
+			a[alias] = canonical
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+
+	return a, nil
+}
+
+// Override replaces aliases of every canonical term of other.
+// Terms canonical in other are no longer aliases of anything.
+func (a Aliases) Override(other Aliases) {
+	for alias, canonical := range other {
+		if alias == canonical {
+			// Previous aliases of the term are dropped too
+			maps.DeleteFunc(a, func(_, v string) bool { return v == canonical })
+		}
+	}
+	maps.Copy(a, other)
+
+	// Aliases of terms which became aliases themselves point to
+	// the new canonical term
+	for alias, canonical := range a {
+		if v, ok := other[canonical]; ok && v != canonical {
+			a[alias] = v
+		}
+	}
+}
+
+// Normalize returns canonical form of word, word itself if it has none.
+func (a Aliases) Normalize(word string) string {
+	if canonical, ok := a[strings.ToLower(word)]; ok {
+		return canonical
+	}
+
+	return word
+}
+
+// NormalizeAll returns canonical forms of words.
+func (a Aliases) NormalizeAll(words []string) []string {
+	normalized := make([]string, len(words))
+	for i, w := range words {
+		normalized[i] = a.Normalize(w)
+	}
+
+	return normalized
+}
+
+// Terms returns sorted aliases of every canonical term, sorted too.
+func (a Aliases) Terms() map[string][]string {
+	terms := make(map[string][]string)
+	for alias, canonical := range a {
+		if alias != canonical {
+			terms[canonical] = append(terms[canonical], alias)
+		}
+	}
+	for _, aliases := range terms {
+		slices.Sort(aliases)
+	}
+
+	return terms
+}
+
+// Merges counts raw forms of words merged into each canonical term by
+// normalization. Words which are already canonical are not counted.
+func (a Aliases) Merges(words []string) map[string]map[string]int {
+	merges := make(map[string]map[string]int)
+	for _, w := range words {
+		canonical := a.Normalize(w)
+		if canonical == w {
+			continue
+		}
+		if merges[canonical] == nil {
+			merges[canonical] = make(map[string]int)
+		}
+		merges[canonical][w]++
+	}
+
+	return merges
+}
@@ -0,0 +1,118 @@
+package textproc_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultAliases(t *testing.T) {
+	t.Parallel()
+
+	a := textproc.DefaultAliases()
+
+	require.Equal(t, "kubernetes", a.Normalize("k8s"))
+	require.Equal(t, "go", a.Normalize("Golang"))
+	require.Equal(t, "postgresql", a.Normalize("postgres"))
+	require.Equal(t, "postgresql", a.Normalize("postgresql"))
+	require.Equal(t, "docker", a.Normalize("docker"))
+
+	require.Equal(t,
+		[]string{"go", "kubernetes", "and", "postgresql"},
+		a.NormalizeAll(textproc.Tokens("Golang, K8s and Postgres.")),
+	)
+}
+
+func TestParseAliases(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		text    string
+		want    textproc.Aliases
+		wantErr error
+	}{
+		{
+			desc: "canonical_and_aliases",
+
+			text: "# comment\n\nKubernetes: k8s, Kube\nterraform:\n",
+			want: textproc.Aliases{
+				"kubernetes": "kubernetes",
+				"k8s":        "kubernetes",
+				"kube":       "kubernetes",
+				"terraform":  "terraform",
+			},
+		},
+		{
+			desc: "missing_colon",
+
+			text:    "kubernetes k8s",
+			wantErr: textproc.ErrInvalidAliases,
+		},
+		{
+			desc: "alias_of_two_terms",
+
+			text:    "go: golang\ngolang-lang: golang",
+			wantErr: textproc.ErrInvalidAliases,
+		},
+		{
+			desc: "canonical_term_is_an_alias",
+
+			text:    "go: golang\ngolang: gopher",
+			wantErr: textproc.ErrInvalidAliases,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := textproc.ParseAliases(strings.NewReader(tC.text))
+			require.ErrorIs(t, err, tC.wantErr)
+			require.Equal(t, tC.want, a)
+		})
+	}
+}
+
+func TestLoadAliases(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "aliases.txt")
+	data := []byte("golang:\nk8s: kubernetes, kates\n")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	a, err := textproc.LoadAliases(path)
+	require.NoError(t, err)
+
+	require.Equal(t, "golang", a.Normalize("golang"), "golang is canonical now")
+	require.Equal(t, "go", a.Normalize("go"))
+	require.Equal(t, "k8s", a.Normalize("kates"))
+	require.Equal(t, "k8s", a.Normalize("kubernetes"), "former term is an alias of the new one")
+	require.Equal(t, "postgresql", a.Normalize("postgres"), "defaults are kept")
+
+	a, err = textproc.LoadAliases("")
+	require.NoError(t, err)
+	require.Equal(t, textproc.DefaultAliases(), a)
+
+	_, err = textproc.LoadAliases(filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAliasesMerges(t *testing.T) {
+	t.Parallel()
+
+	a := textproc.DefaultAliases()
+	words := textproc.Tokens("k8s kubernetes K8s golang go postgres psql docker")
+
+	require.Equal(t, map[string]map[string]int{
+		"kubernetes": {"k8s": 2},
+		"go":         {"golang": 1},
+		"postgresql": {"postgres": 1, "psql": 1},
+	}, a.Merges(words))
+
+	require.Equal(t, []string{"k8s"}, a.Terms()["kubernetes"])
+}
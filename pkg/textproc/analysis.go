@@ -33,6 +33,9 @@ func AnalyzeWordsFrequency(data []string) (*TextAnalysis, error) {
 type TextAnalysis struct {
 	ID            string         `json:"id"`
 	WordFrequency map[string]int `json:"wordFrequency"`
+	// Stemmers are names of stemmers which produced words, none if
+	// words were not stemmed, see Stemming.
+	Stemmers []string `json:"stemmers,omitempty"`
 
 	mu sync.Mutex
 }
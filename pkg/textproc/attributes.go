@@ -0,0 +1,317 @@
+package textproc
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Attribute is a named value describing a whole job offer, e.g. its work
+// mode, rather than a single phrase of it.
+type Attribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Names of attributes of job offers.
+const (
+	AttributeWorkMode   = "work_mode"
+	AttributeContract   = "contract"
+	AttributeEmployment = "employment"
+	AttributeCity       = "city"
+	AttributePostalCode = "postal_code"
+)
+
+// AttributeNames are names of all attributes of job offers.
+var AttributeNames = []string{
+	AttributeWorkMode,
+	AttributeContract,
+	AttributeEmployment,
+	AttributeCity,
+	AttributePostalCode,
+}
+
+var ErrInvalidAttribute = errors.New("invalid attribute")
+
+// WorkMode is a mode of work of a job offer.
+type WorkMode string
+
+const (
+	WorkModeRemote WorkMode = "remote"
+	WorkModeHybrid WorkMode = "hybrid"
+	WorkModeOnsite WorkMode = "onsite"
+)
+
+// Contract is a type of contract of employment, empty if not stated.
+type Contract string
+
+const (
+	ContractNone Contract = ""
+	// ContractB2B is a business to business contract.
+	ContractB2B Contract = "b2b"
+	// ContractEmployment is a contract of employment, "umowa o pracę".
+	ContractEmployment Contract = "uop"
+	// ContractMandate is a contract of mandate, "umowa zlecenie".
+	ContractMandate Contract = "uz"
+)
+
+// Employment is a working time of a job offer.
+type Employment string
+
+const (
+	EmploymentFullTime   Employment = "full_time"
+	EmploymentPartTime   Employment = "part_time"
+	EmploymentInternship Employment = "internship"
+)
+
+// phraseGroup is a value stated by any of its phrases. Words of phrases
+// are separated by spaces, a word ending with "*" matches any word it
+// begins, e.g. "zdaln*" matches "zdalnie".
+type phraseGroup[T any] struct {
+	value   T
+	phrases []string
+}
+
+// workModePhrases are phrases of English and Polish lines stating a work
+// mode, checked in order, e.g. "hybrid remote" is hybrid, not remote.
+var workModePhrases = []phraseGroup[WorkMode]{
+	{
+		value:   WorkModeHybrid,
+		phrases: []string{"hybrid remote", "hybrid", "hybryd*", "częściowo zdaln*"},
+	},
+	{
+		value:   WorkModeRemote,
+		phrases: []string{"fully remote", "remote", "work from home", "wfh", "zdaln*"},
+	},
+	{
+		value:   WorkModeOnsite,
+		phrases: []string{"on site", "onsite", "in office", "stacjonarn*", "w biurze"},
+	},
+}
+
+// contractPhrases are phrases of English and Polish lines stating
+// a contract, checked in order.
+var contractPhrases = []phraseGroup[Contract]{
+	{
+		value:   ContractB2B,
+		phrases: []string{"b2b"},
+	},
+	{
+		value:   ContractMandate,
+		phrases: []string{"umowa zlecenie", "umowę zlecenie", "umowy zlecenie", "umowie zlecenie", "mandate contract"},
+	},
+	{
+		value: ContractEmployment,
+		phrases: []string{
+			"umowa o pracę", "umowę o pracę", "umowy o pracę", "umowie o pracę", "uop", "employment contract",
+			"contract of employment", "permanent contract",
+		},
+	},
+}
+
+// employmentPhrases are phrases of English and Polish lines stating
+// a working time, checked in order, e.g. "niepełny etat" is part-time.
+var employmentPhrases = []phraseGroup[Employment]{
+	{
+		value:   EmploymentPartTime,
+		phrases: []string{"part time", "niepełny etat", "niepełnym etacie", "pół etatu"},
+	},
+	{
+		value:   EmploymentFullTime,
+		phrases: []string{"full time", "pełny etat", "pełnym etacie", "pełen etat"},
+	},
+	{
+		value:   EmploymentInternship,
+		phrases: []string{"internship", "praktyki studenckie", "płatny staż", "program stażowy"},
+	},
+}
+
+// matchPhrases returns values of groups stated by whole words of a line,
+// each once, in order of groups. Words of a matched phrase are removed, so
+// that shorter phrases of other groups do not match them, e.g. "remote"
+// of "hybrid remote".
+func matchPhrases[T comparable](words []string, groups []phraseGroup[T]) []T {
+	words = slices.Clone(words)
+
+	values := make([]T, 0)
+	for _, g := range groups {
+		for _, phrase := range g.phrases {
+			if !removePhrase(words, strings.Fields(phrase)) {
+				continue
+			}
+			if !slices.Contains(values, g.value) {
+				values = append(values, g.value)
+			}
+		}
+	}
+
+	return values
+}
+
+// removePhrase empties every sequence of words matching words of a phrase
+// and reports whether there was any.
+func removePhrase(words, phrase []string) bool {
+	found := false
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if !slices.EqualFunc(words[i:i+len(phrase)], phrase, matchWord) {
+			continue
+		}
+		for j := range phrase {
+			words[i+j] = ""
+		}
+		found = true
+	}
+
+	return found
+}
+
+// matchWord reports whether a word of a line matches a word of a phrase.
+func matchWord(word, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return word != "" && strings.HasPrefix(word, prefix)
+	}
+
+	return word == pattern
+}
+
+// lineWords splits a lowercase line into words of letters and digits, e.g.
+// "on-site" into "on" and "site".
+func lineWords(line string) []string {
+	return strings.FieldsFunc(line, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// postalCodeRe matches Polish postal codes, e.g. "00-850".
+var postalCodeRe = regexp.MustCompile(`\d{2}-\d{3}`)
+
+// cities are English and Polish forms of names of the largest Polish
+// cities, keyed by their lowercase forms.
+var cities = map[string]string{
+	"warszawa": "Warszawa", "warszawie": "Warszawa", "warsaw": "Warszawa",
+	"kraków": "Kraków", "krakowie": "Kraków", "krakow": "Kraków", "cracow": "Kraków",
+	"wrocław": "Wrocław", "wrocławiu": "Wrocław", "wroclaw": "Wrocław",
+	"poznań": "Poznań", "poznaniu": "Poznań", "poznan": "Poznań",
+	"gdańsk": "Gdańsk", "gdańsku": "Gdańsk", "gdansk": "Gdańsk",
+	"gdynia": "Gdynia", "gdyni": "Gdynia",
+	"łódź": "Łódź", "łodzi": "Łódź", "lodz": "Łódź",
+	"katowice": "Katowice", "katowicach": "Katowice",
+	"szczecin": "Szczecin", "szczecinie": "Szczecin",
+	"lublin": "Lublin", "lublinie": "Lublin",
+	"białystok": "Białystok", "białymstoku": "Białystok", "bialystok": "Białystok",
+	"bydgoszcz": "Bydgoszcz", "bydgoszczy": "Bydgoszcz",
+	"rzeszów": "Rzeszów", "rzeszowie": "Rzeszów", "rzeszow": "Rzeszów",
+	"gliwice": "Gliwice", "gliwicach": "Gliwice",
+	"toruń": "Toruń", "toruniu": "Toruń", "torun": "Toruń",
+}
+
+// LineAttributes returns attributes of a job offer stated in a line, e.g.
+// "Work Location: Hybrid remote in 00-850 Warszawa" states a hybrid work
+// mode, a postal code and a city.
+func LineAttributes(line string) []Attribute {
+	lower := strings.ToLower(line)
+	words := lineWords(lower)
+
+	attrs := make([]Attribute, 0)
+	for _, m := range matchPhrases(words, workModePhrases) {
+		attrs = append(attrs, Attribute{Name: AttributeWorkMode, Value: string(m)})
+	}
+	for _, c := range matchPhrases(words, contractPhrases) {
+		attrs = append(attrs, Attribute{Name: AttributeContract, Value: string(c)})
+	}
+	for _, e := range matchPhrases(words, employmentPhrases) {
+		attrs = append(attrs, Attribute{Name: AttributeEmployment, Value: string(e)})
+	}
+	for _, code := range postalCodes(lower) {
+		attrs = append(attrs, Attribute{Name: AttributePostalCode, Value: code})
+	}
+	for _, city := range lineCities(words) {
+		attrs = append(attrs, Attribute{Name: AttributeCity, Value: city})
+	}
+
+	return attrs
+}
+
+// Attributes returns attributes of a job offer stated in its lines, each
+// once, sorted by name and value.
+func Attributes(lines []string) []Attribute {
+	attrs := make([]Attribute, 0)
+	for _, line := range lines {
+		attrs = append(attrs, LineAttributes(line)...)
+	}
+	slices.SortFunc(attrs, func(a, b Attribute) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Value, b.Value))
+	})
+
+	return slices.Compact(attrs)
+}
+
+// ParseAttribute returns an attribute named name of a known value, e.g.
+// a city named "Warsaw" is "Warszawa".
+func ParseAttribute(name, value string) (Attribute, error) {
+	lower := strings.ToLower(strings.TrimSpace(value))
+
+	var ok bool
+	switch name {
+	case AttributeWorkMode:
+		ok = hasValue(workModePhrases, WorkMode(lower))
+	case AttributeContract:
+		ok = hasValue(contractPhrases, Contract(lower))
+	case AttributeEmployment:
+		ok = hasValue(employmentPhrases, Employment(lower))
+	case AttributeCity:
+		var city string
+		if city, ok = cities[lower]; ok {
+			lower = city
+		}
+	case AttributePostalCode:
+		ok = len(lower) == 6 && postalCodeRe.MatchString(lower)
+	default:
+		return Attribute{}, fmt.Errorf("%w: unknown name %q", ErrInvalidAttribute, name)
+	}
+	if !ok {
+		return Attribute{}, fmt.Errorf("%w: %s %q", ErrInvalidAttribute, name, value)
+	}
+
+	return Attribute{Name: name, Value: lower}, nil
+}
+
+// hasValue reports whether v is a value of any of groups.
+func hasValue[T comparable](groups []phraseGroup[T], v T) bool {
+	return slices.ContainsFunc(groups, func(g phraseGroup[T]) bool {
+		return g.value == v
+	})
+}
+
+// postalCodes returns postal codes of a line not glued to other digits,
+// e.g. of phone numbers.
+func postalCodes(line string) []string {
+	codes := make([]string, 0)
+	for _, loc := range postalCodeRe.FindAllStringIndex(line, -1) {
+		before, _ := utf8.DecodeLastRuneInString(line[:loc[0]])
+		after, _ := utf8.DecodeRuneInString(line[loc[1]:])
+		if unicode.IsDigit(before) || unicode.IsDigit(after) || before == '-' || after == '-' {
+			continue
+		}
+		codes = append(codes, line[loc[0]:loc[1]])
+	}
+
+	return codes
+}
+
+// lineCities returns known cities named by words of a line.
+func lineCities(words []string) []string {
+	found := make([]string, 0)
+	for _, w := range words {
+		if city, ok := cities[w]; ok && !slices.Contains(found, city) {
+			found = append(found, city)
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,172 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineAttributes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		line string
+		want []textproc.Attribute
+	}{
+		{
+			desc: "hybrid_remote_with_postal_code_and_city",
+
+			line: "Work Location: Hybrid remote in 00-850 Warszawa",
+			want: []textproc.Attribute{
+				{Name: textproc.AttributeWorkMode, Value: "hybrid"},
+				{Name: textproc.AttributePostalCode, Value: "00-850"},
+				{Name: textproc.AttributeCity, Value: "Warszawa"},
+			},
+		},
+		{
+			desc: "polish_remote_b2b",
+
+			line: "Praca zdalna, kontrakt B2B lub umowa o pracę",
+			want: []textproc.Attribute{
+				{Name: textproc.AttributeWorkMode, Value: "remote"},
+				{Name: textproc.AttributeContract, Value: "b2b"},
+				{Name: textproc.AttributeContract, Value: "uop"},
+			},
+		},
+		{
+			desc: "part_time_is_not_full_time",
+
+			line: "Wymiar pracy: niepełny etat, praca stacjonarna w Krakowie",
+			want: []textproc.Attribute{
+				{Name: textproc.AttributeWorkMode, Value: "onsite"},
+				{Name: textproc.AttributeEmployment, Value: "part_time"},
+				{Name: textproc.AttributeCity, Value: "Kraków"},
+			},
+		},
+		{
+			desc: "full_time",
+
+			line: "Job Type: Full-time",
+			want: []textproc.Attribute{
+				{Name: textproc.AttributeEmployment, Value: "full_time"},
+			},
+		},
+		{
+			desc: "partly_remote_is_hybrid",
+
+			line: "Praca częściowo zdalna, umowa zlecenie",
+			want: []textproc.Attribute{
+				{Name: textproc.AttributeWorkMode, Value: "hybrid"},
+				{Name: textproc.AttributeContract, Value: "uz"},
+			},
+		},
+		{
+			desc: "good_practices_are_not_internship",
+
+			line: "Znasz dobre praktyki programowania",
+			want: []textproc.Attribute{},
+		},
+		{
+			desc: "word_containing_a_phrase",
+
+			line: "Praca nad zleceniem klienta, remotely managed servers",
+			want: []textproc.Attribute{},
+		},
+		{
+			desc: "phone_number_is_not_postal_code",
+
+			line: "Call us: +48 12-345-678",
+			want: []textproc.Attribute{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tC.want, textproc.LineAttributes(tC.line))
+		})
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{
+		"Job Type: Full-time",
+		"Remote, B2B",
+		"100% remote work",
+	}
+
+	want := []textproc.Attribute{
+		{Name: textproc.AttributeContract, Value: "b2b"},
+		{Name: textproc.AttributeEmployment, Value: "full_time"},
+		{Name: textproc.AttributeWorkMode, Value: "remote"},
+	}
+	require.Equal(t, want, textproc.Attributes(lines))
+}
+
+func TestParseAttribute(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		name    string
+		value   string
+		want    textproc.Attribute
+		wantErr error
+	}{
+		{
+			desc: "work_mode",
+
+			name:  textproc.AttributeWorkMode,
+			value: "Remote",
+			want:  textproc.Attribute{Name: textproc.AttributeWorkMode, Value: "remote"},
+		},
+		{
+			desc: "english_name_of_city",
+
+			name:  textproc.AttributeCity,
+			value: "Warsaw",
+			want:  textproc.Attribute{Name: textproc.AttributeCity, Value: "Warszawa"},
+		},
+		{
+			desc: "postal_code",
+
+			name:  textproc.AttributePostalCode,
+			value: "00-850",
+			want:  textproc.Attribute{Name: textproc.AttributePostalCode, Value: "00-850"},
+		},
+		{
+			desc: "unknown_contract",
+
+			name:    textproc.AttributeContract,
+			value:   "freelance",
+			wantErr: textproc.ErrInvalidAttribute,
+		},
+		{
+			desc: "unknown_name",
+
+			name:    "salary",
+			value:   "high",
+			wantErr: textproc.ErrInvalidAttribute,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := textproc.ParseAttribute(tC.name, tC.value)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tC.want, a)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package textproc
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Experience is a number of years of experience required by a line of a job
+// offer, e.g. "3+ years of Go" or "2-3 lata doświadczenia w Javie".
+type Experience struct {
+	// Term is a technology of a taxonomy nearest to the requirement on its
+	// line, empty if there is none.
+	Term     string `json:"term,omitempty"`
+	MinYears int    `json:"min_years"`
+	// MaxYears is 0 if the requirement is open-ended, e.g. "at least 5 years".
+	MaxYears int `json:"max_years,omitempty"`
+	// Source is the line the requirement is found in.
+	Source string `json:"source"`
+}
+
+// experienceYears are English and Polish words of years.
+var experienceYears = map[string]bool{
+	"year": true, "years": true, "yr": true, "yrs": true, "yoe": true,
+	"rok": true, "roku": true, "lata": true, "lat": true,
+}
+
+// experienceMinimums are English and Polish qualifiers of open-ended
+// requirements, e.g. "at least" of "at least 5 years".
+var experienceMinimums = [][]string{
+	{"at", "least"},
+	{"more", "than"},
+	{"minimum"},
+	{"min"},
+	{"over"},
+	{"co", "najmniej"},
+	{"przynajmniej"},
+	{"powyżej"},
+	{"ponad"},
+	{"od"},
+}
+
+// experienceRangeWords join amounts of years of a range, e.g. "3 to 5 years".
+var experienceRangeWords = map[string]bool{"to": true, "do": true}
+
+// experienceYearsRe matches tokens of years, e.g. "3", "3+" and "2-3".
+var experienceYearsRe = regexp.MustCompile(`^(\d{1,2})(?:[-–](\d{1,2}))?(\+)?$`)
+
+// ExperienceExtractor finds years of experience required by lines of job
+// offers and links them to technologies of a taxonomy.
+//
+// ExperienceExtractor is safe for concurrent use.
+type ExperienceExtractor struct {
+	taxonomy    Taxonomy
+	aliases     Aliases
+	inflections map[string]string
+	longest     int
+}
+
+// NewExperienceExtractor returns an extractor linking requirements to terms
+// of t, found in tokens normalized with a.
+func NewExperienceExtractor(t Taxonomy, a Aliases) *ExperienceExtractor {
+	return &ExperienceExtractor{
+		taxonomy:    t,
+		aliases:     a,
+		inflections: polishInflections(t),
+		longest:     longestTerm(t),
+	}
+}
+
+// Line returns years of experience required by a line, each linked to
+// a technology nearest to it, the following one if two are equally near.
+// Ranges, e.g. "2-3 years", "3+" and qualifiers, e.g. "at least 5 years"
+// or "co najmniej 3 lata", are recognized. Technologies named in Polish
+// cases, e.g. "w Javie", are linked too.
+func (e *ExperienceExtractor) Line(line string) []Experience {
+	tokens := e.aliases.NormalizeAll(Tokens(line))
+	for i, token := range tokens {
+		if term, ok := e.inflections[token]; ok {
+			tokens[i] = term
+		}
+	}
+	positions := termPositions(e.taxonomy, e.longest, tokens)
+
+	experiences := make([]Experience, 0)
+	for i, token := range tokens {
+		if !experienceYears[token] || i == 0 {
+			continue
+		}
+		x, start, ok := parseExperience(tokens[:i])
+		if !ok {
+			continue
+		}
+		x.Term = nearestTerm(positions, start, i)
+		x.Source = strings.TrimSpace(line)
+		experiences = append(experiences, x)
+	}
+
+	return experiences
+}
+
+// Experiences returns years of experience required by lines of text, see
+// ScanLines.
+func (e *ExperienceExtractor) Experiences(text string) []Experience {
+	experiences := make([]Experience, 0)
+	for line := range ScanLines(text) {
+		experiences = append(experiences, e.Line(line)...)
+	}
+
+	return experiences
+}
+
+// parseExperience parses years of experience preceding a word of years,
+// the last of tokens, and returns index of the first token of it.
+func parseExperience(tokens []string) (Experience, int, bool) {
+	var x Experience
+
+	end := len(tokens) - 1
+	m := experienceYearsRe.FindStringSubmatch(tokens[end])
+	if m == nil {
+		return x, 0, false
+	}
+	x.MinYears, _ = strconv.Atoi(m[1])
+	start := end
+	switch {
+	case m[2] != "":
+		x.MaxYears, _ = strconv.Atoi(m[2])
+	case m[3] == "" && end > 0:
+		// "3 to 5 years", or "3 - 5 years" of which a dash is dropped
+		lower := end - 1
+		if experienceRangeWords[tokens[lower]] && lower > 0 {
+			lower--
+		}
+		if n, ok := yearsOf(tokens[lower]); ok {
+			x.MinYears, x.MaxYears = n, x.MinYears
+			start = lower
+		} else {
+			x.MaxYears = x.MinYears
+		}
+	case m[3] == "":
+		x.MaxYears = x.MinYears
+	}
+	if x.MaxYears == x.MinYears && hasMinimum(tokens[:start]) {
+		x.MaxYears = 0
+	}
+	if x.MaxYears != 0 && x.MaxYears < x.MinYears {
+		x.MinYears, x.MaxYears = x.MaxYears, x.MinYears
+	}
+
+	return x, start, x.MinYears > 0 || x.MaxYears > 0
+}
+
+// yearsOf parses a token of a plain number of years.
+func yearsOf(token string) (int, bool) {
+	m := experienceYearsRe.FindStringSubmatch(token)
+	if m == nil || m[2] != "" || m[3] != "" {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(m[1])
+
+	return n, true
+}
+
+// hasMinimum reports whether tokens end with a qualifier of an open-ended
+// requirement.
+func hasMinimum(tokens []string) bool {
+	for _, q := range experienceMinimums {
+		if len(tokens) >= len(q) && slices.Equal(tokens[len(tokens)-len(q):], q) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Endings of Polish cases of names of technologies, replacing the final
+// "a" of feminine names, e.g. "javie", or following other names, e.g.
+// "pythonie".
+var (
+	polishFeminineEndings  = []string{"y", "i", "ie", "ę", "ą", "o"}
+	polishMasculineEndings = []string{"a", "u", "em", "iem", "ie", "owi"}
+)
+
+// A term shorter than minInflectedTerm letters, e.g. "go", is not declined.
+const minInflectedTerm = 3
+
+// polishInflections maps Polish case forms of single word terms of t to
+// the terms, e.g. "javie" to "java". Terms ending with other vowels or
+// holding symbols, e.g. "c++", are not declined, and forms which are terms
+// themselves are not inflections.
+func polishInflections(t Taxonomy) map[string]string {
+	inflections := make(map[string]string)
+	for term := range t {
+		if utf8.RuneCountInString(term) < minInflectedTerm || strings.ContainsFunc(term, isNotLetter) {
+			continue
+		}
+		stem, endings := term, polishMasculineEndings
+		if s, ok := strings.CutSuffix(term, "a"); ok {
+			stem, endings = s, polishFeminineEndings
+		} else if strings.ContainsAny(term[len(term)-1:], "eiouy") {
+			continue
+		}
+		for _, ending := range endings {
+			form := stem + ending
+			if _, ok := t[form]; ok {
+				continue
+			}
+			// Keep the same inflection whatever the order of terms
+			if other, ok := inflections[form]; ok && other < term {
+				continue
+			}
+			inflections[form] = term
+		}
+	}
+
+	return inflections
+}
+
+func isNotLetter(r rune) bool {
+	return !unicode.IsLetter(r)
+}
+
+// termPosition is a term of a taxonomy found at a token index.
+type termPosition struct {
+	term  string
+	index int
+	// size is a number of tokens of the term.
+	size int
+}
+
+// longestTerm returns number of words of the longest term of t.
+func longestTerm(t Taxonomy) int {
+	longest := 1
+	for term := range t {
+		longest = max(longest, strings.Count(term, " ")+1)
+	}
+
+	return longest
+}
+
+// termPositions returns terms of t of at most longest words found in
+// tokens, the longest matching n-gram first, see Taxonomy.Match.
+func termPositions(t Taxonomy, longest int, tokens []string) []termPosition {
+	positions := make([]termPosition, 0)
+	for i := 0; i < len(tokens); {
+		n := min(longest, len(tokens)-i)
+		for ; n > 0; n-- {
+			term := strings.Join(tokens[i:i+n], " ")
+			if _, ok := t[term]; ok {
+				positions = append(positions, termPosition{term: term, index: i, size: n})
+
+				break
+			}
+		}
+		i += max(n, 1)
+	}
+
+	return positions
+}
+
+// nearestTerm returns a term nearest to tokens from start to end, the one
+// following them if two are equally near.
+func nearestTerm(positions []termPosition, start, end int) string {
+	term, distance := "", -1
+	for _, p := range positions {
+		d := start - p.index
+		if p.index > end {
+			d = p.index - end
+		}
+		if d <= 0 {
+			continue
+		}
+		if distance < 0 || d < distance || (d == distance && p.index > end) {
+			term, distance = p.term, d
+		}
+	}
+
+	return term
+}
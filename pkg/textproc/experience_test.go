@@ -0,0 +1,101 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperienceExtractorLine(t *testing.T) {
+	t.Parallel()
+
+	e := textproc.NewExperienceExtractor(textproc.DefaultTaxonomy(), textproc.DefaultAliases())
+
+	testCases := []struct {
+		desc string
+
+		line string
+		want []textproc.Experience
+	}{
+		{
+			desc: "plus_before_a_word_of_years",
+
+			line: "Proficiency in Go (3+ years) and Python",
+			want: []textproc.Experience{{Term: "go", MinYears: 3}},
+		},
+		{
+			desc: "range_followed_by_a_technology",
+
+			line: "2-3 years of Golang",
+			want: []textproc.Experience{{Term: "go", MinYears: 2, MaxYears: 3}},
+		},
+		{
+			desc: "at_least_without_a_technology",
+
+			line: "At least 5 years of commercial development experience.",
+			want: []textproc.Experience{{MinYears: 5}},
+		},
+		{
+			desc: "range_with_a_dash_between_words",
+
+			line: "Python developer with 3 – 5 years of Kubernetes",
+			want: []textproc.Experience{{Term: "kubernetes", MinYears: 3, MaxYears: 5}},
+		},
+		{
+			desc: "polish_minimum",
+
+			line: "Doświadczenie komercyjne w Java, co najmniej 3 lata",
+			want: []textproc.Experience{{Term: "java", MinYears: 3}},
+		},
+		{
+			desc: "polish_range",
+
+			line: "Od 2 do 4 lat doświadczenia z PostgreSQL",
+			want: []textproc.Experience{{Term: "postgresql", MinYears: 2, MaxYears: 4}},
+		},
+		{
+			desc: "polish_case_of_a_technology",
+
+			line: "2-3 lata doświadczenia w Javie",
+			want: []textproc.Experience{{Term: "java", MinYears: 2, MaxYears: 3}},
+		},
+		{
+			desc: "polish_instrumental_case_of_a_technology",
+
+			line: "Co najmniej 3 lata pracy z Reactem",
+			want: []textproc.Experience{{Term: "react", MinYears: 3}},
+		},
+		{
+			desc: "english_word_like_a_case_of_a_technology",
+
+			line: "5 years of building systems at scale",
+			want: []textproc.Experience{{MinYears: 5, MaxYears: 5}},
+		},
+		{
+			desc: "many_requirements_on_a_line",
+
+			line: "3+ years of Go and 2 years of Docker",
+			want: []textproc.Experience{
+				{Term: "go", MinYears: 3},
+				{Term: "docker", MinYears: 2, MaxYears: 2},
+			},
+		},
+		{
+			desc: "no_years",
+
+			line: "Experience with Go and Docker",
+			want: []textproc.Experience{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for i := range tC.want {
+				tC.want[i].Source = tC.line
+			}
+			require.Equal(t, tC.want, e.Line(tC.line))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package textproc
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/bbalet/stopwords"
+	"github.com/pemistahl/lingua-go"
+)
+
+// LanguageDetector detects language of lines of text, e.g. Polish headers
+// mixed with English requirements of a job offer.
+//
+// LanguageDetector is expensive to build, so build it once and share it.
+// It is safe for concurrent use.
+type LanguageDetector struct {
+	detector lingua.LanguageDetector
+}
+
+// NewLanguageDetector returns a detector of langs, English and Polish
+// if none are given.
+func NewLanguageDetector(langs ...lingua.Language) *LanguageDetector {
+	if len(langs) == 0 {
+		langs = defaultLanguages()
+	}
+
+	return &LanguageDetector{
+		detector: lingua.NewLanguageDetectorBuilder().
+			FromLanguages(langs...).
+			Build(),
+	}
+}
+
+// DefaultLanguageDetector returns a detector of English and Polish shared
+// by the package, built on first use.
+var DefaultLanguageDetector = sync.OnceValue(func() *LanguageDetector {
+	return NewLanguageDetector()
+})
+
+// Language returns ISO 639-1 code of language of a line, e.g. "pl".
+// Empty if it is not detected.
+func (d *LanguageDetector) Language(line string) string {
+	lang, ok := d.detector.DetectLanguageOf(line)
+	if !ok {
+		return ""
+	}
+
+	return strings.ToLower(lang.IsoCode639_1().String())
+}
+
+// RmStopWords removes stop words of a language detected per line of text,
+// see ScanLines. Lines of undetected language are left as they are.
+func (d *LanguageDetector) RmStopWords(text string) string {
+	lines := make([]string, 0)
+	for line := range ScanLines(text) {
+		if lang := d.Language(line); lang != "" {
+			line = strings.TrimSpace(stopwords.CleanString(line, lang, false))
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
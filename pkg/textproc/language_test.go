@@ -0,0 +1,64 @@
+package textproc_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageDetector(t *testing.T) {
+	t.Parallel()
+
+	d := textproc.DefaultLanguageDetector()
+	require.Same(t, d, textproc.DefaultLanguageDetector())
+
+	testCases := []struct {
+		desc string
+
+		line string
+		want string
+	}{
+		{
+			desc: "english",
+
+			line: "You have strong experience with distributed systems",
+			want: "en",
+		},
+		{
+			desc: "polish",
+
+			line: "Wymagania i oferujemy pracę w zespole",
+			want: "pl",
+		},
+		{
+			desc: "undetected",
+
+			line: "2024",
+			want: "",
+		},
+	}
+	var wg sync.WaitGroup
+	for _, tC := range testCases {
+		// Detector is shared by goroutines
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, tC.want, d.Language(tC.line), tC.desc)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRmStopWordsPerLine(t *testing.T) {
+	t.Parallel()
+
+	text := "Oferujemy pracę dla osób, jest to praca zdalna\n" +
+		"You have strong experience with the distributed systems"
+
+	require.Equal(t,
+		"oferujemy pracę osób praca zdalna\nstrong experience distributed systems",
+		textproc.RmStopWords(text),
+	)
+}
@@ -0,0 +1,265 @@
+package textproc
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/bbalet/stopwords"
+)
+
+// Measure scores how much more often words of an n-gram occur together
+// than they would by chance.
+type Measure string
+
+const (
+	// PMI is pointwise mutual information, log2 of the ratio of n-gram
+	// probability to the product of probabilities of its words.
+	PMI Measure = "pmi"
+	// LogLikelihood is Dunning's log-likelihood ratio of the last word
+	// following the preceding ones. Negative if they repel each other.
+	LogLikelihood Measure = "llr"
+)
+
+// Measures lists supported collocation measures.
+var Measures = []Measure{PMI, LogLikelihood}
+
+var ErrUnknownMeasure = errors.New("unknown collocation measure")
+
+// ParseMeasure returns measure of name s, case insensitive.
+func ParseMeasure(s string) (Measure, error) {
+	m := Measure(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(Measures, m) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownMeasure, s)
+	}
+
+	return m, nil
+}
+
+// MaxNGram is the length of the longest counted n-gram.
+const MaxNGram = 3
+
+// NGram is a sequence of words occurring together in a text.
+type NGram struct {
+	Words []string `json:"words"`
+	Count int      `json:"count"`
+	Score float64  `json:"score"`
+}
+
+// String returns words of the n-gram joined by a space.
+func (g NGram) String() string {
+	return strings.Join(g.Words, " ")
+}
+
+// IsStopWord reports whether word is an English or Polish stop word.
+// Words with digits or symbols, e.g. "c++", never are.
+func IsStopWord(word string) bool {
+	if !isWord(word) {
+		return false
+	}
+	for _, lang := range []string{"en", "pl"} {
+		if strings.TrimSpace(stopwords.CleanString(word, lang, false)) == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NGramCounter counts words and n-grams of up to MaxNGram words in lines,
+// e.g. those received from ScanLines.
+//
+// N-grams never cross punctuation ending a clause, such as a comma, and
+// never include alternatives joined by a slash. Collocations neither start
+// nor end with a stop word, though one may be in the middle of a trigram,
+// e.g. "quality of service".
+//
+// NGramCounter is safe for concurrent use.
+type NGramCounter struct {
+	tokenizer *Tokenizer
+
+	mu     sync.Mutex
+	words  map[string]int
+	ngrams map[string]int
+	total  int
+	stop   map[string]bool
+}
+
+// NewNGramCounter returns a counter splitting lines into words with t,
+// or a tokenizer protecting DefaultProtectedTerms if t is nil.
+func NewNGramCounter(t *Tokenizer) *NGramCounter {
+	if t == nil {
+		t = defaultTokenizer
+	}
+
+	return &NGramCounter{
+		tokenizer: t,
+		words:     make(map[string]int),
+		ngrams:    make(map[string]int),
+		stop:      make(map[string]bool),
+	}
+}
+
+// AddLine counts words and n-grams of line.
+func (c *NGramCounter) AddLine(line string) {
+	clauses := c.clauses(line)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, clause := range clauses {
+		for i, w := range clause {
+			c.words[w]++
+			c.total++
+			for n := 2; n <= MaxNGram && i+n <= len(clause); n++ {
+				c.ngrams[strings.Join(clause[i:i+n], " ")]++
+			}
+		}
+	}
+}
+
+// clauses splits line into runs of tokens which n-grams may span.
+func (c *NGramCounter) clauses(line string) [][]string {
+	clauses := make([][]string, 0)
+	clause := make([]string, 0)
+	split := func() {
+		if len(clause) > 0 {
+			clauses = append(clauses, clause)
+			clause = make([]string, 0)
+		}
+	}
+
+	for _, field := range strings.FieldsFunc(line, isSeparator) {
+		if r, _ := utf8.DecodeRuneInString(field); strings.ContainsRune("([{", r) {
+			split()
+		}
+		tokens := c.tokenizer.Tokens(field)
+		if len(tokens) > 1 {
+			// Alternatives such as "go/python" are words of their own
+			split()
+			for _, token := range tokens {
+				clauses = append(clauses, []string{token})
+			}
+		} else {
+			clause = append(clause, tokens...)
+		}
+		if r, _ := utf8.DecodeLastRuneInString(field); strings.ContainsRune(".,;:!?)]}", r) {
+			split()
+		}
+	}
+	split()
+
+	return clauses
+}
+
+// Collocations returns n-grams of n words, or of any length if n is 0,
+// occurring at least minCount times. They are sorted by score of measure m,
+// highest first.
+func (c *NGramCounter) Collocations(n int, m Measure, minCount int) []NGram {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ngrams := make([]NGram, 0)
+	for key, count := range c.ngrams {
+		if count < minCount {
+			continue
+		}
+		words := strings.Split(key, " ")
+		if n != 0 && len(words) != n {
+			continue
+		}
+		if c.isStopWord(words[0]) || c.isStopWord(words[len(words)-1]) {
+			continue
+		}
+		ngrams = append(ngrams, NGram{
+			Words: words,
+			Count: count,
+			Score: c.score(words, count, m),
+		})
+	}
+	slices.SortFunc(ngrams, func(a, b NGram) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(b.Count, a.Count),
+			cmp.Compare(a.String(), b.String()),
+		)
+	})
+
+	return ngrams
+}
+
+func (c *NGramCounter) isStopWord(word string) bool {
+	stop, ok := c.stop[word]
+	if !ok {
+		stop = IsStopWord(word)
+		c.stop[word] = stop
+	}
+
+	return stop
+}
+
+func (c *NGramCounter) score(words []string, count int, m Measure) float64 {
+	total := float64(c.total)
+
+	switch m {
+	case LogLikelihood:
+		// Contingency of the preceding words and the last one
+		var prefix int
+		if len(words) == 2 {
+			prefix = c.words[words[0]]
+		} else {
+			prefix = c.ngrams[strings.Join(words[:len(words)-1], " ")]
+		}
+		k11 := float64(count)
+		k12 := float64(prefix - count)
+		k21 := float64(c.words[words[len(words)-1]] - count)
+		k22 := max(total-k11-k12-k21, 0)
+
+		return logLikelihood(k11, k12, k21, k22)
+	default:
+		expected := 1.0
+		for _, w := range words {
+			expected *= float64(c.words[w]) / total
+		}
+
+		return math.Log2(float64(count) / total / expected)
+	}
+}
+
+// logLikelihood returns Dunning's G² statistic of a 2x2 contingency table,
+// negative if k11 is lower than expected.
+func logLikelihood(k11, k12, k21, k22 float64) float64 {
+	n := k11 + k12 + k21 + k22
+	term := func(k, row, col float64) float64 {
+		if k == 0 {
+			return 0
+		}
+
+		return k * math.Log(k*n/(row*col))
+	}
+	g2 := 2 * (term(k11, k11+k12, k11+k21) +
+		term(k12, k11+k12, k12+k22) +
+		term(k21, k21+k22, k11+k21) +
+		term(k22, k21+k22, k12+k22))
+	if k11*n < (k11+k12)*(k11+k21) {
+		return -g2
+	}
+
+	return g2
+}
+
+// ExtractNGrams counts n-grams of lines and returns their collocations,
+// see NGramCounter.Collocations.
+func ExtractNGrams(lines <-chan string, n int, m Measure, minCount int) []NGram {
+	c := NewNGramCounter(nil)
+	for line := range lines {
+		c.AddLine(line)
+	}
+
+	return c.Collocations(n, m, minCount)
+}
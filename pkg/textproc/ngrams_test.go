@@ -0,0 +1,110 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+var jobOfferLines = []string{
+	"Experience with machine learning and distributed systems.",
+	"Knowledge of distributed systems, machine learning",
+	"You will build machine learning pipelines on event driven architecture",
+	"Event driven architecture in Go/Python services",
+	"Good quality of service and quality of service monitoring",
+}
+
+func ngramValues(ngrams []textproc.NGram) []string {
+	values := make([]string, 0, len(ngrams))
+	for _, g := range ngrams {
+		values = append(values, g.String())
+	}
+
+	return values
+}
+
+func TestNGramCounterCollocations(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		n       int
+		measure textproc.Measure
+		want    []string
+	}{
+		{
+			desc: "bigrams_by_pmi",
+
+			n:       2,
+			measure: textproc.PMI,
+			want:    []string{"event driven", "driven architecture", "distributed systems", "machine learning"},
+		},
+		{
+			desc: "trigrams_by_log_likelihood",
+
+			n:       3,
+			measure: textproc.LogLikelihood,
+			want:    []string{"event driven architecture", "quality of service"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			c := textproc.NewNGramCounter(nil)
+			for _, line := range jobOfferLines {
+				c.AddLine(line)
+			}
+
+			ngrams := c.Collocations(tC.n, tC.measure, 2)
+			require.ElementsMatch(t, tC.want, ngramValues(ngrams))
+			for i := 1; i < len(ngrams); i++ {
+				require.GreaterOrEqual(t, ngrams[i-1].Score, ngrams[i].Score)
+			}
+		})
+	}
+}
+
+func TestNGramCounterBoundaries(t *testing.T) {
+	t.Parallel()
+
+	c := textproc.NewNGramCounter(nil)
+	for _, line := range jobOfferLines {
+		c.AddLine(line)
+	}
+	values := ngramValues(c.Collocations(0, textproc.PMI, 1))
+
+	require.Contains(t, values, "machine learning")
+	require.Contains(t, values, "machine learning pipelines")
+	require.Contains(t, values, "learning and distributed")
+	// Stop words at the edges
+	require.NotContains(t, values, "with machine")
+	require.NotContains(t, values, "of distributed systems")
+	// Clause punctuation and alternatives
+	require.NotContains(t, values, "systems machine")
+	require.NotContains(t, values, "in go")
+	require.NotContains(t, values, "python services")
+}
+
+func TestExtractNGrams(t *testing.T) {
+	t.Parallel()
+
+	ngrams := textproc.ExtractNGrams(textproc.ScanLines(jobOfferLines...), 2, textproc.LogLikelihood, 3)
+	require.Len(t, ngrams, 1)
+	require.Equal(t, []string{"machine", "learning"}, ngrams[0].Words)
+	require.Equal(t, 3, ngrams[0].Count)
+	require.Positive(t, ngrams[0].Score)
+}
+
+func TestParseMeasure(t *testing.T) {
+	t.Parallel()
+
+	m, err := textproc.ParseMeasure(" PMI ")
+	require.NoError(t, err)
+	require.Equal(t, textproc.PMI, m)
+
+	_, err = textproc.ParseMeasure("dice")
+	require.ErrorIs(t, err, textproc.ErrUnknownMeasure)
+}
@@ -0,0 +1,184 @@
+package textproc
+
+import "strings"
+
+// Proficiency is a CEFR level of a spoken language, or native.
+type Proficiency string
+
+const (
+	ProficiencyA1     Proficiency = "A1"
+	ProficiencyA2     Proficiency = "A2"
+	ProficiencyB1     Proficiency = "B1"
+	ProficiencyB2     Proficiency = "B2"
+	ProficiencyC1     Proficiency = "C1"
+	ProficiencyC2     Proficiency = "C2"
+	ProficiencyNative Proficiency = "native"
+)
+
+// LanguageRequirement is a spoken language required by a line of a job
+// offer at a level, e.g. "English B2" or "komunikatywny język angielski".
+type LanguageRequirement struct {
+	// Language is an ISO 639-1 code, e.g. "en".
+	Language    string      `json:"language"`
+	Proficiency Proficiency `json:"proficiency"`
+	// Source is the line the requirement is found in.
+	Source string `json:"source"`
+}
+
+// spokenLanguages are English and Polish names of spoken languages, keyed
+// by their lowercase forms.
+var spokenLanguages = map[string]string{
+	"english": "en", "angielski": "en", "angielskiego": "en", "angielskim": "en", "angielsku": "en",
+	"polish": "pl", "polski": "pl", "polskiego": "pl", "polskim": "pl", "polsku": "pl",
+	"german": "de", "niemiecki": "de", "niemieckiego": "de", "niemieckim": "de", "niemiecku": "de",
+	"french": "fr", "francuski": "fr", "francuskiego": "fr", "francuskim": "fr", "francusku": "fr",
+	"spanish": "es", "hiszpański": "es", "hiszpańskiego": "es", "hiszpańskim": "es", "hiszpańsku": "es",
+	"italian": "it", "włoski": "it", "włoskiego": "it", "włoskim": "it",
+	"ukrainian": "uk", "ukraiński": "uk", "ukraińskiego": "uk", "ukraińskim": "uk",
+	"russian": "ru", "rosyjski": "ru", "rosyjskiego": "ru", "rosyjskim": "ru",
+}
+
+// proficiencyWords are CEFR levels and English and Polish adjectives of
+// proficiency, the latter mapped to their nearest CEFR level.
+var proficiencyWords = map[string]Proficiency{
+	"a1": ProficiencyA1, "a2": ProficiencyA2,
+	"b1": ProficiencyB1, "b2": ProficiencyB2,
+	"c1": ProficiencyC1, "c2": ProficiencyC2,
+
+	"native": ProficiencyNative, "ojczysty": ProficiencyNative, "ojczysta": ProficiencyNative,
+
+	"fluent": ProficiencyC1, "fluently": ProficiencyC1, "fluency": ProficiencyC1,
+	"advanced": ProficiencyC1, "proficient": ProficiencyC1,
+	"biegły": ProficiencyC1, "biegła": ProficiencyC1, "biegłej": ProficiencyC1, "biegłą": ProficiencyC1,
+	"biegle": ProficiencyC1, "płynny": ProficiencyC1, "płynna": ProficiencyC1, "płynnie": ProficiencyC1,
+	"zaawansowany": ProficiencyC1, "zaawansowana": ProficiencyC1, "zaawansowanej": ProficiencyC1,
+
+	"good": ProficiencyB2, "upper-intermediate": ProficiencyB2,
+	"dobry": ProficiencyB2, "dobra": ProficiencyB2, "dobrej": ProficiencyB2, "dobrą": ProficiencyB2,
+
+	"communicative": ProficiencyB1, "intermediate": ProficiencyB1,
+	"komunikatywny": ProficiencyB1, "komunikatywna": ProficiencyB1, "komunikatywnej": ProficiencyB1,
+	"komunikatywną": ProficiencyB1, "komunikatywnie": ProficiencyB1, "średniozaawansowany": ProficiencyB1,
+
+	"basic": ProficiencyA2, "podstawowy": ProficiencyA2, "podstawowa": ProficiencyA2,
+	"podstawowej": ProficiencyA2, "podstawową": ProficiencyA2,
+}
+
+// A level farther than maxProficiencyDistance tokens from a language is
+// not a level of it.
+const maxProficiencyDistance = 4
+
+// clauseJoiners join clauses of a line, e.g. "and" of "Fluent English and
+// communicative Polish".
+var clauseJoiners = map[string]bool{"and": true, "oraz": true}
+
+// ParseLanguageRequirements returns spoken languages required by a line,
+// e.g. "English B2, Polish C1" or "Fluent English and communicative Polish".
+// A level is a level of the single language nearest to it within a clause,
+// the preceding one if two are equally near, and a language of many levels
+// requires the nearest of them, the following one if two are equally near.
+// Languages without a level are not requirements.
+func ParseLanguageRequirements(line string) []LanguageRequirement {
+	requirements := make([]LanguageRequirement, 0)
+	for _, tokens := range clauses(line) {
+		for _, r := range clauseRequirements(tokens) {
+			r.Source = strings.TrimSpace(line)
+			requirements = append(requirements, r)
+		}
+	}
+
+	return requirements
+}
+
+// clauses returns tokens of clauses of a line, separated by ";" or "," or
+// joined by clauseJoiners.
+func clauses(line string) [][]string {
+	all := make([][]string, 0)
+	for _, part := range strings.FieldsFunc(line, func(r rune) bool { return r == ';' || r == ',' }) {
+		clause := make([]string, 0)
+		for _, token := range Tokens(part) {
+			if clauseJoiners[token] {
+				all = append(all, clause)
+				clause = make([]string, 0)
+
+				continue
+			}
+			clause = append(clause, token)
+		}
+		all = append(all, clause)
+	}
+
+	return all
+}
+
+// clauseRequirements returns spoken languages required by tokens of
+// a clause, without sources.
+func clauseRequirements(tokens []string) []LanguageRequirement {
+	type position struct {
+		index int
+		level Proficiency
+	}
+	languages := make([]int, 0)
+	levels := make([]position, 0)
+	for i, token := range tokens {
+		if _, ok := spokenLanguages[token]; ok {
+			languages = append(languages, i)
+		}
+		if p, ok := proficiencyWords[strings.TrimSuffix(token, "+")]; ok {
+			levels = append(levels, position{index: i, level: p})
+		}
+	}
+
+	// Bind every level to its nearest language
+	bound := make(map[int][]position, len(languages))
+	for _, p := range levels {
+		language, distance := -1, maxProficiencyDistance+1
+		for _, i := range languages {
+			if d := abs(p.index - i); d < distance {
+				language, distance = i, d
+			}
+		}
+		if language >= 0 {
+			bound[language] = append(bound[language], p)
+		}
+	}
+
+	requirements := make([]LanguageRequirement, 0)
+	for _, i := range languages {
+		level, distance := Proficiency(""), maxProficiencyDistance+1
+		for _, p := range bound[i] {
+			d := abs(p.index - i)
+			if d < distance || (d == distance && p.index > i) {
+				level, distance = p.level, d
+			}
+		}
+		if level == "" {
+			continue
+		}
+		requirements = append(requirements, LanguageRequirement{
+			Language:    spokenLanguages[tokens[i]],
+			Proficiency: level,
+		})
+	}
+
+	return requirements
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// LanguageRequirements returns spoken languages required by lines of text,
+// see ScanLines.
+func LanguageRequirements(text string) []LanguageRequirement {
+	requirements := make([]LanguageRequirement, 0)
+	for line := range ScanLines(text) {
+		requirements = append(requirements, ParseLanguageRequirements(line)...)
+	}
+
+	return requirements
+}
@@ -0,0 +1,95 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLanguageRequirements(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		line string
+		want []textproc.LanguageRequirement
+	}{
+		{
+			desc: "cefr_levels",
+
+			line: "English B2, Polish C1",
+			want: []textproc.LanguageRequirement{
+				{Language: "en", Proficiency: textproc.ProficiencyB2},
+				{Language: "pl", Proficiency: textproc.ProficiencyC1},
+			},
+		},
+		{
+			desc: "adjectives",
+
+			line: "Fluent English and communicative Polish",
+			want: []textproc.LanguageRequirement{
+				{Language: "en", Proficiency: textproc.ProficiencyC1},
+				{Language: "pl", Proficiency: textproc.ProficiencyB1},
+			},
+		},
+		{
+			desc: "lower_of_alternative_levels",
+
+			line: "Very good command of English (B2+/C1)",
+			want: []textproc.LanguageRequirement{
+				{Language: "en", Proficiency: textproc.ProficiencyB2},
+			},
+		},
+		{
+			desc: "polish_level_of_a_language",
+
+			line: "Znajomość języka angielskiego na poziomie min. B2",
+			want: []textproc.LanguageRequirement{
+				{Language: "en", Proficiency: textproc.ProficiencyB2},
+			},
+		},
+		{
+			desc: "polish_native",
+
+			line: "Język polski – ojczysty, niemiecki komunikatywny",
+			want: []textproc.LanguageRequirement{
+				{Language: "pl", Proficiency: textproc.ProficiencyNative},
+				{Language: "de", Proficiency: textproc.ProficiencyB1},
+			},
+		},
+		{
+			desc: "level_of_a_single_language",
+
+			line: "Native Polish speaker; English is a plus",
+			want: []textproc.LanguageRequirement{
+				{Language: "pl", Proficiency: textproc.ProficiencyNative},
+			},
+		},
+		{
+			desc: "level_within_a_clause",
+
+			line: "Polish C1 and English",
+			want: []textproc.LanguageRequirement{
+				{Language: "pl", Proficiency: textproc.ProficiencyC1},
+			},
+		},
+		{
+			desc: "language_without_a_level",
+
+			line: "Documentation in English",
+			want: []textproc.LanguageRequirement{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for i := range tC.want {
+				tC.want[i].Source = tC.line
+			}
+			require.Equal(t, tC.want, textproc.ParseLanguageRequirements(tC.line))
+		})
+	}
+}
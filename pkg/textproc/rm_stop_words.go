@@ -1,7 +1,6 @@
 package textproc
 
 import (
-	"github.com/bbalet/stopwords"
 	"github.com/pemistahl/lingua-go"
 )
 
@@ -12,21 +11,17 @@ func defaultLanguages() []lingua.Language {
 	}
 }
 
+// RmStopWords removes stop words of every line of text in its own language,
+// detected among langs, English and Polish by default.
+// See LanguageDetector.RmStopWords.
 func RmStopWords(text string, langs ...lingua.Language) string {
 	if text == "" {
 		return ""
 	}
-	if len(langs) == 0 {
-		langs = defaultLanguages()
+	d := DefaultLanguageDetector()
+	if len(langs) > 0 {
+		d = NewLanguageDetector(langs...)
 	}
-	detector := lingua.NewLanguageDetectorBuilder().
-		FromLanguages(langs...).
-		Build()
 
-	lang, exists := detector.DetectLanguageOf(text)
-	if !exists {
-		return text
-	}
-
-	return stopwords.CleanString(text, lang.IsoCode639_1().String(), false)
+	return d.RmStopWords(text)
 }
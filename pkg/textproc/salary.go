@@ -0,0 +1,279 @@
+package textproc
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Currency is an ISO 4217 code of a salary currency.
+type Currency string
+
+const (
+	CurrencyPLN Currency = "PLN"
+	CurrencyEUR Currency = "EUR"
+	CurrencyUSD Currency = "USD"
+)
+
+// SalaryPeriod is a period a salary is paid for.
+type SalaryPeriod string
+
+const (
+	PeriodHour  SalaryPeriod = "hour"
+	PeriodMonth SalaryPeriod = "month"
+	PeriodYear  SalaryPeriod = "year"
+)
+
+// Salary is a salary range offered in a line of a job offer.
+type Salary struct {
+	Min      float64      `json:"min"`
+	Max      float64      `json:"max"`
+	Currency Currency     `json:"currency"`
+	Period   SalaryPeriod `json:"period"`
+	Contract Contract     `json:"contract,omitempty"`
+	// Source is the line the salary is found in.
+	Source string `json:"source"`
+}
+
+var (
+	// salaryCurrencyRe matches currencies, symbols and codes of PLN, EUR
+	// and USD, e.g. "zł" of "185,000.00zł".
+	salaryCurrencyRe = regexp.MustCompile(`(?i)(€|\$|(?:^|[^\p{L}])(zł|zl|pln|eur|euro|usd)(?:[^\p{L}]|$))`)
+	// salaryAmountRe matches amounts of Polish and English formats,
+	// e.g. "15 000", "15.000,50", "185,000.00" and "20k".
+	salaryAmountRe = regexp.MustCompile(`(\d{1,3}(?:[ \x{00a0}\x{202f}]\d{3})+(?:,\d{1,2})?|\d+(?:[.,]\d+)*)([kK])?`)
+)
+
+var salaryCurrencies = map[string]Currency{
+	"€":    CurrencyEUR,
+	"$":    CurrencyUSD,
+	"zł":   CurrencyPLN,
+	"zl":   CurrencyPLN,
+	"pln":  CurrencyPLN,
+	"eur":  CurrencyEUR,
+	"euro": CurrencyEUR,
+	"usd":  CurrencyUSD,
+}
+
+// salaryPeriods are words of English and Polish lines stating a period.
+var salaryPeriods = map[string]SalaryPeriod{
+	"h": PeriodHour, "hr": PeriodHour, "hour": PeriodHour, "hourly": PeriodHour,
+	"godz": PeriodHour, "godzina": PeriodHour, "godzinę": PeriodHour, "godzinowo": PeriodHour, "godzinowa": PeriodHour,
+	"mo": PeriodMonth, "month": PeriodMonth, "monthly": PeriodMonth,
+	"mc": PeriodMonth, "msc": PeriodMonth, "mies": PeriodMonth, "miesiąc": PeriodMonth,
+	"miesięcznie": PeriodMonth, "miesięczne": PeriodMonth,
+	"year": PeriodYear, "yearly": PeriodYear, "annual": PeriodYear, "annually": PeriodYear, "annum": PeriodYear,
+	"rok": PeriodYear, "rocznie": PeriodYear, "roczne": PeriodYear,
+}
+
+// Amounts below maxHourlyAmount with no period stated are hourly rates,
+// higher ones monthly salaries.
+const maxHourlyAmount = 500
+
+// Amounts of a range differ at most maxSalaryRatio times, a lower amount
+// is rather a number of years or a bonus, e.g. "3+ years, 20 000 PLN".
+const maxSalaryRatio = 10
+
+// ParseSalary returns a salary offered in a line, e.g. "Pay: 185,000.00zł
+// - 285,000.00zł per year" or "15 000 - 20 000 PLN netto/mies. (B2B)".
+// Lines without a currency are not salaries. A period not stated is
+// a month unless amounts are hourly rates.
+func ParseSalary(line string) (Salary, bool) {
+	lower := strings.ToLower(line)
+
+	m := salaryCurrencyRe.FindStringSubmatch(lower)
+	if m == nil {
+		return Salary{}, false
+	}
+	currency := salaryCurrencies[m[1]]
+	if m[2] != "" {
+		currency = salaryCurrencies[m[2]]
+	}
+
+	amounts := salaryAmounts(lower)
+	if len(amounts) == 0 {
+		return Salary{}, false
+	}
+	s := Salary{
+		Min:      amounts[0],
+		Max:      amounts[0],
+		Currency: currency,
+		Contract: salaryContract(lower),
+		Source:   strings.TrimSpace(line),
+	}
+	if len(amounts) > 1 {
+		s.Min, s.Max = min(amounts[0], amounts[1]), max(amounts[0], amounts[1])
+		if s.Min*maxSalaryRatio < s.Max {
+			s.Min = s.Max
+		}
+	}
+	s.Period = salaryPeriod(lower, s.Max)
+
+	return s, true
+}
+
+// Salaries returns salaries offered in lines of text, see ScanLines.
+func Salaries(text string) []Salary {
+	salaries := make([]Salary, 0)
+	for line := range ScanLines(text) {
+		if s, ok := ParseSalary(line); ok {
+			salaries = append(salaries, s)
+		}
+	}
+
+	return salaries
+}
+
+// salaryAmounts returns at most two first amounts of a line. Amounts
+// glued to letters, e.g. "b2b", and percentages are skipped, and the lower
+// amount of a range is in thousands if the higher one is, e.g. "20-25k".
+func salaryAmounts(line string) []float64 {
+	amounts := make([]float64, 0, 2)
+	thousands := make([]bool, 0, 2)
+	for _, loc := range salaryAmountLocs(line) {
+		if r, _ := utf8.DecodeLastRuneInString(line[:loc[0]]); unicode.IsLetter(r) {
+			continue
+		}
+		end := loc[1]
+		k := loc[4] >= 0
+		if r, _ := utf8.DecodeRuneInString(line[end:]); r == '%' {
+			continue
+		} else if k && unicode.IsLetter(r) {
+			k = false
+		}
+
+		amount, ok := parseAmount(line[loc[2]:loc[3]])
+		if !ok || amount == 0 {
+			continue
+		}
+		if k {
+			amount *= 1000
+		}
+		amounts = append(amounts, amount)
+		thousands = append(thousands, k)
+		if len(amounts) == 2 {
+			break
+		}
+	}
+	if len(amounts) == 2 && !thousands[0] && thousands[1] && amounts[0] < 1000 {
+		amounts[0] *= 1000
+	}
+
+	return amounts
+}
+
+// salaryAmountLocs returns locations of amounts of line and their
+// thousands suffixes, see salaryAmountRe. Numbers separated by a space are
+// one amount only if the last group has exactly three digits and does not
+// start a range of amounts without thousands, e.g. "100 150-200" is 100
+// and a range of 150 to 200, while "12 000-15 000" is a range.
+func salaryAmountLocs(line string) [][]int {
+	locs := make([][]int, 0)
+	for start := 0; start < len(line); {
+		loc := salaryAmountRe.FindStringSubmatchIndex(line[start:])
+		if loc == nil {
+			break
+		}
+		for i := range loc {
+			if loc[i] >= 0 {
+				loc[i] += start
+			}
+		}
+		if i := strings.IndexFunc(line[loc[2]:loc[3]], unicode.IsSpace); i >= 0 && loc[4] < 0 && !spaceGrouped(line[loc[3]:]) {
+			end := loc[2] + i
+			loc = []int{loc[2], end, loc[2], end, -1, -1}
+		}
+		locs = append(locs, loc)
+		start = loc[1]
+	}
+
+	return locs
+}
+
+// spaceGrouped reports whether rest of a line following a number grouped
+// by spaces lets its last group be thousands: neither more digits follow
+// nor a range dash and an amount without thousands.
+func spaceGrouped(rest string) bool {
+	r, size := utf8.DecodeRuneInString(rest)
+	switch {
+	case unicode.IsDigit(r):
+		return false
+	case r == '-' || r == '–' || r == '—':
+		rest = rest[size:]
+		loc := salaryAmountRe.FindStringSubmatchIndex(rest)
+
+		return loc == nil || loc[0] != 0 || loc[4] >= 0 || strings.ContainsFunc(rest[loc[2]:loc[3]], unicode.IsSpace)
+	}
+
+	return true
+}
+
+// parseAmount parses an amount of Polish or English format. A separator
+// used once and followed by other than three digits is decimal, e.g.
+// "12,50", otherwise the last of two separators is, e.g. "15.000,50".
+func parseAmount(s string) (float64, bool) {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, s)
+
+	lastComma, lastDot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		decimal := max(lastComma, lastDot)
+		s = strings.NewReplacer(",", "", ".", "").Replace(s[:decimal]) + "." + s[decimal+1:]
+	case lastComma >= 0:
+		s = normalizeSeparator(s, ",")
+	case lastDot >= 0:
+		s = normalizeSeparator(s, ".")
+	}
+
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return amount, true
+}
+
+// normalizeSeparator replaces a thousands separator sep of s with nothing
+// or a decimal one with a dot.
+func normalizeSeparator(s, sep string) string {
+	if i := strings.Index(s, sep); strings.Count(s, sep) == 1 && len(s)-i-1 != 3 {
+		return strings.Replace(s, sep, ".", 1)
+	}
+
+	return strings.ReplaceAll(s, sep, "")
+}
+
+// salaryPeriod returns a period stated in a line, or guesses it from
+// the maximum amount.
+func salaryPeriod(line string, maxAmount float64) SalaryPeriod {
+	words := strings.FieldsFunc(line, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, w := range words {
+		if p, ok := salaryPeriods[w]; ok {
+			return p
+		}
+	}
+	if maxAmount < maxHourlyAmount {
+		return PeriodHour
+	}
+
+	return PeriodMonth
+}
+
+// salaryContract returns the first contract stated in a lowercase line.
+func salaryContract(line string) Contract {
+	if contracts := matchPhrases(lineWords(line), contractPhrases); len(contracts) > 0 {
+		return contracts[0]
+	}
+
+	return ContractNone
+}
@@ -0,0 +1,171 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSalary(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		line   string
+		want   textproc.Salary
+		wantOK bool
+	}{
+		{
+			desc: "english_format_per_year",
+
+			line: "Pay: 185,000.00zł - 285,000.00zł per year",
+			want: textproc.Salary{
+				Min:      185000,
+				Max:      285000,
+				Currency: textproc.CurrencyPLN,
+				Period:   textproc.PeriodYear,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "polish_format_b2b_per_month",
+
+			line: "Wynagrodzenie: 15 000 – 20 000 PLN netto/mies. + VAT (B2B)",
+			want: textproc.Salary{
+				Min:      15000,
+				Max:      20000,
+				Currency: textproc.CurrencyPLN,
+				Period:   textproc.PeriodMonth,
+				Contract: textproc.ContractB2B,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "polish_format_range_without_spaces",
+
+			line: "12 000-15 000 PLN/mies.",
+			want: textproc.Salary{
+				Min:      12000,
+				Max:      15000,
+				Currency: textproc.CurrencyPLN,
+				Period:   textproc.PeriodMonth,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "separate_amounts_are_not_merged",
+
+			line: "Rate: 100 150-200 PLN/h",
+			want: textproc.Salary{
+				Min:      100,
+				Max:      150,
+				Currency: textproc.CurrencyPLN,
+				Period:   textproc.PeriodHour,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "longer_group_is_not_thousands",
+
+			line: "100 1500 PLN",
+			want: textproc.Salary{
+				Min:      1500,
+				Max:      1500,
+				Currency: textproc.CurrencyPLN,
+				Period:   textproc.PeriodMonth,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "polish_decimals_employment_contract",
+
+			line: "12.500,50 zł brutto miesięcznie na umowę o pracę",
+			want: textproc.Salary{
+				Min:      12500.5,
+				Max:      12500.5,
+				Currency: textproc.CurrencyPLN,
+				Period:   textproc.PeriodMonth,
+				Contract: textproc.ContractEmployment,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "thousands_suffix",
+
+			line: "€60-80k a year",
+			want: textproc.Salary{
+				Min:      60000,
+				Max:      80000,
+				Currency: textproc.CurrencyEUR,
+				Period:   textproc.PeriodYear,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "hourly_rate_without_period",
+
+			line: "$45 - $60.50",
+			want: textproc.Salary{
+				Min:      45,
+				Max:      60.5,
+				Currency: textproc.CurrencyUSD,
+				Period:   textproc.PeriodHour,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "years_of_experience_are_not_amounts",
+
+			line: "3+ years of experience, 20 000 PLN/month",
+			want: textproc.Salary{
+				Min:      20000,
+				Max:      20000,
+				Currency: textproc.CurrencyPLN,
+				Period:   textproc.PeriodMonth,
+			},
+			wantOK: true,
+		},
+		{
+			desc: "no_currency",
+
+			line:   "Work Location: Hybrid remote in 00-850 Warszawa",
+			wantOK: false,
+		},
+		{
+			desc: "no_amount",
+
+			line:   "Salary in PLN or EUR",
+			wantOK: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			s, ok := textproc.ParseSalary(tC.line)
+			require.Equal(t, tC.wantOK, ok)
+			if !ok {
+				return
+			}
+			tC.want.Source = tC.line
+			require.Equal(t, tC.want, s)
+		})
+	}
+}
+
+func TestSalaries(t *testing.T) {
+	t.Parallel()
+
+	text := `Job Type: Full-time
+
+Pay: 185,000.00zł - 285,000.00zł per year
+
+Work Location: Hybrid remote in 00-850 Warszawa`
+
+	salaries := textproc.Salaries(text)
+	require.Len(t, salaries, 1)
+	require.InDelta(t, 185000, salaries[0].Min, 1e-9)
+	require.Equal(t, textproc.PeriodYear, salaries[0].Period)
+}
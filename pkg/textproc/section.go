@@ -0,0 +1,174 @@
+package textproc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Section is a part of a job offer a line belongs to, e.g. requirements.
+type Section string
+
+const (
+	// SectionNone labels lines before any known heading.
+	SectionNone             Section = ""
+	SectionRequirements     Section = "requirements"
+	SectionResponsibilities Section = "responsibilities"
+	SectionNiceToHave       Section = "nice_to_have"
+	SectionBenefits         Section = "benefits"
+)
+
+// Sections are known sections of job offers.
+var Sections = []Section{
+	SectionRequirements,
+	SectionResponsibilities,
+	SectionNiceToHave,
+	SectionBenefits,
+}
+
+var ErrUnknownSection = errors.New("unknown section")
+
+// ParseSection returns a known section named s.
+func ParseSection(s string) (Section, error) {
+	for _, section := range Sections {
+		if Section(strings.ToLower(strings.TrimSpace(s))) == section {
+			return section, nil
+		}
+	}
+
+	return SectionNone, fmt.Errorf("%w: %q", ErrUnknownSection, s)
+}
+
+// Weight returns weight of a keyword found in a section, a keyword
+// required by an offer weighs more than a nice to have one.
+func (s Section) Weight() float64 {
+	switch s {
+	case SectionRequirements:
+		return 1
+	case SectionResponsibilities:
+		return 0.8
+	case SectionNiceToHave:
+		return 0.4
+	case SectionBenefits:
+		return 0.2
+	default:
+		return 0.6
+	}
+}
+
+// sectionHeadings are English and Polish phrases of headings opening
+// a section, checked in order.
+var sectionHeadings = []struct {
+	section Section
+	phrases []string
+}{
+	{
+		section: SectionNiceToHave,
+		phrases: []string{
+			"nice to have", "nice-to-have", "bonus points", "would be a plus", "is a plus",
+			"mile widziane", "dodatkowe atuty", "dodatkowym atutem", "będzie atutem", "będzie plusem",
+		},
+	},
+	{
+		section: SectionBenefits,
+		phrases: []string{
+			"benefits", "we offer", "perks", "what you get", "why join",
+			"oferujemy", "benefity", "co zyskasz", "co otrzymasz", "korzyści",
+		},
+	},
+	{
+		section: SectionRequirements,
+		phrases: []string{
+			"requirements", "we expect", "expectations", "qualifications", "your profile",
+			"must have", "must-have", "what you need", "you should have", "to be successful",
+			"about you", "your skills",
+			"wymagania", "oczekujemy", "oczekiwania", "wymagamy", "twój profil",
+			"czego od ciebie", "kogo szukamy", "szukamy osoby",
+		},
+	},
+	{
+		section: SectionResponsibilities,
+		phrases: []string{
+			"responsibilities", "your tasks", "main tasks", "your role", "what you will do",
+			"what you'll do", "your day", "the role",
+			"obowiązki", "twoje zadania", "zakres zadań", "czym będziesz się zajmować",
+			"będziesz odpowiedzialny", "będziesz odpowiedzialna",
+		},
+	},
+}
+
+// Headings end with one of headingTrailingSymbols and have at most
+// maxHeadingWords words, or maxBareHeadingWords without a trailing symbol.
+// Longer lines are items.
+const (
+	maxHeadingWords        = 8
+	maxBareHeadingWords    = 4
+	headingTrailingSymbols = ":-–—"
+)
+
+// HeadingSection returns a section opened by a heading line, e.g.
+// "We expect from you:" or "Mile widziane". Headings are short lines,
+// ending with a colon or a dash unless they are very short.
+func HeadingSection(line string) (Section, bool) {
+	line = strings.ToLower(strings.TrimSpace(line))
+	trimmed := strings.TrimRight(line, headingTrailingSymbols+" ")
+
+	n := len(strings.Fields(trimmed))
+	switch {
+	case n == 0:
+		return SectionNone, false
+	case trimmed != line && n <= maxHeadingWords:
+	case n <= maxBareHeadingWords:
+	default:
+		return SectionNone, false
+	}
+
+	return matchSection(trimmed)
+}
+
+// matchSection returns a section whose heading phrase s contains.
+func matchSection(s string) (Section, bool) {
+	for _, h := range sectionHeadings {
+		for _, phrase := range h.phrases {
+			if strings.Contains(s, phrase) {
+				return h.section, true
+			}
+		}
+	}
+
+	return SectionNone, false
+}
+
+// SectionClassifier labels lines of a job offer with sections they belong
+// to, following headings of English and Polish offers. Lines must be
+// classified in order, a classifier is not safe for concurrent use.
+type SectionClassifier struct {
+	current Section
+}
+
+// NewSectionClassifier returns a classifier of lines of a single offer.
+func NewSectionClassifier() *SectionClassifier {
+	return &SectionClassifier{}
+}
+
+// Classify returns section of a line. A heading belongs to a section it
+// opens. A line labeled inline, e.g. "Nice to have: Kafka", belongs to
+// a section of its label without opening it. An item marked as optional,
+// e.g. "Kafka is a plus", is nice to have in any section.
+func (c *SectionClassifier) Classify(line string) Section {
+	if label, items, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(items) != "" {
+		if section, ok := HeadingSection(label + ":"); ok {
+			return section
+		}
+	}
+	if section, ok := HeadingSection(line); ok {
+		c.current = section
+
+		return section
+	}
+	if section, ok := matchSection(strings.ToLower(line)); ok && section == SectionNiceToHave {
+		return section
+	}
+
+	return c.current
+}
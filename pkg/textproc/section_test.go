@@ -0,0 +1,130 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadingSection(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		line   string
+		want   textproc.Section
+		wantOK bool
+	}{
+		{
+			desc: "english_responsibilities",
+
+			line:   "Your main tasks will be:",
+			want:   textproc.SectionResponsibilities,
+			wantOK: true,
+		},
+		{
+			desc: "english_requirements_ending_with_dash",
+
+			line:   "To be successful in this role you will-",
+			want:   textproc.SectionRequirements,
+			wantOK: true,
+		},
+		{
+			desc: "english_nice_to_have_without_colon",
+
+			line:   "Nice to have",
+			want:   textproc.SectionNiceToHave,
+			wantOK: true,
+		},
+		{
+			desc: "polish_requirements",
+
+			line:   "Nasze oczekiwania:",
+			want:   textproc.SectionRequirements,
+			wantOK: true,
+		},
+		{
+			desc: "polish_benefits",
+
+			line:   "Co oferujemy?",
+			want:   textproc.SectionBenefits,
+			wantOK: true,
+		},
+		{
+			desc: "item_is_not_a_heading",
+
+			line:   "Have experience with SQL and software development in Go is nice to have",
+			wantOK: false,
+		},
+		{
+			desc: "unknown_heading",
+
+			line:   "Soft Skills:",
+			wantOK: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			section, ok := textproc.HeadingSection(tC.line)
+			require.Equal(t, tC.wantOK, ok)
+			require.Equal(t, tC.want, section)
+		})
+	}
+}
+
+func TestSectionClassifier(t *testing.T) {
+	t.Parallel()
+
+	text := `Senior Go Developer
+Your main tasks will be:
+    Designing and developing scalable backend solutions using Go and Python.
+We expect from you:
+Technical Expertise:
+    Proficiency in Go (3+ years) and Python
+    Experience with ML applications is a plus.
+Mile widziane:
+    Znajomość Kafka
+Oferujemy:
+    Prywatna opieka medyczna
+Wymagania: Docker
+    Karta Multisport`
+
+	c := textproc.NewSectionClassifier()
+	sections := make([]textproc.Section, 0)
+	for line := range textproc.ScanLines(text) {
+		sections = append(sections, c.Classify(line))
+	}
+
+	require.Equal(t, []textproc.Section{
+		textproc.SectionNone,
+		textproc.SectionResponsibilities,
+		textproc.SectionResponsibilities,
+		textproc.SectionRequirements,
+		textproc.SectionRequirements,
+		textproc.SectionRequirements,
+		textproc.SectionNiceToHave,
+		textproc.SectionNiceToHave,
+		textproc.SectionNiceToHave,
+		textproc.SectionBenefits,
+		textproc.SectionBenefits,
+		textproc.SectionRequirements,
+		textproc.SectionBenefits,
+	}, sections)
+
+	require.Greater(t, textproc.SectionRequirements.Weight(), textproc.SectionNiceToHave.Weight())
+}
+
+func TestParseSection(t *testing.T) {
+	t.Parallel()
+
+	section, err := textproc.ParseSection("Nice_To_Have")
+	require.NoError(t, err)
+	require.Equal(t, textproc.SectionNiceToHave, section)
+
+	_, err = textproc.ParseSection("hobbies")
+	require.ErrorIs(t, err, textproc.ErrUnknownSection)
+}
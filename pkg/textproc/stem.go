@@ -0,0 +1,182 @@
+package textproc
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kljensen/snowball/english"
+)
+
+// Stemmer reduces inflected forms of words to their stem, e.g. "deploying"
+// and "deployments" to "deploy".
+type Stemmer interface {
+	// Name identifies the stemmer in analyses.
+	Name() string
+	Stem(word string) string
+}
+
+// EnglishStemmer returns the Snowball (Porter2) stemmer of English.
+func EnglishStemmer() Stemmer {
+	return englishStemmer{}
+}
+
+type englishStemmer struct{}
+
+func (englishStemmer) Name() string { return "snowball-en" }
+
+func (englishStemmer) Stem(word string) string {
+	return english.Stem(word, false)
+}
+
+// PolishStemmer returns a light stemmer of Polish stripping inflectional
+// suffixes, so "doświadczenie", "doświadczenia" and "doświadczeń" share
+// the stem "doświadczen".
+func PolishStemmer() Stemmer {
+	return polishStemmer{}
+}
+
+type polishStemmer struct{}
+
+// Minimum number of letters left after a suffix is stripped.
+const minPolishStem = 3
+
+// polishReplacements replace suffixes of abstract nouns with their
+// nominative, so "znajomości" is "znajomość".
+var polishReplacements = map[string]string{
+	"ościami": "ość",
+	"ościach": "ość",
+	"ością":   "ość",
+	"ości":    "ość",
+}
+
+// polishSuffixes are inflectional suffixes of nouns, adjectives and verbs,
+// the longest first.
+var polishSuffixes = func() []string {
+	suffixes := []string{
+		"aniami", "aniach", "aniem", "ania", "anie", "aniu",
+		"iami", "iach", "owie", "ują", "uje",
+		"ami", "ach", "ego", "emu", "ymi", "imi", "ych", "ich", "iem", "iom",
+		"ów", "om", "em", "ej", "ym", "im", "ie", "ia", "iu", "ią", "ię",
+		"ać", "eć", "ić", "yć",
+		"y", "i", "a", "e", "u", "ę", "ą", "o",
+	}
+	slices.SortStableFunc(suffixes, func(a, b string) int {
+		return utf8.RuneCountInString(b) - utf8.RuneCountInString(a)
+	})
+
+	return suffixes
+}()
+
+func (polishStemmer) Name() string { return "light-pl" }
+
+func (polishStemmer) Stem(word string) string {
+	word = strings.ToLower(strings.TrimSpace(word))
+
+	for suffix, replacement := range polishReplacements {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && utf8.RuneCountInString(stem) >= minPolishStem {
+			return stem + replacement
+		}
+	}
+	for _, suffix := range polishSuffixes {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && utf8.RuneCountInString(stem) >= minPolishStem {
+			word = stem
+
+			break
+		}
+	}
+	// Soft consonants of genitive plural, "doświadczeń" is "doświadczen"
+	if stem, ok := strings.CutSuffix(word, "ń"); ok {
+		word = stem + "n"
+	}
+
+	return word
+}
+
+// Stemming reduces words of lines to their stems with a stemmer of
+// a language detected per line, see LanguageDetector. Lines of undetected
+// language are left as they are.
+//
+// Stemming is safe for concurrent use.
+type Stemming struct {
+	detector *LanguageDetector
+	stemmers map[string]Stemmer
+	keep     map[string]bool
+}
+
+// NewStemming returns English and Polish stemming which keeps terms intact,
+// e.g. canonical forms of aliases, so "kubernetes" is not "kubernet".
+func NewStemming(keep ...string) *Stemming {
+	s := &Stemming{
+		detector: DefaultLanguageDetector(),
+		stemmers: map[string]Stemmer{
+			"en": EnglishStemmer(),
+			"pl": PolishStemmer(),
+		},
+		keep: make(map[string]bool, len(keep)),
+	}
+	for _, term := range keep {
+		s.keep[strings.ToLower(term)] = true
+	}
+
+	return s
+}
+
+// NewTermStemming returns stemming which keeps canonical forms of aliases
+// a and skill terms of taxonomy t intact.
+func NewTermStemming(a Aliases, t Taxonomy) *Stemming {
+	return NewStemming(slices.Concat(
+		slices.Collect(maps.Values(a)),
+		slices.Collect(maps.Keys(t)),
+	)...)
+}
+
+// Line returns stems of tokens of a line and a stemmer which produced them,
+// tokens as they are and nil if language of the line is not detected.
+// Only words made of letters are stemmed.
+func (s *Stemming) Line(line string, tokens []string) ([]string, Stemmer) {
+	stemmer, ok := s.stemmers[s.detector.Language(line)]
+	if !ok {
+		return tokens, nil
+	}
+
+	stems := make([]string, len(tokens))
+	for i, token := range tokens {
+		stems[i] = token
+		if !s.keep[token] && isWord(token) {
+			stems[i] = stemmer.Stem(token)
+		}
+	}
+
+	return stems, stemmer
+}
+
+// Words returns stems of tokens of every line of text, split into tokens
+// by tokens, and sorted names of stemmers which produced them.
+func (s *Stemming) Words(text string, tokens func(line string) []string) ([]string, []string) {
+	words := make([]string, 0)
+	names := make([]string, 0)
+	for line := range ScanLines(text) {
+		stems, stemmer := s.Line(line, tokens(line))
+		words = append(words, stems...)
+		if stemmer != nil && !slices.Contains(names, stemmer.Name()) {
+			names = append(names, stemmer.Name())
+		}
+	}
+	slices.Sort(names)
+
+	return words, names
+}
+
+// isWord reports whether s is made of letters only.
+func isWord(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return s != ""
+}
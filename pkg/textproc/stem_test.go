@@ -0,0 +1,93 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStemmers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		stemmer textproc.Stemmer
+		words   []string
+		want    string
+	}{
+		{
+			desc: "english_verb_and_noun",
+
+			stemmer: textproc.EnglishStemmer(),
+			words:   []string{"deploy", "deploying", "deployed", "deployments"},
+			want:    "deploy",
+		},
+		{
+			desc: "polish_noun",
+
+			stemmer: textproc.PolishStemmer(),
+			words:   []string{"doświadczenie", "doświadczenia", "doświadczeniem", "doświadczeń"},
+			want:    "doświadczen",
+		},
+		{
+			desc: "polish_abstract_noun",
+
+			stemmer: textproc.PolishStemmer(),
+			words:   []string{"znajomość", "znajomości", "znajomością"},
+			want:    "znajomość",
+		},
+		{
+			desc: "polish_adjective",
+
+			stemmer: textproc.PolishStemmer(),
+			words:   []string{"nowoczesny", "nowoczesnego", "nowoczesnych", "nowoczesnej"},
+			want:    "nowoczesn",
+		},
+		{
+			desc: "polish_short_word",
+
+			stemmer: textproc.PolishStemmer(),
+			words:   []string{"ma"},
+			want:    "ma",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for _, w := range tC.words {
+				require.Equal(t, tC.want, tC.stemmer.Stem(w), w)
+			}
+		})
+	}
+}
+
+func TestStemmingWords(t *testing.T) {
+	t.Parallel()
+
+	s := textproc.NewStemming("kubernetes")
+	text := "We are deploying our services to Kubernetes every day\n" +
+		"Wymagamy doświadczenia w programowaniu w C++"
+
+	words, stemmers := s.Words(text, textproc.Tokens)
+	require.Equal(t, []string{
+		"we", "are", "deploy", "our", "servic", "to", "kubernetes", "everi", "day",
+		"wymagam", "doświadczen", "w", "programow", "w", "c++",
+	}, words)
+	require.Equal(t, []string{"light-pl", "snowball-en"}, stemmers)
+}
+
+func TestTermStemmingKeepsTerms(t *testing.T) {
+	t.Parallel()
+
+	s := textproc.NewTermStemming(
+		textproc.Aliases{"k8s": "kubernetes"},
+		textproc.Taxonomy{"jenkins": "tools"},
+	)
+	stems, stemmer := s.Line("We are deploying services with Jenkins to Kubernetes",
+		[]string{"deploying", "services", "jenkins", "kubernetes"})
+	require.NotNil(t, stemmer)
+	require.Equal(t, []string{"deploy", "servic", "jenkins", "kubernetes"}, stems)
+}
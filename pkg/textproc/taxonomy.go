@@ -0,0 +1,163 @@
+package textproc
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+//go:embed taxonomy.txt
+var defaultTaxonomy []byte
+
+var (
+	ErrInvalidTaxonomy = errors.New("invalid taxonomy")
+	ErrUnknownCategory = errors.New("unknown category")
+)
+
+// Taxonomy maps skill terms, e.g. "postgresql" or "github actions", to their
+// category, e.g. "databases". Terms are canonical forms of Aliases.
+type Taxonomy map[string]string
+
+// DefaultTaxonomy returns taxonomy of common skills shipped with the package.
+func DefaultTaxonomy() Taxonomy {
+	t, err := ParseTaxonomy(bytes.NewReader(defaultTaxonomy))
+	if err != nil {
+		panic(fmt.Sprintf("default taxonomy: %v", err))
+	}
+
+	return t
+}
+
+// ParseTaxonomy parses lines of format "category: term, term". Blank lines
+// and lines starting with # are skipped.
+func ParseTaxonomy(r io.Reader) (Taxonomy, error) {
+	t := make(Taxonomy)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		category, list, ok := strings.Cut(line, ":")
+		category = strings.ToLower(strings.TrimSpace(category))
+		if !ok || category == "" {
+			return nil, fmt.Errorf("%w: line %d: want category: term, term", ErrInvalidTaxonomy, n)
+		}
+		for _, term := range strings.Split(list, ",") {
+			term = strings.Join(strings.Fields(strings.ToLower(term)), " ")
+			if term == "" {
+				continue
+			}
+			if c, ok := t[term]; ok {
+				return nil, fmt.Errorf("%w: line %d: %q is already in %q", ErrInvalidTaxonomy, n, term, c)
+			}
+			t[term] = category
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+
+	return t, nil
+}
+
+// Edit moves term to category. Empty category removes term.
+func (t Taxonomy) Edit(term, category string) {
+	term = strings.Join(strings.Fields(strings.ToLower(term)), " ")
+	if category = strings.ToLower(strings.TrimSpace(category)); category == "" {
+		delete(t, term)
+
+		return
+	}
+	t[term] = category
+}
+
+// Category returns category of a term, a token or an n-gram.
+func (t Taxonomy) Category(term string) (string, bool) {
+	c, ok := t[strings.ToLower(term)]
+
+	return c, ok
+}
+
+// Categories returns sorted names of categories.
+func (t Taxonomy) Categories() []string {
+	categories := slices.Sorted(maps.Values(t))
+
+	return slices.Compact(categories)
+}
+
+// Terms returns sorted terms of categories. Fails if any of them has no terms.
+func (t Taxonomy) Terms(categories ...string) ([]string, error) {
+	terms := make([]string, 0)
+	for _, category := range categories {
+		category = strings.ToLower(strings.TrimSpace(category))
+
+		n := len(terms)
+		for term, c := range t {
+			if c == category {
+				terms = append(terms, term)
+			}
+		}
+		if len(terms) == n {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownCategory, category)
+		}
+	}
+	slices.Sort(terms)
+
+	return slices.Compact(terms), nil
+}
+
+// Tag is a term of a taxonomy found in text.
+type Tag struct {
+	Term     string `json:"term"`
+	Category string `json:"category"`
+}
+
+// Match tags terms found in tokens, the longest matching n-gram first,
+// so "spring boot" is a single term rather than "spring" and "boot".
+func (t Taxonomy) Match(tokens []string) []Tag {
+	longest := 1
+	for term := range t {
+		longest = max(longest, strings.Count(term, " ")+1)
+	}
+
+	tags := make([]Tag, 0)
+	for i := 0; i < len(tokens); {
+		n := min(longest, len(tokens)-i)
+		for ; n > 0; n-- {
+			term := strings.Join(tokens[i:i+n], " ")
+			if c, ok := t[term]; ok {
+				tags = append(tags, Tag{Term: term, Category: c})
+
+				break
+			}
+		}
+		i += max(n, 1)
+	}
+
+	return tags
+}
+
+// GroupByCategory groups items by category of their term, keeping order of
+// items and at most limit items of every category, all if limit is 0.
+// Items without category are skipped.
+func GroupByCategory[T any](t Taxonomy, items []T, term func(T) string, limit int) map[string][]T {
+	groups := make(map[string][]T)
+	for _, item := range items {
+		c, ok := t.Category(term(item))
+		if !ok || (limit > 0 && len(groups[c]) == limit) {
+			continue
+		}
+		groups[c] = append(groups[c], item)
+	}
+
+	return groups
+}
@@ -0,0 +1,139 @@
+package textproc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTaxonomy(t *testing.T) {
+	t.Parallel()
+
+	tax := textproc.DefaultTaxonomy()
+
+	for term, want := range map[string]string{
+		"go":           "languages",
+		"aws":          "clouds",
+		"postgresql":   "databases",
+		"terraform":    "tools",
+		"ci/cd":        "practices",
+		"spring boot":  "frameworks",
+		"Kubernetes":   "tools",
+		"machine shop": "",
+	} {
+		c, ok := tax.Category(term)
+		require.Equal(t, want != "", ok, term)
+		require.Equal(t, want, c, term)
+	}
+	require.Equal(t,
+		[]string{"clouds", "databases", "frameworks", "languages", "practices", "tools"},
+		tax.Categories(),
+	)
+
+	// Every term is a canonical form of aliases
+	aliases := textproc.DefaultAliases()
+	for term := range tax {
+		require.Equal(t, term, aliases.Normalize(term))
+	}
+}
+
+func TestParseTaxonomy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		text    string
+		want    textproc.Taxonomy
+		wantErr error
+	}{
+		{
+			desc: "categories_and_terms",
+
+			text: "# comment\n\nDatabases: PostgreSQL,  MS   SQL\ntools: docker,\n",
+			want: textproc.Taxonomy{
+				"postgresql": "databases",
+				"ms sql":     "databases",
+				"docker":     "tools",
+			},
+		},
+		{
+			desc: "missing_colon",
+
+			text:    "databases postgresql",
+			wantErr: textproc.ErrInvalidTaxonomy,
+		},
+		{
+			desc: "term_of_two_categories",
+
+			text:    "tools: docker\nclouds: docker",
+			wantErr: textproc.ErrInvalidTaxonomy,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tax, err := textproc.ParseTaxonomy(strings.NewReader(tC.text))
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tC.want, tax)
+		})
+	}
+}
+
+func TestTaxonomyTerms(t *testing.T) {
+	t.Parallel()
+
+	tax := textproc.Taxonomy{
+		"postgresql": "databases",
+		"redis":      "databases",
+		"aws":        "clouds",
+		"docker":     "tools",
+	}
+	tax.Edit("Redis", "")
+	tax.Edit("kafka", "Tools")
+
+	terms, err := tax.Terms("databases", "tools")
+	require.NoError(t, err)
+	require.Equal(t, []string{"docker", "kafka", "postgresql"}, terms)
+
+	_, err = tax.Terms("clouds", "hobbies")
+	require.ErrorIs(t, err, textproc.ErrUnknownCategory)
+}
+
+func TestTaxonomyMatch(t *testing.T) {
+	t.Parallel()
+
+	tax := textproc.DefaultTaxonomy()
+	tokens := textproc.DefaultAliases().NormalizeAll(
+		textproc.Tokens("Golang, Spring Boot on AWS; GitHub Actions and event driven architecture"),
+	)
+
+	require.Equal(t, []textproc.Tag{
+		{Term: "go", Category: "languages"},
+		{Term: "spring boot", Category: "frameworks"},
+		{Term: "aws", Category: "clouds"},
+		{Term: "github actions", Category: "tools"},
+		{Term: "event driven architecture", Category: "practices"},
+	}, tax.Match(tokens))
+}
+
+func TestGroupByCategory(t *testing.T) {
+	t.Parallel()
+
+	tax := textproc.DefaultTaxonomy()
+	ranked := []string{"go", "experience", "postgresql", "python", "redis", "rust", "mysql"}
+
+	groups := textproc.GroupByCategory(tax, ranked, func(s string) string { return s }, 2)
+	require.Equal(t, map[string][]string{
+		"languages": {"go", "python"},
+		"databases": {"postgresql", "redis"},
+	}, groups)
+}
@@ -0,0 +1,77 @@
+package textproc
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// TermScore is a score of a term within a document.
+type TermScore struct {
+	Term  string  `json:"term"`
+	Score float64 `json:"score"`
+}
+
+// LoadTextAnalysis reads an analysis written as json to file at path.
+func LoadTextAnalysis(path string) (*TextAnalysis, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+	analysis := new(TextAnalysis)
+	if err := json.Unmarshal(data, analysis); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+
+	return analysis, nil
+}
+
+// TFIDF scores words of every analysis, each treated as a document, by term
+// frequency times inverse document frequency, ln((1+N)/(1+df)). Words
+// occurring in every analysis, such as filler words, score 0, while words
+// frequent in few analyses score highest.
+//
+// Returns scores of words of every analysis in order of analyses, sorted
+// highest first.
+func TFIDF(analyses ...*TextAnalysis) [][]TermScore {
+	frequencies := make([]map[string]int, len(analyses))
+	df := make(map[string]int)
+	for i, ta := range analyses {
+		ta.mu.Lock()
+		frequencies[i] = make(map[string]int, len(ta.WordFrequency))
+		for word, count := range ta.WordFrequency {
+			if count > 0 {
+				frequencies[i][word] = count
+				df[word]++
+			}
+		}
+		ta.mu.Unlock()
+	}
+
+	n := float64(len(analyses))
+	scores := make([][]TermScore, len(analyses))
+	for i, frequency := range frequencies {
+		size := 0
+		for _, count := range frequency {
+			size += count
+		}
+
+		scores[i] = make([]TermScore, 0, len(frequency))
+		for word, count := range frequency {
+			idf := math.Log((1 + n) / float64(1+df[word]))
+			scores[i] = append(scores[i], TermScore{
+				Term:  word,
+				Score: float64(count) / float64(size) * idf,
+			})
+		}
+		slices.SortFunc(scores[i], func(a, b TermScore) int {
+			return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Term, b.Term))
+		})
+	}
+
+	return scores
+}
@@ -0,0 +1,77 @@
+package textproc_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAnalysis(t *testing.T, words ...string) *textproc.TextAnalysis {
+	t.Helper()
+
+	analysis, err := textproc.AnalyzeWordsFrequency(words)
+	require.NoError(t, err)
+
+	return analysis
+}
+
+func TestTFIDF(t *testing.T) {
+	t.Parallel()
+
+	scores := textproc.TFIDF(
+		newTestAnalysis(t, "experience", "team", "go", "go", "go", "kubernetes"),
+		newTestAnalysis(t, "experience", "team", "java", "spring"),
+		newTestAnalysis(t, "experience", "go", "react"),
+	)
+	require.Len(t, scores, 3)
+
+	// Word of every analysis is not distinctive at all
+	for _, s := range scores {
+		i := indexTerm(s, "experience")
+		require.NotEqual(t, -1, i)
+		require.Zero(t, s[i].Score)
+	}
+
+	require.Equal(t, "go", scores[0][0].Term)
+	require.Equal(t, "kubernetes", scores[0][1].Term)
+	require.Equal(t, []string{"java", "spring"}, []string{scores[1][0].Term, scores[1][1].Term})
+	require.Equal(t, "react", scores[2][0].Term)
+	for _, s := range scores {
+		for i := 1; i < len(s); i++ {
+			require.GreaterOrEqual(t, s[i-1].Score, s[i].Score)
+		}
+	}
+}
+
+func indexTerm(scores []textproc.TermScore, term string) int {
+	for i, s := range scores {
+		if s.Term == term {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func TestLoadTextAnalysis(t *testing.T) {
+	t.Parallel()
+
+	analysis := newTestAnalysis(t, "go", "go", "docker")
+	data, err := json.Marshal(analysis)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "analysis.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	loaded, err := textproc.LoadTextAnalysis(path)
+	require.NoError(t, err)
+	require.Equal(t, analysis.ID, loaded.ID)
+	require.Equal(t, map[string]int{"go": 2, "docker": 1}, loaded.WordFrequency)
+
+	_, err = textproc.LoadTextAnalysis(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
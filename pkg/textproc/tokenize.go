@@ -0,0 +1,133 @@
+package textproc
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultProtectedTerms are technology names containing punctuation or
+// symbols which must survive tokenization as they are.
+var DefaultProtectedTerms = []string{
+	".net",
+	"asp.net",
+	"c#",
+	"c++",
+	"ci/cd",
+	"f#",
+	"express.js",
+	"next.js",
+	"node.js",
+	"nuxt.js",
+	"objective-c",
+	"pl/sql",
+	"react.js",
+	"t-sql",
+	"tcp/ip",
+	"ui/ux",
+	"vue.js",
+}
+
+// Tokenizer splits text into lower case words.
+//
+// Punctuation around words is stripped, so "Kubernetes," and "kubernetes"
+// are the same token. Terms of a protected vocabulary are kept intact, e.g.
+// "C++," is "c++". Hyphenated words such as "real-time" are single tokens,
+// while words joined by a slash are alternatives and split, "Go/Python" is
+// "go" and "python". Tokens without letters or digits are dropped.
+//
+// Tokenizer is safe for concurrent use.
+type Tokenizer struct {
+	protected map[string]bool
+}
+
+// NewTokenizer returns a tokenizer protecting DefaultProtectedTerms and
+// terms, matched case insensitively.
+func NewTokenizer(terms ...string) *Tokenizer {
+	t := &Tokenizer{
+		protected: make(map[string]bool, len(DefaultProtectedTerms)+len(terms)),
+	}
+	for _, term := range slices.Concat(DefaultProtectedTerms, terms) {
+		if term = strings.ToLower(strings.TrimSpace(term)); term != "" {
+			t.protected[term] = true
+		}
+	}
+
+	return t
+}
+
+var defaultTokenizer = NewTokenizer()
+
+// Tokens splits text into tokens with a tokenizer protecting
+// DefaultProtectedTerms.
+func Tokens(text string) []string {
+	return defaultTokenizer.Tokens(text)
+}
+
+// Tokens splits text into tokens in order of appearance.
+func (t *Tokenizer) Tokens(text string) []string {
+	tokens := make([]string, 0)
+	for _, field := range strings.FieldsFunc(strings.ToLower(text), isSeparator) {
+		tokens = t.appendTokens(tokens, field)
+	}
+
+	return tokens
+}
+
+// isSeparator reports whether r separates words: a space or a dash which
+// is not a hyphen.
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '–' || r == '—'
+}
+
+func (t *Tokenizer) appendTokens(tokens []string, field string) []string {
+	s := t.trim(field)
+	if t.protected[s] {
+		return append(tokens, s)
+	}
+	if strings.Contains(s, "/") && !strings.Contains(s, "://") {
+		for _, part := range strings.Split(s, "/") {
+			tokens = t.appendTokens(tokens, part)
+		}
+
+		return tokens
+	}
+	if strings.Contains(s, "--") {
+		for _, part := range strings.Split(s, "--") {
+			tokens = t.appendTokens(tokens, part)
+		}
+
+		return tokens
+	}
+	if !strings.ContainsFunc(s, isWordRune) {
+		return tokens
+	}
+
+	return append(tokens, s)
+}
+
+// trim strips punctuation from the end and then from the start of s,
+// stopping as soon as s is a protected term.
+func (t *Tokenizer) trim(s string) string {
+	for s != "" && !t.protected[s] {
+		r, size := utf8.DecodeLastRuneInString(s)
+		if !unicode.IsPunct(r) {
+			break
+		}
+		s = s[:len(s)-size]
+	}
+	for s != "" && !t.protected[s] {
+		r, size := utf8.DecodeRuneInString(s)
+		if !unicode.IsPunct(r) {
+			break
+		}
+		s = s[size:]
+	}
+
+	return s
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
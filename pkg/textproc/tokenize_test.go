@@ -0,0 +1,83 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokens(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+
+		text string
+		want []string
+	}{
+		{
+			desc: "trailing_punctuation_is_stripped",
+
+			text: "Docker, Kubernetes. (Helm) \"Terraform\"!",
+			want: []string{"docker", "kubernetes", "helm", "terraform"},
+		},
+		{
+			desc: "protected_terms_are_kept",
+
+			text: "C++, C#; Node.js. .NET (CI/CD)",
+			want: []string{"c++", "c#", "node.js", ".net", "ci/cd"},
+		},
+		{
+			desc: "slash_joined_terms_are_alternatives",
+
+			text: "Go/Python, C/C++ and hands-on/production",
+			want: []string{"go", "python", "c", "c++", "and", "hands-on", "production"},
+		},
+		{
+			desc: "hyphenated_words_are_single_tokens",
+
+			text: "real-time, high-load systems - remote – or — hybrid -- onsite",
+			want: []string{"real-time", "high-load", "systems", "remote", "or", "hybrid", "onsite"},
+		},
+		{
+			desc: "punctuation_only_is_dropped",
+
+			text: "• Go ... +/- | *",
+			want: []string{"go"},
+		},
+		{
+			desc: "versions_and_urls",
+
+			text: "Go 1.22, Java 17+ https://go.dev/doc/",
+			want: []string{"go", "1.22", "java", "17+", "https://go.dev/doc"},
+		},
+		{
+			desc: "empty",
+
+			text: " \n\t",
+			want: []string{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tC.want, textproc.Tokens(tC.text))
+		})
+	}
+}
+
+func TestTokenizerProtectedTerms(t *testing.T) {
+	t.Parallel()
+
+	text := "Experience with Vue.js, ASP.NET and Socket.IO."
+
+	require.Equal(t,
+		[]string{"experience", "with", "vue.js", "asp.net", "and", "socket.io"},
+		textproc.Tokens(text),
+	)
+
+	tok := textproc.NewTokenizer("C++/CLI", " ")
+	require.Equal(t, []string{"c++/cli", "c++", "qt"}, tok.Tokens("C++/CLI, C++/Qt/"))
+}
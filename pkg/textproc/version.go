@@ -0,0 +1,120 @@
+package textproc
+
+import (
+	"regexp"
+	"strings"
+)
+
+// TechnologyVersion is a version of a technology asked for by a line of
+// a job offer, e.g. "Go 1.22" or "Java 17".
+type TechnologyVersion struct {
+	// Term is a technology of a taxonomy followed by the version.
+	Term    string `json:"term"`
+	Version string `json:"version"`
+	// Source is the line the version is found in.
+	Source string `json:"source"`
+}
+
+// versionRe matches tokens of versions, e.g. "1.22", "v3", "3.x" and "17+".
+var versionRe = regexp.MustCompile(`^v?(\d{1,3}(?:\.\d{1,3}){0,2})(?:\.x|\+)?$`)
+
+// versionWords precede a version following a technology, e.g. "version" of
+// "Java version 17".
+var versionWords = map[string]bool{"version": true, "versions": true, "wersja": true, "wersji": true, "v": true}
+
+// versionedTerms are technologies commonly asked for in major versions,
+// e.g. "Java 17", whose versions may be plain numbers. Versions of other
+// technologies are dotted, e.g. "Go 1.22", unless prefixed with "v" or
+// a word of version, so "go 2 times a week" states no version.
+var versionedTerms = map[string]bool{
+	"java": true, "python": true, "php": true, "scala": true, "typescript": true, "c++": true, "c#": true,
+	"react": true, "angular": true, "vue": true, "node.js": true, ".net": true, "spring": true, "spring boot": true,
+	"django": true, "laravel": true, "rails": true,
+	"postgresql": true, "mysql": true, "oracle": true, "redis": true, "mongodb": true, "elasticsearch": true,
+}
+
+// versionJoiners join alternative versions, e.g. "or" of "Java 17 or 21".
+var versionJoiners = map[string]bool{"or": true, "and": true, "lub": true, "albo": true, "i": true, "oraz": true}
+
+// VersionExtractor finds versions of technologies of a taxonomy asked for
+// by lines of job offers.
+//
+// VersionExtractor is safe for concurrent use.
+type VersionExtractor struct {
+	taxonomy Taxonomy
+	aliases  Aliases
+	longest  int
+}
+
+// NewVersionExtractor returns an extractor of versions of terms of t, found
+// in tokens normalized with a.
+func NewVersionExtractor(t Taxonomy, a Aliases) *VersionExtractor {
+	return &VersionExtractor{
+		taxonomy: t,
+		aliases:  a,
+		longest:  longestTerm(t),
+	}
+}
+
+// Line returns versions of technologies of a line, each a version directly
+// following a technology, e.g. "Go 1.22", "Python 3.x" or "Java 17/21".
+// Numbers of years, e.g. "Go 3 years", are not versions, and neither are
+// plain numbers following technologies other than versionedTerms.
+func (e *VersionExtractor) Line(line string) []TechnologyVersion {
+	tokens := e.aliases.NormalizeAll(Tokens(line))
+
+	versions := make([]TechnologyVersion, 0)
+	for _, p := range termPositions(e.taxonomy, e.longest, tokens) {
+		i := p.index + p.size
+		plain := versionedTerms[p.term]
+		if i < len(tokens) && versionWords[tokens[i]] {
+			i++
+			plain = true
+		}
+		for i < len(tokens) {
+			v, ok := parseVersion(tokens[i:], plain)
+			if !ok {
+				break
+			}
+			versions = append(versions, TechnologyVersion{
+				Term:    p.term,
+				Version: v,
+				Source:  strings.TrimSpace(line),
+			})
+			i++
+			if i+1 < len(tokens) && versionJoiners[tokens[i]] {
+				i++
+			}
+		}
+	}
+
+	return versions
+}
+
+// Versions returns versions of technologies of lines of text, see ScanLines.
+func (e *VersionExtractor) Versions(text string) []TechnologyVersion {
+	versions := make([]TechnologyVersion, 0)
+	for line := range ScanLines(text) {
+		versions = append(versions, e.Line(line)...)
+	}
+
+	return versions
+}
+
+// parseVersion parses a version of the first of tokens, not followed by
+// a word of years. A plain number, e.g. "17" but not "v17" or "1.22", is
+// a version only if plain is true.
+func parseVersion(tokens []string, plain bool) (string, bool) {
+	m := versionRe.FindStringSubmatch(tokens[0])
+	if m == nil {
+		return "", false
+	}
+	if !plain && !strings.HasPrefix(tokens[0], "v") && !strings.Contains(tokens[0], ".") {
+		return "", false
+	}
+	if len(tokens) > 1 && experienceYears[tokens[1]] {
+		return "", false
+	}
+
+	return m[1], true
+}
@@ -0,0 +1,92 @@
+package textproc_test
+
+import (
+	"testing"
+
+	"github.com/kndrad/piccrack/pkg/textproc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionExtractorLine(t *testing.T) {
+	t.Parallel()
+
+	e := textproc.NewVersionExtractor(textproc.DefaultTaxonomy(), textproc.DefaultAliases())
+
+	testCases := []struct {
+		desc string
+
+		line string
+		want []textproc.TechnologyVersion
+	}{
+		{
+			desc: "minor_version_of_an_alias",
+
+			line: "Strong Golang 1.22 skills",
+			want: []textproc.TechnologyVersion{{Term: "go", Version: "1.22"}},
+		},
+		{
+			desc: "alternative_versions",
+
+			line: "Java 17/21, Python 3.x or PostgreSQL v16",
+			want: []textproc.TechnologyVersion{
+				{Term: "java", Version: "17"},
+				{Term: "java", Version: "21"},
+				{Term: "python", Version: "3"},
+				{Term: "postgresql", Version: "16"},
+			},
+		},
+		{
+			desc: "versions_joined_by_a_word",
+
+			line: "Znajomość Java 17 oraz 21 lub Python 3",
+			want: []textproc.TechnologyVersion{
+				{Term: "java", Version: "17"},
+				{Term: "java", Version: "21"},
+				{Term: "python", Version: "3"},
+			},
+		},
+		{
+			desc: "years_are_not_versions",
+
+			line: "Go 3+ years and Java 5 lat",
+			want: []textproc.TechnologyVersion{},
+		},
+		{
+			desc: "prefixed_versions",
+
+			line: "Docker v24 and Terraform version 1",
+			want: []textproc.TechnologyVersion{
+				{Term: "docker", Version: "24"},
+				{Term: "terraform", Version: "1"},
+			},
+		},
+		{
+			desc: "plain_number_after_a_verb",
+
+			line: "We go 2 times a week",
+			want: []textproc.TechnologyVersion{},
+		},
+		{
+			desc: "plain_number_after_a_letter",
+
+			line: "C 3 razy w tygodniu",
+			want: []textproc.TechnologyVersion{},
+		},
+		{
+			desc: "no_versions",
+
+			line: "Experience with Go and Docker",
+			want: []textproc.TechnologyVersion{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			for i := range tC.want {
+				tC.want[i].Source = tC.line
+			}
+			require.Equal(t, tC.want, e.Line(tC.line))
+		})
+	}
+}